@@ -113,6 +113,16 @@ func (abi ABI) Unpack(name string, data []byte) ([]interface{}, error) {
 	return args.Unpack(data)
 }
 
+// UnpackNamed unpacks the output according to the abi specification like Unpack,
+// but returns the tuples as maps keyed by their component names.
+func (abi ABI) UnpackNamed(name string, data []byte) ([]interface{}, error) {
+	args, err := abi.getArguments(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return args.UnpackNamed(data)
+}
+
 // UnpackIntoInterface unpacks the output in v according to the abi specification.
 // It performs an additional copy. Please only use, if you want to unpack into a
 // structure that does not strictly conform to the abi structure (e.g. has additional arguments)
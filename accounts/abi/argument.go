@@ -87,6 +87,20 @@ func (arguments Arguments) Unpack(data []byte) ([]interface{}, error) {
 	return arguments.UnpackValues(data)
 }
 
+// UnpackNamed performs the operation hexdata -> Go format like Unpack, but returns
+// the tuples as map[string]interface{} keyed by their component names instead of
+// anonymous structs, allowing their fields to be accessed without reflection.
+func (arguments Arguments) UnpackNamed(data []byte) ([]interface{}, error) {
+	values, err := arguments.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	for i, arg := range arguments.NonIndexed() {
+		values[i] = namedTuples(&arg.Type, reflect.ValueOf(values[i]))
+	}
+	return values, nil
+}
+
 // UnpackIntoMap performs the operation hexdata -> mapping of argument name to argument value.
 func (arguments Arguments) UnpackIntoMap(v map[string]interface{}, data []byte) error {
 	// Make sure map is not nil
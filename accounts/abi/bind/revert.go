@@ -0,0 +1,139 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrTransactionReverted is returned if the execution of a mined transaction failed.
+var ErrTransactionReverted = errors.New("transaction reverted")
+
+// RevertError is the error of a failed transaction, carrying the revert reason
+// recovered by replaying it. It wraps ErrTransactionReverted.
+type RevertError struct {
+	Reason string // Decoded revert reason or custom error, empty if not recovered
+	Data   []byte // Raw revert data, nil if not recovered
+}
+
+// Error implements error.
+func (e *RevertError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%v: %s", ErrTransactionReverted, e.Reason)
+	}
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("%v: %#x", ErrTransactionReverted, e.Data)
+	}
+	return ErrTransactionReverted.Error()
+}
+
+// Unwrap returns ErrTransactionReverted.
+func (e *RevertError) Unwrap() error {
+	return ErrTransactionReverted
+}
+
+// WaitMinedWithReason waits for tx to be mined like WaitMined. If its execution
+// failed, the receipt is returned along with a *RevertError. If the backend is
+// also a ContractCaller, the transaction is replayed as a call on the state of
+// the parent block to recover the revert reason, decoding custom errors with the
+// optional contract ABI. If the backend can't replay the transaction, e.g. because
+// the historical state is not available, the error is returned without reason.
+func WaitMinedWithReason(ctx context.Context, b DeployBackend, tx *types.Transaction, contractABI *abi.ABI) (*types.Receipt, error) {
+	receipt, err := WaitMined(ctx, b, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return receipt, nil
+	}
+	caller, ok := b.(ContractCaller)
+	if !ok {
+		return receipt, &RevertError{}
+	}
+	return receipt, replayRevert(ctx, caller, tx, receipt, contractABI)
+}
+
+// replayRevert replays a failed transaction to recover its revert reason.
+func replayRevert(ctx context.Context, caller ContractCaller, tx *types.Transaction, receipt *types.Receipt, contractABI *abi.ABI) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return &RevertError{}
+	}
+	msg := ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	var number *big.Int
+	if receipt.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		number = new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	}
+	_, callErr := caller.CallContract(ctx, msg, number)
+	if callErr == nil {
+		// The revert is not reproducible at the replayed state
+		return &RevertError{}
+	}
+	derr, ok := callErr.(interface{ ErrorData() interface{} })
+	if !ok {
+		log.Debug("Failed to replay reverted transaction", "hash", tx.Hash(), "err", callErr)
+		return &RevertError{}
+	}
+	hex, _ := derr.ErrorData().(string)
+	data, err := hexutil.Decode(hex)
+	if err != nil || len(data) == 0 {
+		return &RevertError{Reason: callErr.Error()}
+	}
+	return &RevertError{Reason: decodeRevert(data, contractABI), Data: data}
+}
+
+// decodeRevert decodes revert data as a revert reason string, a panic code or one
+// of the custom errors of the contract ABI, returning empty if none matches.
+func decodeRevert(data []byte, contractABI *abi.ABI) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if contractABI == nil || len(data) < 4 {
+		return ""
+	}
+	abiErr, err := contractABI.ErrorByID([4]byte(data[:4]))
+	if err != nil {
+		return ""
+	}
+	unpacked, err := abiErr.Unpack(data)
+	if err != nil {
+		return abiErr.Name
+	}
+	args, _ := unpacked.([]interface{})
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		strs[i] = fmt.Sprint(arg)
+	}
+	return fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(strs, ", "))
+}
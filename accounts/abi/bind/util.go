@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -89,3 +90,11 @@ func WaitDeployedHash(ctx context.Context, b DeployBackend, hash common.Hash) (c
 	}
 	return receipt.ContractAddress, err
 }
+
+// CalcCreate2Address returns the address a contract is deployed to when created
+// by deployer through CREATE2 with the given salt and hash of the init code, as
+// specified by EIP-1014. It allows verifying the address of a contract deployed
+// through a factory before sending the deployment transaction.
+func CalcCreate2Address(deployer common.Address, salt [32]byte, initCodeHash common.Hash) common.Address {
+	return crypto.CreateAddress2(deployer, salt, initCodeHash[:])
+}
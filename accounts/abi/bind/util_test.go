@@ -17,15 +17,20 @@
 package bind_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient/simulated"
 	"github.com/ethereum/go-ethereum/params"
@@ -143,3 +148,94 @@ func TestWaitDeployedCornerCases(t *testing.T) {
 	}
 	cancel()
 }
+
+func TestWaitMinedWithReason(t *testing.T) {
+	backend := simulated.NewBackend(
+		types.GenesisAlloc{
+			crypto.PubkeyToAddress(testKey.PublicKey): {Balance: big.NewInt(10000000000000000)},
+		},
+	)
+	defer backend.Close()
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"error","name":"Denied","inputs":[{"name":"code","type":"uint256"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Deploy a contract reverting every call with Denied(42)
+	id := parsed.Errors["Denied"].ID
+	revert := append(id[:4:4], common.LeftPadBytes([]byte{42}, 32)...)
+	code := program.New().Mstore(revert, 0).Push(len(revert)).Push(0).Op(vm.REVERT).Bytes()
+
+	var (
+		ctx      = context.Background()
+		head, _  = backend.Client().HeaderByNumber(ctx, nil)
+		gasPrice = new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei))
+		signer   = types.LatestSignerForChainID(big.NewInt(1337))
+	)
+	deploy, _ := types.SignTx(types.NewContractCreation(0, big.NewInt(0), 100000, gasPrice, program.New().ReturnViaCodeCopy(code).Bytes()), signer, testKey)
+	if err := backend.Client().SendTransaction(ctx, deploy); err != nil {
+		t.Fatalf("failed to send deployment: %v", err)
+	}
+	backend.Commit()
+	receipt, err := bind.WaitMined(ctx, backend.Client(), deploy)
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("failed to deploy: %v", err)
+	}
+	tx, _ := types.SignTx(types.NewTransaction(1, receipt.ContractAddress, big.NewInt(0), 100000, gasPrice, nil), signer, testKey)
+	if err := backend.Client().SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	backend.Commit()
+
+	tests := []struct {
+		abi  *abi.ABI
+		want string
+	}{
+		{&parsed, "Denied(42)"},
+		{nil, ""},
+	}
+	for i, tt := range tests {
+		receipt, err = bind.WaitMinedWithReason(ctx, backend.Client(), tx, tt.abi)
+		if receipt == nil || receipt.Status != types.ReceiptStatusFailed {
+			t.Fatalf("test %d: transaction not failed", i)
+		}
+		var rerr *bind.RevertError
+		if !errors.As(err, &rerr) || !errors.Is(err, bind.ErrTransactionReverted) {
+			t.Fatalf("test %d: wrong error: %v", i, err)
+		}
+		if rerr.Reason != tt.want {
+			t.Errorf("test %d: reason mismatch: have %q, want %q", i, rerr.Reason, tt.want)
+		}
+		if !bytes.Equal(rerr.Data, revert) {
+			t.Errorf("test %d: revert data mismatch: have %x, want %x", i, rerr.Data, revert)
+		}
+	}
+}
+
+// Tests that CREATE2 addresses are calculated according to the examples of EIP-1014.
+func TestCalcCreate2Address(t *testing.T) {
+	tests := []struct {
+		deployer string
+		salt     string
+		initCode string
+		want     string
+	}{
+		{"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x00", "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38"},
+		{"0xdeadbeef00000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x00", "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3"},
+		{"0xdeadbeef00000000000000000000000000000000", "0x000000000000000000000000feed000000000000000000000000000000000000", "0x00", "0xD04116cDd17beBE565EB2422F2497E06cC1C9833"},
+		{"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0xdeadbeef", "0x70f2b2914A2a4b783FaEFb75f459A580616Fcb5e"},
+		{"0x00000000000000000000000000000000deadbeef", "0x00000000000000000000000000000000000000000000000000000000cafebabe", "0xdeadbeef", "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7"},
+		{"0x00000000000000000000000000000000deadbeef", "0x00000000000000000000000000000000000000000000000000000000cafebabe", "0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef", "0x1d8bfDC5D46DC4f61D6b6115972536eBE6A8854C"},
+		{"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x", "0xE33C0C7F7df4809055C3ebA6c09CFe4BaF1BD9e0"},
+	}
+	for i, tt := range tests {
+		var (
+			deployer = common.HexToAddress(tt.deployer)
+			salt     = common.HexToHash(tt.salt)
+			initHash = crypto.Keccak256Hash(common.FromHex(tt.initCode))
+		)
+		if have := bind.CalcCreate2Address(deployer, salt, initHash); have != common.HexToAddress(tt.want) {
+			t.Errorf("test %d: address mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var errNotStruct = errors.New("abi: EIP-712 hashing requires a tuple type")
+
+// EIP712Type returns the EIP-712 type encoding of the tuple type t, i.e. the
+// struct definition followed by the definitions of all the referenced structs
+// sorted by name:
+//
+//	Mail(Person from,Person to,string contents)Person(string name,address wallet)
+//
+// The struct names are taken from the internal type of the ABI definition, so
+// all tuples referenced by t need to be named.
+func (t Type) EIP712Type() (string, error) {
+	if t.T != TupleTy {
+		return "", errNotStruct
+	}
+	deps := make(map[string]string)
+	if err := collectEIP712Structs(t, deps); err != nil {
+		return "", err
+	}
+	primary := deps[t.TupleRawName]
+	delete(deps, t.TupleRawName)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	b.WriteString(primary)
+	for _, name := range names {
+		b.WriteString(deps[name])
+	}
+	return b.String(), nil
+}
+
+// EIP712TypeHash returns the hash of the EIP-712 type encoding of the tuple type t.
+func (t Type) EIP712TypeHash() (common.Hash, error) {
+	typ, err := t.EIP712Type()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte(typ)), nil
+}
+
+// EIP712Hash computes the EIP-712 struct hash of the given value, interpreted
+// as an instance of the tuple type t. The value needs to be a struct with the
+// same layout as accepted for packing the type.
+func (t Type) EIP712Hash(v interface{}) (common.Hash, error) {
+	if t.T != TupleTy {
+		return common.Hash{}, errNotStruct
+	}
+	return hashEIP712Struct(t, reflect.ValueOf(v))
+}
+
+// eip712TypeName returns the name of the type as used in EIP-712 type encodings.
+func eip712TypeName(t Type) (string, error) {
+	switch t.T {
+	case TupleTy:
+		if t.TupleRawName == "" {
+			return "", fmt.Errorf("abi: unnamed tuple %s can't be used with EIP-712", t.String())
+		}
+		return t.TupleRawName, nil
+	case SliceTy:
+		name, err := eip712TypeName(*t.Elem)
+		return name + "[]", err
+	case ArrayTy:
+		name, err := eip712TypeName(*t.Elem)
+		return name + "[" + strconv.Itoa(t.Size) + "]", err
+	case FunctionTy, FixedPointTy, HashTy:
+		return "", fmt.Errorf("abi: type %s can't be used with EIP-712", t.String())
+	default:
+		return t.String(), nil
+	}
+}
+
+// collectEIP712Structs gathers the member encodings of the structs referenced
+// by the given type, including itself, keyed by their names.
+func collectEIP712Structs(t Type, structs map[string]string) error {
+	switch t.T {
+	case SliceTy, ArrayTy:
+		return collectEIP712Structs(*t.Elem, structs)
+	case TupleTy:
+	default:
+		return nil
+	}
+	name, err := eip712TypeName(t)
+	if err != nil {
+		return err
+	}
+	if _, ok := structs[name]; ok {
+		return nil
+	}
+	members := make([]string, len(t.TupleElems))
+	for i, elem := range t.TupleElems {
+		typ, err := eip712TypeName(*elem)
+		if err != nil {
+			return err
+		}
+		members[i] = typ + " " + t.TupleRawNames[i]
+	}
+	structs[name] = name + "(" + strings.Join(members, ",") + ")"
+
+	for _, elem := range t.TupleElems {
+		if err := collectEIP712Structs(*elem, structs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashEIP712Struct computes hashStruct(s) = keccak256(typeHash ‖ encodeData(s)).
+func hashEIP712Struct(t Type, v reflect.Value) (common.Hash, error) {
+	v = indirect(v)
+	if v.Kind() != reflect.Struct {
+		return common.Hash{}, fmt.Errorf("abi: cannot use %v as type struct as argument", v.Type())
+	}
+	typeHash, err := t.EIP712TypeHash()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	fields, err := mapArgNamesToStructFields(t.TupleRawNames, v)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var buf bytes.Buffer
+	buf.Write(typeHash[:])
+	for i, elem := range t.TupleElems {
+		field := v.FieldByName(fields[t.TupleRawNames[i]])
+		if !field.IsValid() {
+			return common.Hash{}, fmt.Errorf("abi: field %s can't be found in the given value", t.TupleRawNames[i])
+		}
+		enc, err := encodeEIP712Value(*elem, field)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		buf.Write(enc)
+	}
+	return crypto.Keccak256Hash(buf.Bytes()), nil
+}
+
+// encodeEIP712Value returns the 32 byte encoding of a struct member.
+func encodeEIP712Value(t Type, v reflect.Value) ([]byte, error) {
+	v = indirect(v)
+	switch t.T {
+	case TupleTy:
+		hash, err := hashEIP712Struct(t, v)
+		return hash[:], err
+
+	case SliceTy, ArrayTy:
+		if err := typeCheck(t, v); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		for i := 0; i < v.Len(); i++ {
+			enc, err := encodeEIP712Value(*t.Elem, v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(enc)
+		}
+		return crypto.Keccak256(buf.Bytes()), nil
+
+	case StringTy:
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("abi: cannot use %v as type string as argument", v.Type())
+		}
+		return crypto.Keccak256([]byte(v.String())), nil
+
+	case BytesTy:
+		if v.Kind() == reflect.Array {
+			v = mustArrayToByteSlice(v)
+		}
+		if v.Type() != reflect.TypeOf([]byte{}) {
+			return nil, fmt.Errorf("abi: cannot use %v as type bytes as argument", v.Type())
+		}
+		return crypto.Keccak256(v.Bytes()), nil
+
+	default:
+		// Atomic types are encoded the same as in the ABI
+		if _, err := eip712TypeName(t); err != nil {
+			return nil, err
+		}
+		return t.pack(v)
+	}
+}
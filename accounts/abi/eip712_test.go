@@ -0,0 +1,183 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests the EIP-712 hashing against the example of the specification.
+func TestEIP712HashSpecExample(t *testing.T) {
+	person := []ArgumentMarshaling{
+		{Name: "name", Type: "string"},
+		{Name: "wallet", Type: "address"},
+	}
+	mailType, err := NewType("tuple", "struct Mail", []ArgumentMarshaling{
+		{Name: "from", Type: "tuple", InternalType: "struct Person", Components: person},
+		{Name: "to", Type: "tuple", InternalType: "struct Person", Components: person},
+		{Name: "contents", Type: "string"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	domainType, err := NewType("tuple", "struct EIP712Domain", []ArgumentMarshaling{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type Person struct {
+		Name   string
+		Wallet common.Address
+	}
+	mail := struct {
+		From     Person
+		To       Person
+		Contents string
+	}{
+		From:     Person{"Cow", common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")},
+		To:       Person{"Bob", common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")},
+		Contents: "Hello, Bob!",
+	}
+	domain := struct {
+		Name              string
+		Version           string
+		ChainId           *big.Int
+		VerifyingContract common.Address
+	}{"Ether Mail", "1", big.NewInt(1), common.HexToAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")}
+
+	typ, err := mailType.EIP712Type()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Mail(Person from,Person to,string contents)Person(string name,address wallet)"; typ != want {
+		t.Fatalf("type mismatch: have %q, want %q", typ, want)
+	}
+	typeHash, err := mailType.EIP712TypeHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"); typeHash != want {
+		t.Fatalf("type hash mismatch: have %x, want %x", typeHash, want)
+	}
+	mailHash, err := mailType.EIP712Hash(mail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"); mailHash != want {
+		t.Fatalf("struct hash mismatch: have %x, want %x", mailHash, want)
+	}
+	domainHash, err := domainType.EIP712Hash(&domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"); domainHash != want {
+		t.Fatalf("domain separator mismatch: have %x, want %x", domainHash, want)
+	}
+	digest := crypto.Keccak256Hash([]byte{0x19, 0x01}, domainHash[:], mailHash[:])
+	if want := common.HexToHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"); digest != want {
+		t.Fatalf("digest mismatch: have %x, want %x", digest, want)
+	}
+}
+
+// Tests the EIP-712 hashing of arrays, nested struct arrays and the various
+// atomic and dynamic types. The expected values are computed by the typed data
+// implementation of the signer.
+func TestEIP712HashArrays(t *testing.T) {
+	person := []ArgumentMarshaling{
+		{Name: "name", Type: "string"},
+		{Name: "wallets", Type: "address[]"},
+	}
+	mailType, err := NewType("tuple", "struct Mail", []ArgumentMarshaling{
+		{Name: "from", Type: "tuple", InternalType: "struct Person", Components: person},
+		{Name: "to", Type: "tuple[]", InternalType: "struct Person[]", Components: person},
+		{Name: "contents", Type: "string"},
+		{Name: "tags", Type: "bytes32[2]"},
+		{Name: "score", Type: "int8"},
+		{Name: "blob", Type: "bytes"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type Person struct {
+		Name    string
+		Wallets []common.Address
+	}
+	mail := struct {
+		From     Person
+		To       []Person
+		Contents string
+		Tags     [2][32]byte
+		Score    int8
+		Blob     []byte
+	}{
+		From: Person{"Cow", []common.Address{
+			common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"),
+			common.HexToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"),
+		}},
+		To: []Person{
+			{"Bob", []common.Address{common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")}},
+		},
+		Contents: "Hello, Bob!",
+		Tags:     [2][32]byte{{0x01}, {0x02}},
+		Score:    -5,
+		Blob:     common.FromHex("0xdeadbeef"),
+	}
+	typ, err := mailType.EIP712Type()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Mail(Person from,Person[] to,string contents,bytes32[2] tags,int8 score,bytes blob)Person(string name,address[] wallets)"; typ != want {
+		t.Fatalf("type mismatch: have %q, want %q", typ, want)
+	}
+	typeHash, err := mailType.EIP712TypeHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xe17db699c0a3300ab542a1a3e846ad8080d452ff5a767b9893f37a1096e6e898"); typeHash != want {
+		t.Fatalf("type hash mismatch: have %x, want %x", typeHash, want)
+	}
+	hash, err := mailType.EIP712Hash(mail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xb1130545da614df8ed4c43b9a0c0afe56e4a2b7ef993acc0ea2225b93f687e92"); hash != want {
+		t.Fatalf("struct hash mismatch: have %x, want %x", hash, want)
+	}
+}
+
+func TestEIP712HashErrors(t *testing.T) {
+	unnamed, _ := NewType("tuple", "", []ArgumentMarshaling{{Name: "a", Type: "uint256"}})
+	if _, err := unnamed.EIP712Type(); err == nil {
+		t.Error("expected error for unnamed tuple")
+	}
+	uint256, _ := NewType("uint256", "", nil)
+	if _, err := uint256.EIP712Hash(big.NewInt(1)); err != errNotStruct {
+		t.Errorf("expected error for non-tuple type, got %v", err)
+	}
+	named, _ := NewType("tuple", "struct A", []ArgumentMarshaling{{Name: "a", Type: "uint256"}})
+	if _, err := named.EIP712Hash(struct{ A string }{"1"}); err == nil {
+		t.Error("expected error for mismatching value")
+	}
+}
@@ -179,6 +179,24 @@ func TestMethodPack(t *testing.T) {
 	}
 }
 
+// Tests that function references are only packed from 24 byte arrays and unpacked
+// if right-padded with zeroes.
+func TestFunctionType(t *testing.T) {
+	t.Parallel()
+	typ, _ := NewType("function", "", nil)
+	args := Arguments{{Type: typ}}
+
+	for _, v := range []interface{}{[20]byte{}, [32]byte{}, []byte{}} {
+		if _, err := args.Pack(v); err == nil {
+			t.Errorf("packed function from %T", v)
+		}
+	}
+	packed := common.FromHex("0000000000000000000000000000000000000001aabbccdd0000000000000001")
+	if _, err := args.Unpack(packed); err == nil {
+		t.Error("unpacked function with non-zero padding")
+	}
+}
+
 func TestPackNumber(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
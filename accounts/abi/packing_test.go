@@ -389,6 +389,24 @@ var packUnpackTests = []packUnpackTest{
 		packed:   "0100000000000000000000000000000000000000000000000000000000000000",
 		unpacked: [24]byte{1},
 	},
+	{
+		def:      `[{"type": "function"}]`,
+		packed:   "0000000000000000000000000000000000000001aabbccdd0000000000000000",
+		unpacked: [24]byte{19: 0x01, 20: 0xaa, 21: 0xbb, 22: 0xcc, 23: 0xdd},
+	},
+	{
+		def: `[{"type": "function[2]"}]`,
+		packed: "0000000000000000000000000000000000000001aabbccdd0000000000000000" +
+			"0100000000000000000000000000000000000000000000000000000000000000",
+		unpacked: [2][24]byte{{19: 0x01, 20: 0xaa, 21: 0xbb, 22: 0xcc, 23: 0xdd}, {1}},
+	},
+	{
+		def: `[{"type": "function[]"}]`,
+		packed: "0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000001aabbccdd0000000000000000",
+		unpacked: [][24]byte{[24]byte{19: 0x01, 20: 0xaa, 21: 0xbb, 22: 0xcc, 23: 0xdd}},
+	},
 	// Slice and Array
 	{
 		def: `[{"type": "uint8[]"}]`,
@@ -327,3 +327,38 @@ func tuplePointsTo(index int, output []byte) (start int, err error) {
 	}
 	return int(offset.Uint64()), nil
 }
+
+// namedTuples converts a decoded value of type t, replacing its tuples with maps
+// keyed by the component names, recursively. Arrays and slices containing tuples
+// are converted to []interface{}, other values are returned as decoded.
+func namedTuples(t *Type, value reflect.Value) interface{} {
+	if !hasTuple(t) {
+		return value.Interface()
+	}
+	switch t.T {
+	case TupleTy:
+		named := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			named[t.TupleRawNames[i]] = namedTuples(elem, value.Field(i))
+		}
+		return named
+	default: // ArrayTy, SliceTy
+		elems := make([]interface{}, value.Len())
+		for i := range elems {
+			elems[i] = namedTuples(t.Elem, value.Index(i))
+		}
+		return elems
+	}
+}
+
+// hasTuple reports whether the type is a tuple or contains one.
+func hasTuple(t *Type) bool {
+	switch t.T {
+	case TupleTy:
+		return true
+	case ArrayTy, SliceTy:
+		return hasTuple(t.Elem)
+	default:
+		return false
+	}
+}
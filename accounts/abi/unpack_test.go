@@ -884,6 +884,68 @@ func TestUnpackTuple(t *testing.T) {
 	}
 }
 
+func TestUnpackNamed(t *testing.T) {
+	t.Parallel()
+	const nestedTuple = `[{"name":"tuple","type":"function","outputs":[
+		{"type":"tuple","name":"s","components":[{"type":"uint256","name":"a"},{"type":"uint256[]","name":"b"},{"type":"tuple[]","name":"c","components":[{"name":"x", "type":"uint256"},{"name":"y","type":"uint256"}]}]},
+		{"type":"tuple","name":"t","components":[{"name":"x", "type":"uint256"},{"name":"y","type":"uint256"}]},
+		{"type":"uint256","name":"a"}
+	]}]`
+	abi, err := JSON(strings.NewReader(nestedTuple))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := common.FromHex("" +
+		"0000000000000000000000000000000000000000000000000000000000000080" + // s offset
+		"0000000000000000000000000000000000000000000000000000000000000000" + // t.x = 0
+		"0000000000000000000000000000000000000000000000000000000000000001" + // t.y = 1
+		"0000000000000000000000000000000000000000000000000000000000000001" + // a = 1
+		"0000000000000000000000000000000000000000000000000000000000000001" + // s.a = 1
+		"0000000000000000000000000000000000000000000000000000000000000060" + // s.b offset
+		"00000000000000000000000000000000000000000000000000000000000000c0" + // s.c offset
+		"0000000000000000000000000000000000000000000000000000000000000002" + // s.b length
+		"0000000000000000000000000000000000000000000000000000000000000001" + // s.b[0] = 1
+		"0000000000000000000000000000000000000000000000000000000000000002" + // s.b[1] = 2
+		"0000000000000000000000000000000000000000000000000000000000000002" + // s.c length
+		"0000000000000000000000000000000000000000000000000000000000000001" + // s.c[0].x = 1
+		"0000000000000000000000000000000000000000000000000000000000000002" + // s.c[0].y = 2
+		"0000000000000000000000000000000000000000000000000000000000000002" + // s.c[1].x = 2
+		"0000000000000000000000000000000000000000000000000000000000000001") // s.c[1].y = 1
+
+	have, err := abi.UnpackNamed("tuple", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{
+		map[string]interface{}{
+			"a": big.NewInt(1),
+			"b": []*big.Int{big.NewInt(1), big.NewInt(2)},
+			"c": []interface{}{
+				map[string]interface{}{"x": big.NewInt(1), "y": big.NewInt(2)},
+				map[string]interface{}{"x": big.NewInt(2), "y": big.NewInt(1)},
+			},
+		},
+		map[string]interface{}{"x": big.NewInt(0), "y": big.NewInt(1)},
+		big.NewInt(1),
+	}
+	// The values are compared textually, as the decoded big integers might
+	// differ in their internal representation
+	if fmt.Sprint(have) != fmt.Sprint(want) {
+		t.Fatalf("unpacked value mismatch:\nhave %v\nwant %v", have, want)
+	}
+	if _, ok := have[0].(map[string]interface{})["c"].([]interface{})[0].(map[string]interface{}); !ok {
+		t.Fatalf("nested tuple not unpacked as map: %T", have[0].(map[string]interface{})["c"])
+	}
+	// The struct-based decoding is unaffected
+	values, err := abi.Unpack("tuple", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind := reflect.TypeOf(values[0]).Kind(); kind != reflect.Struct {
+		t.Fatalf("tuple decoded as %v, want struct", kind)
+	}
+}
+
 func TestOOMMaliciousInput(t *testing.T) {
 	t.Parallel()
 	oomTests := []unpackTest{
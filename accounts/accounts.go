@@ -31,8 +31,9 @@ import (
 // Account represents an Ethereum account located at a specific location defined
 // by the optional URL field.
 type Account struct {
-	Address common.Address `json:"address"` // Ethereum account address derived from the key
-	URL     URL            `json:"url"`     // Optional resource locator within a backend
+	Address   common.Address `json:"address"`             // Ethereum account address derived from the key
+	URL       URL            `json:"url"`                 // Optional resource locator within a backend
+	WatchOnly bool           `json:"watchOnly,omitempty"` // Whether the backend holds no key for the account
 }
 
 const (
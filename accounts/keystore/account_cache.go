@@ -251,7 +251,8 @@ func (ac *accountCache) scanAccounts() error {
 	var (
 		buf = new(bufio.Reader)
 		key struct {
-			Address string `json:"address"`
+			Address   string `json:"address"`
+			WatchOnly bool   `json:"watchonly"`
 		}
 	)
 	readAccount := func(path string) *accounts.Account {
@@ -263,7 +264,7 @@ func (ac *accountCache) scanAccounts() error {
 		defer fd.Close()
 		buf.Reset(fd)
 		// Parse the address.
-		key.Address = ""
+		key.Address, key.WatchOnly = "", false
 		err = json.NewDecoder(buf).Decode(&key)
 		addr := common.HexToAddress(key.Address)
 		switch {
@@ -273,8 +274,9 @@ func (ac *accountCache) scanAccounts() error {
 			log.Debug("Failed to decode keystore key", "path", path, "err", "missing or zero address")
 		default:
 			return &accounts.Account{
-				Address: addr,
-				URL:     accounts.URL{Scheme: KeyStoreScheme, Path: path},
+				Address:   addr,
+				URL:       accounts.URL{Scheme: KeyStoreScheme, Path: path},
+				WatchOnly: key.WatchOnly,
 			}
 		}
 		return nil
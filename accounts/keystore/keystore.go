@@ -23,6 +23,8 @@ package keystore
 import (
 	"crypto/ecdsa"
 	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"math/big"
 	"os"
@@ -44,6 +46,10 @@ var (
 	ErrNoMatch = errors.New("no key for given address or file")
 	ErrDecrypt = errors.New("could not decrypt key with given password")
 
+	// ErrNoKey is returned if a signing operation is requested for a watch-only
+	// account, which has no private key in the keystore.
+	ErrNoKey = errors.New("no private key for watch-only account")
+
 	// ErrAccountAlreadyExists is returned if an account attempted to import is
 	// already present in the keystore.
 	ErrAccountAlreadyExists = errors.New("account already exists")
@@ -228,6 +234,10 @@ func (ks *KeyStore) Accounts() []accounts.Account {
 // Delete deletes the key matched by account if the passphrase is correct.
 // If the account contains no filename, the address must match a unique key.
 func (ks *KeyStore) Delete(a accounts.Account, passphrase string) error {
+	// Watch-only accounts have no key to authenticate the removal with
+	if found, err := ks.Find(a); err == nil && found.WatchOnly {
+		return ks.deleteFile(found)
+	}
 	// Decrypting the key isn't really necessary, but we do
 	// it anyway to check the password and zero out the key
 	// immediately afterwards.
@@ -238,10 +248,15 @@ func (ks *KeyStore) Delete(a accounts.Account, passphrase string) error {
 	if err != nil {
 		return err
 	}
+	return ks.deleteFile(a)
+}
+
+// deleteFile removes the file of the given account from the key directory.
+func (ks *KeyStore) deleteFile(a accounts.Account) error {
 	// The order is crucial here. The key is dropped from the
 	// cache after the file is gone so that a reload happening in
 	// between won't insert it into the cache again.
-	err = os.Remove(a.URL.Path)
+	err := os.Remove(a.URL.Path)
 	if err == nil {
 		ks.cache.delete(a)
 		ks.refreshWallets()
@@ -258,7 +273,7 @@ func (ks *KeyStore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
 
 	unlockedKey, found := ks.unlocked[a.Address]
 	if !found {
-		return nil, ErrLocked
+		return nil, ks.lockedError(a)
 	}
 	// Sign the hash using plain ECDSA operations
 	return crypto.Sign(hash, unlockedKey.PrivateKey)
@@ -272,7 +287,7 @@ func (ks *KeyStore) SignTx(a accounts.Account, tx *types.Transaction, chainID *b
 
 	unlockedKey, found := ks.unlocked[a.Address]
 	if !found {
-		return nil, ErrLocked
+		return nil, ks.lockedError(a)
 	}
 	// Depending on the presence of the chain ID, sign with 2718 or homestead
 	signer := types.LatestSignerForChainID(chainID)
@@ -365,11 +380,22 @@ func (ks *KeyStore) Find(a accounts.Account) (accounts.Account, error) {
 	return a, err
 }
 
+// lockedError returns the error to report if the given account is not unlocked.
+func (ks *KeyStore) lockedError(a accounts.Account) error {
+	if found, err := ks.Find(a); err == nil && found.WatchOnly {
+		return ErrNoKey
+	}
+	return ErrLocked
+}
+
 func (ks *KeyStore) getDecryptedKey(a accounts.Account, auth string) (accounts.Account, *Key, error) {
 	a, err := ks.Find(a)
 	if err != nil {
 		return a, nil, err
 	}
+	if a.WatchOnly {
+		return a, nil, ErrNoKey
+	}
 	key, err := ks.storage.GetKey(a.Address, a.URL.Path, auth)
 	return a, key, err
 }
@@ -467,6 +493,42 @@ func (ks *KeyStore) importKey(key *Key, passphrase string) (accounts.Account, er
 	return a, nil
 }
 
+// watchOnlyJSON is the on-disk format of watch-only accounts.
+type watchOnlyJSON struct {
+	Address   string `json:"address"`
+	WatchOnly bool   `json:"watchonly"`
+}
+
+// ImportWatchOnly adds an account without a private key to the key directory.
+// The account is listed along with the regular ones, but any attempt to unlock
+// it or sign with it fails with ErrNoKey. Signing requests for such accounts are
+// expected to be routed to an external signer.
+func (ks *KeyStore) ImportWatchOnly(addr common.Address) (accounts.Account, error) {
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	if ks.cache.hasAddress(addr) {
+		return accounts.Account{
+			Address: addr,
+		}, ErrAccountAlreadyExists
+	}
+	a := accounts.Account{
+		Address:   addr,
+		URL:       accounts.URL{Scheme: KeyStoreScheme, Path: ks.storage.JoinPath(keyFileName(addr))},
+		WatchOnly: true,
+	}
+	content, err := json.Marshal(&watchOnlyJSON{Address: hex.EncodeToString(addr[:]), WatchOnly: true})
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	if err := writeKeyFile(a.URL.Path, content); err != nil {
+		return accounts.Account{}, err
+	}
+	ks.cache.add(a)
+	ks.refreshWallets()
+	return a, nil
+}
+
 // Update changes the passphrase of an existing account.
 func (ks *KeyStore) Update(a accounts.Account, passphrase, newPassphrase string) error {
 	a, key, err := ks.getDecryptedKey(a, passphrase)
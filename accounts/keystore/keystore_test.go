@@ -17,6 +17,7 @@
 package keystore
 
 import (
+	"math/big"
 	"math/rand"
 	"os"
 	"runtime"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 )
@@ -441,6 +443,56 @@ func checkAccounts(t *testing.T, live map[common.Address]accounts.Account, walle
 	}
 }
 
+// TestImportWatchOnly tests that watch-only accounts are listed, survive cache
+// reloads and refuse signing.
+func TestImportWatchOnly(t *testing.T) {
+	t.Parallel()
+	dir, ks := tmpKeyStore(t)
+
+	addr := common.HexToAddress("0x7ef5a6135f1fd6a02593eedc869c6d41d934aef8")
+	a, err := ks.ImportWatchOnly(addr)
+	if err != nil {
+		t.Fatalf("failed to import watch-only account: %v", err)
+	}
+	if !a.WatchOnly || a.Address != addr {
+		t.Fatalf("wrong account imported: %v", a)
+	}
+	if _, err := ks.ImportWatchOnly(addr); err != ErrAccountAlreadyExists {
+		t.Fatalf("wrong error for duplicate import: have %v, want %v", err, ErrAccountAlreadyExists)
+	}
+	// The account must be listed with the flag, also after reloading from disk
+	for _, store := range []*KeyStore{ks, NewKeyStore(dir, veryLightScryptN, veryLightScryptP)} {
+		accs := store.Accounts()
+		if len(accs) != 1 || accs[0] != a {
+			t.Fatalf("wrong accounts listed: have %v, want %v", accs, a)
+		}
+		if status, _ := store.Wallets()[0].Status(); status != "Watch-only" {
+			t.Errorf("wrong wallet status: %q", status)
+		}
+	}
+	// Signing and unlocking must fail with a clear error
+	tx := types.NewTransaction(0, common.Address{}, new(big.Int), 0, new(big.Int), nil)
+	if _, err := ks.SignTx(a, tx, big.NewInt(1)); err != ErrNoKey {
+		t.Errorf("wrong SignTx error: have %v, want %v", err, ErrNoKey)
+	}
+	if _, err := ks.SignHash(accounts.Account{Address: addr}, testSigData); err != ErrNoKey {
+		t.Errorf("wrong SignHash error: have %v, want %v", err, ErrNoKey)
+	}
+	if _, err := ks.SignTxWithPassphrase(a, "", tx, big.NewInt(1)); err != ErrNoKey {
+		t.Errorf("wrong SignTxWithPassphrase error: have %v, want %v", err, ErrNoKey)
+	}
+	if err := ks.Unlock(a, ""); err != ErrNoKey {
+		t.Errorf("wrong Unlock error: have %v, want %v", err, ErrNoKey)
+	}
+	// Watch-only accounts can be deleted without a passphrase
+	if err := ks.Delete(a, ""); err != nil {
+		t.Fatalf("failed to delete watch-only account: %v", err)
+	}
+	if ks.HasAddress(addr) || common.FileExist(a.URL.Path) {
+		t.Errorf("watch-only account still present after Delete")
+	}
+}
+
 // checkEvents checks that all events in 'want' are present in 'have'. Events may be present multiple times.
 func checkEvents(t *testing.T, want []walletEvent, have []walletEvent) {
 	for _, wantEv := range want {
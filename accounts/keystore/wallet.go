@@ -38,8 +38,11 @@ func (w *keystoreWallet) URL() accounts.URL {
 }
 
 // Status implements accounts.Wallet, returning whether the account held by the
-// keystore wallet is unlocked or not.
+// keystore wallet is unlocked or not, or whether it is a watch-only account.
 func (w *keystoreWallet) Status() (string, error) {
+	if w.account.WatchOnly {
+		return "Watch-only", nil
+	}
 	w.keystore.mu.RLock()
 	defer w.keystore.mu.RUnlock()
 
@@ -183,6 +183,7 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 			ExcessBlobGas: pre.Env.ExcessBlobGas,
 		}
 		vmContext.BlobBaseFee = eip4844.CalcBlobFee(chainConfig, header)
+		vmContext.ExcessBlobGas = &excessBlobGas
 	} else {
 		// If it is not explicitly defined, but we have the parent values, we try
 		// to calculate it ourselves.
@@ -200,6 +201,7 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 			}
 			excessBlobGas = eip4844.CalcExcessBlobGas(chainConfig, parent, header.Time)
 			vmContext.BlobBaseFee = eip4844.CalcBlobFee(chainConfig, header)
+			vmContext.ExcessBlobGas = &excessBlobGas
 		}
 	}
 	// If DAO is supported/enabled, we need to handle it here. In geth 'proper', it's
@@ -347,6 +347,12 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Rejournal,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolJournalLimitFlag = &cli.Uint64Flag{
+		Name:     "txpool.journallimit",
+		Usage:    "Maximum size in bytes of the local transaction journal before regenerating it (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPool.JournalLimit,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -1443,6 +1449,9 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
 	}
+	if ctx.IsSet(TxPoolJournalLimitFlag.Name) {
+		cfg.JournalLimit = ctx.Uint64(TxPoolJournalLimitFlag.Name)
+	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
@@ -72,7 +72,7 @@ func CalcExcessBlobGas(config *params.ChainConfig, parent *types.Header, headTim
 		parentBlobGasUsed = *parent.BlobGasUsed
 	}
 	excessBlobGas := parentExcessBlobGas + parentBlobGasUsed
-	targetGas := uint64(targetBlobsPerBlock(config, headTimestamp)) * params.BlobTxBlobGasPerBlob
+	targetGas := TargetBlobGasPerBlock(config, headTimestamp)
 	if excessBlobGas < targetGas {
 		return 0
 	}
@@ -121,6 +121,11 @@ func MaxBlobGasPerBlock(cfg *params.ChainConfig, time uint64) uint64 {
 	return uint64(MaxBlobsPerBlock(cfg, time)) * params.BlobTxBlobGasPerBlob
 }
 
+// TargetBlobGasPerBlock returns the target blob gas of a block at the given timestamp.
+func TargetBlobGasPerBlock(cfg *params.ChainConfig, time uint64) uint64 {
+	return uint64(targetBlobsPerBlock(cfg, time)) * params.BlobTxBlobGasPerBlob
+}
+
 // LatestMaxBlobsPerBlock returns the latest max blobs per block defined by the
 // configuration, regardless of the currently active fork.
 func LatestMaxBlobsPerBlock(cfg *params.ChainConfig) int {
@@ -18,6 +18,7 @@ package core
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -209,6 +211,56 @@ func benchInsertChain(b *testing.B, disk bool, gen func(int, *BlockGen)) {
 	}
 }
 
+// insertChainDirtyPeak imports the blocks one by one into a fresh chain with
+// the given dirty trie node limit in megabytes, returning the largest size the
+// dirty nodes reached once the flush limit applies.
+func insertChainDirtyPeak(tb testing.TB, gspec *Genesis, blocks []*types.Block, limit int) common.StorageSize {
+	cache := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cache.TrieDirtyLimit = limit
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cache, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		tb.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	var peak common.StorageSize
+	for _, block := range blocks {
+		if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+			tb.Fatalf("block %d: insert error: %v", block.NumberU64(), err)
+		}
+		if block.NumberU64() <= state.TriesInMemory {
+			continue
+		}
+		if _, nodes, _ := chain.triedb.Size(); nodes > peak {
+			peak = nodes
+		}
+	}
+	return peak
+}
+
+// BenchmarkInsertChain_dirtyLimit imports a chain segment with various dirty
+// trie node limits, reporting the largest size reached by the dirty nodes.
+func BenchmarkInsertChain_dirtyLimit(b *testing.B) {
+	gspec := &Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{benchRootAddr: {Balance: benchRootFunds}},
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2*state.TriesInMemory, genTxRing(1000))
+
+	for _, limit := range []int{256, 4, 1} {
+		b.Run(fmt.Sprintf("%dMB", limit), func(b *testing.B) {
+			var peak common.StorageSize
+			for i := 0; i < b.N; i++ {
+				if size := insertChainDirtyPeak(b, gspec, blocks, limit); size > peak {
+					peak = size
+				}
+			}
+			b.ReportMetric(float64(peak), "peak-bytes")
+		})
+	}
+}
+
 func BenchmarkChainRead_header_10k(b *testing.B) {
 	benchReadChain(b, false, 10000)
 }
@@ -152,6 +152,8 @@ type CacheConfig struct {
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
 	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
 
+	StorageCacheLimits map[common.Address]int // Memory allowance (MB) of dedicated storage trie node caches per account
+
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
 }
@@ -237,6 +239,7 @@ type BlockChain struct {
 	chainHeadFeed event.Feed
 	logsFeed      event.Feed
 	blockProcFeed event.Feed
+	finalizedFeed event.Feed
 	scope         event.SubscriptionScope
 	genesisBlock  *types.Block
 
@@ -322,7 +325,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		return nil, err
 	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
-	bc.statedb = state.NewDatabase(bc.triedb, nil)
+	bc.statedb = bc.newStateDatabase(nil)
 	bc.validator = NewBlockValidator(chainConfig, bc)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc.hc)
 	bc.processor = NewStateProcessor(chainConfig, bc.hc)
@@ -462,7 +465,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		bc.snaps, _ = snapshot.New(snapconfig, bc.db, bc.triedb, head.Root)
 
 		// Re-initialize the state database with snapshot
-		bc.statedb = state.NewDatabase(bc.triedb, bc.snaps)
+		bc.statedb = bc.newStateDatabase(bc.snaps)
 	}
 
 	// Rewind the chain in case of an incompatible config upgrade.
@@ -482,6 +485,16 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	return bc, nil
 }
 
+// newStateDatabase creates the state database used for block imports, with the
+// dedicated storage caches configured.
+func (bc *BlockChain) newStateDatabase(snaps *snapshot.Tree) *state.CachingDB {
+	db := state.NewDatabase(bc.triedb, snaps)
+	for addr, limit := range bc.cacheConfig.StorageCacheLimits {
+		db.SetStorageCacheSize(addr, limit*1024*1024)
+	}
+	return db
+}
+
 // empty returns an indicator whether the blockchain is empty.
 // Note, it's a special case that we connect a non-empty ancient
 // database with an empty node, so that we can plugin the ancient
@@ -612,10 +625,16 @@ func (bc *BlockChain) SetHeadWithTimestamp(timestamp uint64) error {
 
 // SetFinalized sets the finalized block.
 func (bc *BlockChain) SetFinalized(header *types.Header) {
-	bc.currentFinalBlock.Store(header)
+	prev := bc.currentFinalBlock.Swap(header)
 	if header != nil {
 		rawdb.WriteFinalizedBlockHash(bc.db, header.Hash())
 		headFinalizedBlockGauge.Update(int64(header.Number.Uint64()))
+
+		// Notify subscribers only once per distinct finalized block, the
+		// consensus client repeats the same finalized block on every update.
+		if prev == nil || prev.Hash() != header.Hash() {
+			bc.finalizedFeed.Send(FinalizedEvent{Header: header})
+		}
 	} else {
 		rawdb.WriteFinalizedBlockHash(bc.db, common.Hash{})
 		headFinalizedBlockGauge.Update(0)
@@ -18,6 +18,7 @@ package core
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -29,6 +30,8 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
 	"github.com/ethereum/go-ethereum/triedb"
 )
 
@@ -254,6 +257,36 @@ func (bc *BlockChain) GetAncestor(hash common.Hash, number, ancestor uint64, max
 	return bc.hc.GetAncestor(hash, number, ancestor, maxNonCanonical)
 }
 
+// TransactionInclusionProof retrieves the header of the canonical block including
+// the given transaction, along with the merkle proof of the transaction within the
+// transactions trie of the block, verifiable against the header's transactions root.
+// The proof is keyed by the RLP-encoded index of the transaction in the block.
+//
+// ErrTxNotFound is returned if the transaction is not included in the chain,
+// which includes the pending transactions not mined yet.
+func (bc *BlockChain) TransactionInclusionProof(hash common.Hash) (*types.Header, [][]byte, error) {
+	lookup, _, err := bc.GetTransactionLookup(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lookup == nil {
+		return nil, nil, ErrTxNotFound
+	}
+	block := bc.GetBlock(lookup.BlockHash, lookup.BlockIndex)
+	if block == nil {
+		return nil, nil, fmt.Errorf("block %d (%x) of transaction not found", lookup.BlockIndex, lookup.BlockHash)
+	}
+	tr := trie.NewEmpty(nil)
+	if root := types.DeriveSha(block.Transactions(), tr); root != block.TxHash() {
+		return nil, nil, fmt.Errorf("transactions root mismatch: have %x, want %x", root, block.TxHash())
+	}
+	proof := trienode.NewProofSet()
+	if err := tr.Prove(rlp.AppendUint64(nil, lookup.Index), proof); err != nil {
+		return nil, nil, err
+	}
+	return block.Header(), proof.List(), nil
+}
+
 // GetTransactionLookup retrieves the lookup along with the transaction
 // itself associate with the given transaction hash.
 //
@@ -432,6 +465,12 @@ func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 	return bc.scope.Track(bc.logsFeed.Subscribe(ch))
 }
 
+// SubscribeFinalizedBlock registers a subscription of FinalizedEvent, which is
+// fired whenever the finalized block changes to a new block.
+func (bc *BlockChain) SubscribeFinalizedBlock(ch chan<- FinalizedEvent) event.Subscription {
+	return bc.scope.Track(bc.finalizedFeed.Subscribe(ch))
+}
+
 // SubscribeBlockProcessingEvent registers a subscription of bool where true means
 // block processing has started while false means it has stopped.
 func (bc *BlockChain) SubscribeBlockProcessingEvent(ch chan<- bool) event.Subscription {
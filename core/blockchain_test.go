@@ -43,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/pebble"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
 )
@@ -4265,3 +4266,199 @@ func TestEIP7702(t *testing.T) {
 		t.Fatalf("addr2 storage wrong: expected %d, got %d", fortyTwo, actual)
 	}
 }
+
+// Tests that finalized block events are fired once per distinct finalized block.
+func TestFinalizedBlockEvent(t *testing.T) {
+	_, _, chain, err := newCanonical(ethash.NewFaker(), 3, true, rawdb.HashScheme)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer chain.Stop()
+
+	events := make(chan FinalizedEvent, 10)
+	sub := chain.SubscribeFinalizedBlock(events)
+	defer sub.Unsubscribe()
+
+	var (
+		first  = chain.GetHeaderByNumber(1)
+		second = chain.GetHeaderByNumber(2)
+	)
+	chain.SetFinalized(first)
+	chain.SetFinalized(first)
+	chain.SetFinalized(second)
+	chain.SetFinalized(second)
+	chain.SetFinalized(nil)
+
+	for _, want := range []*types.Header{first, second} {
+		select {
+		case ev := <-events:
+			if ev.Header.Hash() != want.Hash() {
+				t.Fatalf("wrong finalized block: have #%d, want #%d", ev.Header.Number, want.Number)
+			}
+		default:
+			t.Fatalf("missing finalized event for #%d", want.Number)
+		}
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected finalized event for #%d", ev.Header.Number)
+	default:
+	}
+}
+
+// Tests that the reorg risk of competing tips is summarized correctly.
+func TestReorgRisk(t *testing.T) {
+	genDb, genesis, chain, err := newCanonical(ethash.NewFaker(), 10, true, rawdb.HashScheme)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer chain.Stop()
+
+	var canon []*types.Header
+	for i := uint64(6); i <= 10; i++ {
+		canon = append(canon, chain.GetHeaderByNumber(i))
+	}
+	head := canon[len(canon)-1]
+
+	// Import a side chain forking off at block #5, and create a competing tip
+	// which is not stored locally.
+	fork := makeBlockChain(genesis.Config, chain.GetBlockByNumber(5), 4, ethash.NewFaker(), genDb, 1)
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert side chain: %v", err)
+	}
+	rival := makeBlockChain(genesis.Config, chain.GetBlock(canon[3].Hash(), 9), 1, ethash.NewFaker(), genDb, 2)[0]
+
+	weight := func(headers ...*types.Header) *big.Int {
+		w := new(big.Int)
+		for _, h := range headers {
+			w.Add(w, h.Difficulty)
+		}
+		return w
+	}
+	var forked []*types.Header
+	for _, block := range fork {
+		forked = append(forked, block.Header())
+	}
+	for i, tt := range []struct {
+		candidates   []*types.Header
+		ancestor     uint64
+		depth        uint64
+		spread       *big.Int
+		tips         int
+		tipsAtHeight int
+	}{
+		// A single tip doesn't compete with anything
+		{[]*types.Header{head, head}, 10, 0, new(big.Int), 1, 1},
+		// Tips on the same branch converge at the lowest one
+		{[]*types.Header{head, canon[2]}, 8, 2, weight(canon[3:]...), 2, 1},
+		// Competing tips at the same height
+		{[]*types.Header{head, rival.Header()}, 9, 1, new(big.Int).Sub(weight(head), weight(rival.Header())), 2, 2},
+		// Side chain with a different length
+		{[]*types.Header{head, forked[3], rival.Header()}, 5, 5, new(big.Int).Sub(weight(canon...), weight(forked...)), 3, 2},
+	} {
+		risk, err := chain.ReorgRisk(tt.candidates)
+		if err != nil {
+			t.Fatalf("test %d: failed to compute reorg risk: %v", i, err)
+		}
+		if have := risk.Ancestor.Number.Uint64(); have != tt.ancestor {
+			t.Errorf("test %d: ancestor mismatch: have #%d, want #%d", i, have, tt.ancestor)
+		}
+		if risk.Depth != tt.depth {
+			t.Errorf("test %d: depth mismatch: have %d, want %d", i, risk.Depth, tt.depth)
+		}
+		if risk.WeightSpread.CmpAbs(tt.spread) != 0 {
+			t.Errorf("test %d: weight spread mismatch: have %v, want %v", i, risk.WeightSpread, tt.spread)
+		}
+		if risk.Tips != tt.tips || risk.TipsAtHeight != tt.tipsAtHeight {
+			t.Errorf("test %d: tips mismatch: have %d/%d, want %d/%d", i, risk.Tips, risk.TipsAtHeight, tt.tips, tt.tipsAtHeight)
+		}
+	}
+	if _, err := chain.ReorgRisk(nil); err != errNoCandidates {
+		t.Errorf("expected error for missing candidates, got %v", err)
+	}
+}
+
+func TestTransactionInclusionProof(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}}}
+		signer  = types.LatestSigner(gspec.Config)
+		pending *types.Transaction
+	)
+	// Create a block with enough transactions to span the index ordering quirks
+	// of the transactions trie
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, gen *BlockGen) {
+		if i == 0 {
+			return
+		}
+		for j := 0; j < 130; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr), common.Address{0xaa}, big.NewInt(1), params.TxGas, gen.header.BaseFee, nil), signer, key)
+			gen.AddTx(tx)
+		}
+		pending, _ = types.SignTx(types.NewTransaction(gen.TxNonce(addr), common.Address{0xaa}, big.NewInt(1), params.TxGas, gen.header.BaseFee, nil), signer, key)
+	})
+	chain, _ := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for _, index := range []int{0, 1, 127, 128, 129} {
+		tx := blocks[1].Transactions()[index]
+		header, proof, err := chain.TransactionInclusionProof(tx.Hash())
+		if err != nil {
+			t.Fatalf("tx %d: failed to prove: %v", index, err)
+		}
+		if header.Hash() != blocks[1].Hash() {
+			t.Fatalf("tx %d: wrong header #%d", index, header.Number)
+		}
+		proofDb := rawdb.NewMemoryDatabase()
+		for _, node := range proof {
+			proofDb.Put(crypto.Keccak256(node), node)
+		}
+		value, err := trie.VerifyProof(header.TxHash, rlp.AppendUint64(nil, uint64(index)), proofDb)
+		if err != nil {
+			t.Fatalf("tx %d: invalid proof: %v", index, err)
+		}
+		if enc, _ := tx.MarshalBinary(); !bytes.Equal(value, enc) {
+			t.Fatalf("tx %d: proven value mismatch: have %x, want %x", index, value, enc)
+		}
+	}
+	if _, _, err := chain.TransactionInclusionProof(pending.Hash()); err != ErrTxNotFound {
+		t.Fatalf("unexpected error for pending transaction: %v", err)
+	}
+}
+
+// Tests that the dirty trie nodes are flushed to disk once they exceed the
+// configured limit, bounding the memory used by a long import.
+func TestTrieDirtyLimit(t *testing.T) {
+	gspec := &Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{benchRootAddr: {Balance: benchRootFunds}},
+	}
+	// Fund a few new accounts in every block, growing the state trie
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), state.TriesInMemory+4, func(i int, b *BlockGen) {
+		for j := 0; j < 20; j++ {
+			to := common.BytesToAddress(crypto.Keccak256([]byte{byte(i), byte(i >> 8), byte(j)}))
+			tx, err := types.SignNewTx(benchRootKey, b.Signer(), &types.LegacyTx{
+				Nonce:    b.TxNonce(benchRootAddr),
+				To:       &to,
+				Value:    big.NewInt(1),
+				Gas:      params.TxGas,
+				GasPrice: b.BaseFee(),
+			})
+			if err != nil {
+				t.Fatalf("failed to sign transaction: %v", err)
+			}
+			b.AddTx(tx)
+		}
+	})
+
+	const limit = 1
+	if peak := insertChainDirtyPeak(t, gspec, blocks, 256); peak <= limit*1024*1024 {
+		t.Fatalf("unbounded import too small: peak %v", peak)
+	}
+	if peak := insertChainDirtyPeak(t, gspec, blocks, limit); peak > limit*1024*1024 {
+		t.Fatalf("dirty nodes exceeded the limit: peak %v, limit %dMB", peak, limit)
+	}
+}
@@ -29,6 +29,10 @@ var (
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
 
+	// ErrTxNotFound is returned when a transaction is not included in the canonical
+	// chain, e.g. because it's still pending.
+	ErrTxNotFound = errors.New("transaction not found")
+
 	errSideChainReceipts = errors.New("side blocks can't be accepted as ancient chain data")
 )
 
@@ -74,7 +78,7 @@ var (
 	ErrInsufficientFunds = errors.New("insufficient funds for gas * price + value")
 
 	// ErrGasUintOverflow is returned when calculating gas usage.
-	ErrGasUintOverflow = errors.New("gas uint64 overflow")
+	ErrGasUintOverflow = types.ErrGasUintOverflow
 
 	// ErrIntrinsicGas is returned if the transaction is specified to use less gas
 	// than required to start the invocation.
@@ -90,7 +94,7 @@ var (
 
 	// ErrTipAboveFeeCap is a sanity error to ensure no one is able to specify a
 	// transaction with a tip higher than the total fee cap.
-	ErrTipAboveFeeCap = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrTipAboveFeeCap = types.ErrTipAboveFeeCap
 
 	// ErrTipVeryHigh is a sanity error to avoid extremely big numbers specified
 	// in the tip field.
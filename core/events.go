@@ -33,3 +33,8 @@ type ChainEvent struct {
 type ChainHeadEvent struct {
 	Header *types.Header
 }
+
+// FinalizedEvent is posted when the finalized block advances.
+type FinalizedEvent struct {
+	Header *types.Header
+}
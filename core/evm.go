@@ -49,6 +49,8 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		baseFee     *big.Int
 		blobBaseFee *big.Int
 		random      *common.Hash
+
+		excessBlobGas *uint64
 	)
 
 	// If we don't have an explicit author (i.e. not mining), extract from the header
@@ -62,6 +64,8 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 	}
 	if header.ExcessBlobGas != nil {
 		blobBaseFee = eip4844.CalcBlobFee(chain.Config(), header)
+		excessBlobGas = new(uint64)
+		*excessBlobGas = *header.ExcessBlobGas
 	}
 	if header.Difficulty.Sign() == 0 {
 		random = &header.MixDigest
@@ -78,6 +82,8 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		BlobBaseFee: blobBaseFee,
 		GasLimit:    header.GasLimit,
 		Random:      random,
+
+		ExcessBlobGas: excessBlobGas,
 	}
 }
 
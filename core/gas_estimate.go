@@ -0,0 +1,200 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GasProbe executes the message with the given gas limit, discarding its state
+// changes afterwards. The returned error is only set if the message could not be
+// executed at all, e.g. due to insufficient funds.
+type GasProbe func(msg *Message, gasLimit uint64) (*ExecutionResult, error)
+
+// EstimateGas searches for the lowest gas limit the message executes successfully
+// with, using the state and block context of the given EVM. The highest gas limit
+// tried is the message's own limit (or the block gas limit if unset), capped by
+// the funds of the sender and the given cap if non-zero.
+//
+// The message is executed several times, reverting the state changes of every
+// execution afterwards. The access list and transient storage of the state are
+// reset by each execution though, so the state should not be reused mid-message.
+// The returned execution result belongs to the run with the returned gas limit.
+// If the message fails regardless of the gas limit, e.g. because it always
+// reverts, the result of the failing execution is returned along with its error.
+func EstimateGas(evm *vm.EVM, msg *Message, gasCap uint64) (uint64, *ExecutionResult, error) {
+	probe := func(msg *Message, gasLimit uint64) (*ExecutionResult, error) {
+		snapshot := evm.StateDB.Snapshot()
+		defer evm.StateDB.RevertToSnapshot(snapshot)
+
+		result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(math.MaxUint64))
+		if err != nil {
+			return nil, fmt.Errorf("failed with %d gas: %w", gasLimit, err)
+		}
+		return result, nil
+	}
+	return EstimateGasWithProbe(evm, msg, gasCap, 0, probe)
+}
+
+// EstimateGasWithProbe is like EstimateGas, but executes the message with the
+// given probe, e.g. on fresh copies of the state. The EVM is then only consulted
+// for capping the gas limit and detecting plain transfers. If errorRatio is
+// non-zero, the search stops as soon as the relative overestimation of the gas
+// limit is below it.
+func EstimateGasWithProbe(evm *vm.EVM, msg *Message, gasCap uint64, errorRatio float64, probe GasProbe) (uint64, *ExecutionResult, error) {
+	// Determine the highest gas limit can be used during the estimation.
+	hi := evm.Context.GasLimit
+	if msg.GasLimit >= params.TxGas {
+		hi = msg.GasLimit
+	}
+	// Recap the highest gas limit with account's available balance.
+	allowance, err := estimateGasAllowance(evm, msg)
+	if err != nil {
+		return 0, nil, err
+	}
+	if allowance != nil && allowance.IsUint64() && hi > allowance.Uint64() {
+		log.Debug("Gas estimation capped by limited funds", "original", hi, "fundable", allowance)
+		hi = allowance.Uint64()
+	}
+	// Recap the highest gas allowance with specified gascap.
+	if gasCap != 0 && hi > gasCap {
+		log.Debug("Caller gas above allowance, capping", "requested", hi, "cap", gasCap)
+		hi = gasCap
+	}
+	// If the message is a plain value transfer, short circuit estimation and
+	// directly try 21000, falling back to the search if some fields of the
+	// message bump the price up.
+	if len(msg.Data) == 0 && msg.To != nil && evm.StateDB.GetCodeSize(*msg.To) == 0 {
+		failed, result, err := estimateGasExecute(probe, msg, params.TxGas)
+		if !failed && err == nil {
+			return params.TxGas, result, nil
+		}
+	}
+	// Execute the message at the highest allowable gas limit first, if this fails
+	// there's no point in searching any further.
+	failed, result, err := estimateGasExecute(probe, msg, hi)
+	if err != nil {
+		return 0, result, err
+	}
+	if failed {
+		if result != nil && !errors.Is(result.Err, vm.ErrOutOfGas) {
+			return 0, result, result.Err
+		}
+		return 0, result, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+	// The gas consumed by the unconstrained execution lower-bounds the gas limit
+	// required for success, apart from messages that explicitly inspect the gas
+	// remaining.
+	lo := result.UsedGas - 1
+
+	// Calls only forward 63/64 of the available gas, so the message might need
+	// more gas than it ends up using. Try the used gas topped up by that fraction
+	// first, which succeeds for most messages and narrows down the search.
+	optimistic := (result.UsedGas + result.RefundedGas + params.CallStipend) * 64 / 63
+	if optimistic < hi {
+		failed, res, err := estimateGasExecute(probe, msg, optimistic)
+		if err != nil {
+			// The message executed fine before, so this should not happen
+			log.Error("Execution error in estimate gas", "err", err)
+			return 0, res, err
+		}
+		if failed {
+			lo = optimistic
+		} else {
+			hi, result = optimistic, res
+		}
+	}
+	// Binary search for the smallest gas limit that allows the message to succeed.
+	for lo+1 < hi {
+		// A perfect estimation is pointless if the caller bumps it up anyway,
+		// allow a small upwards approximation error if requested.
+		if errorRatio > 0 && float64(hi-lo)/float64(hi) < errorRatio {
+			break
+		}
+		mid := (hi + lo) / 2
+		if mid > lo*2 {
+			// Most messages don't need much more gas than they use, skew the
+			// bisection to favor the low side.
+			mid = lo * 2
+		}
+		failed, res, err := estimateGasExecute(probe, msg, mid)
+		if err != nil {
+			// The message executed fine before, so this should not happen
+			log.Error("Execution error in estimate gas", "err", err)
+			return 0, res, err
+		}
+		if failed {
+			lo = mid
+		} else {
+			hi, result = mid, res
+		}
+	}
+	return hi, result, nil
+}
+
+// estimateGasAllowance returns the maximum gas the sender of the message can pay
+// for, or nil if the message doesn't pay for gas.
+func estimateGasAllowance(evm *vm.EVM, msg *Message) (*big.Int, error) {
+	feeCap := msg.GasFeeCap
+	if feeCap == nil {
+		feeCap = msg.GasPrice
+	}
+	if feeCap == nil || feeCap.BitLen() == 0 {
+		return nil, nil
+	}
+	available := evm.StateDB.GetBalance(msg.From).ToBig()
+	if msg.Value != nil {
+		if msg.Value.Cmp(available) >= 0 {
+			return nil, ErrInsufficientFundsForTransfer
+		}
+		available.Sub(available, msg.Value)
+	}
+	if evm.ChainConfig().IsCancun(evm.Context.BlockNumber, evm.Context.Time) && len(msg.BlobHashes) > 0 && msg.BlobGasFeeCap != nil {
+		blobCost := new(big.Int).SetUint64(uint64(len(msg.BlobHashes)) * params.BlobTxBlobGasPerBlob)
+		blobCost.Mul(blobCost, msg.BlobGasFeeCap)
+		if blobCost.Cmp(available) >= 0 {
+			return nil, ErrInsufficientFunds
+		}
+		available.Sub(available, blobCost)
+	}
+	return available.Div(available, feeCap), nil
+}
+
+// estimateGasExecute executes the message with the given gas limit using the
+// probe. It returns true if the message failed for a reason that might be related
+// to not having enough gas, a non-nil error means the execution failed for reasons
+// unrelated to the gas limit.
+func estimateGasExecute(probe GasProbe, msg *Message, gasLimit uint64) (bool, *ExecutionResult, error) {
+	defer func(gas uint64) { msg.GasLimit = gas }(msg.GasLimit)
+	msg.GasLimit = gasLimit
+
+	result, err := probe(msg, gasLimit)
+	if err != nil {
+		if errors.Is(err, ErrIntrinsicGas) {
+			return true, nil, nil // Special case, raise gas limit
+		}
+		return true, nil, err
+	}
+	return result.Failed(), result, nil
+}
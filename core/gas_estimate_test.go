@@ -0,0 +1,131 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestEstimateGas(t *testing.T) {
+	var (
+		sender   = common.HexToAddress("0x1000")
+		child    = common.HexToAddress("0x2000")
+		parent   = common.HexToAddress("0x3000")
+		reverter = common.HexToAddress("0x4000")
+		empty    = common.HexToAddress("0x5000")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetBalance(sender, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+
+	// The child stores a value, the parent calls it with all its gas and
+	// reverts if the call fails
+	statedb.SetCode(child, common.FromHex("0x600160005500"))
+	statedb.SetCode(parent, append(append(common.FromHex("0x6000600060006000600073"), child.Bytes()...), common.FromHex("0x5af115602657005b600080fd")...))
+	statedb.SetCode(reverter, common.FromHex("0x600080fd"))
+
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   30_000_000,
+		BaseFee:    new(big.Int),
+		Difficulty: new(big.Int),
+	}
+	evm := vm.NewEVM(NewEVMBlockContext(header, nil, new(common.Address)), statedb, params.MergedTestChainConfig, vm.Config{NoBaseFee: true})
+	root := statedb.IntermediateRoot(true)
+
+	newMsg := func(to common.Address) *Message {
+		return &Message{
+			From:             sender,
+			To:               &to,
+			Value:            new(big.Int),
+			GasPrice:         new(big.Int),
+			GasFeeCap:        new(big.Int),
+			GasTipCap:        new(big.Int),
+			SkipNonceChecks:  true,
+			SkipFromEOACheck: true,
+		}
+	}
+	// Plain transfers are short circuited
+	gas, result, err := EstimateGas(evm, newMsg(empty), 0)
+	if err != nil {
+		t.Fatalf("transfer: estimation failed: %v", err)
+	}
+	if gas != params.TxGas || result.UsedGas != params.TxGas {
+		t.Fatalf("transfer: have %d gas, %d used, want %d", gas, result.UsedGas, params.TxGas)
+	}
+	// Nested calls need more than the gas used due to the 63/64 rule
+	msg := newMsg(parent)
+	gas, result, err = EstimateGas(evm, msg, 0)
+	if err != nil {
+		t.Fatalf("call: estimation failed: %v", err)
+	}
+	if result.Failed() || gas <= result.UsedGas {
+		t.Fatalf("call: have %d gas, %d used, failed %v", gas, result.UsedGas, result.Failed())
+	}
+	if msg.GasLimit != 0 {
+		t.Fatalf("call: message gas limit modified to %d", msg.GasLimit)
+	}
+	msg.GasLimit = gas - 1
+	if _, _, err := EstimateGas(evm, msg, 0); err == nil {
+		t.Fatalf("call: execution succeeded with %d gas, estimate %d not minimal", gas-1, gas)
+	}
+	// Searches allowing an approximation error overestimate with fewer executions
+	var probes int
+	probe := func(msg *Message, gasLimit uint64) (*ExecutionResult, error) {
+		probes++
+		snapshot := statedb.Snapshot()
+		defer statedb.RevertToSnapshot(snapshot)
+		return ApplyMessage(evm, msg, new(GasPool).AddGas(math.MaxUint64))
+	}
+	approx, _, err := EstimateGasWithProbe(evm, newMsg(parent), 0, 0.5, probe)
+	if err != nil {
+		t.Fatalf("approx: estimation failed: %v", err)
+	}
+	approxProbes := probes
+
+	probes = 0
+	exact, _, err := EstimateGasWithProbe(evm, newMsg(parent), 0, 0, probe)
+	if err != nil || exact != gas {
+		t.Fatalf("exact: have %d gas, err %v, want %d", exact, err, gas)
+	}
+	if approx < exact || approxProbes >= probes {
+		t.Fatalf("approx: have %d gas in %d probes, exact %d gas in %d probes", approx, approxProbes, exact, probes)
+	}
+	// Messages always failing are reported with their result
+	gas, result, err = EstimateGas(evm, newMsg(reverter), 0)
+	if !errors.Is(err, vm.ErrExecutionReverted) || result == nil || gas != 0 {
+		t.Fatalf("revert: have %d gas, err %v, result %v", gas, err, result)
+	}
+	// Messages needing more gas than the cap are rejected
+	if _, _, err = EstimateGas(evm, newMsg(parent), 30_000); err == nil {
+		t.Fatal("cap: expected estimation to fail")
+	}
+	// The state is left untouched
+	if have := statedb.IntermediateRoot(true); have != root {
+		t.Fatalf("state modified: have root %x, want %x", have, root)
+	}
+}
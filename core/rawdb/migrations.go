@@ -0,0 +1,106 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errMigrationVersion   = errors.New("migration versions must be consecutive starting from 1")
+	errSchemaVersionAhead = errors.New("database schema is newer than supported")
+)
+
+// Migration is a change of the ancillary data schema, bringing the database from
+// version Version-1 to Version.
+type Migration struct {
+	Version uint64 // Schema version after applying the migration
+	Name    string // Human readable description for logging
+
+	// Migrate applies the migration. It must be idempotent, as it is run again
+	// if the process is interrupted before the new version is recorded.
+	Migrate func(db ethdb.KeyValueStore) error
+}
+
+// ReadSchemaVersion retrieves the schema version of the database. Databases
+// without any recorded version are at version 0.
+func ReadSchemaVersion(db ethdb.KeyValueReader) uint64 {
+	var version uint64
+
+	enc, _ := db.Get(schemaVersionKey)
+	if len(enc) == 0 {
+		return 0
+	}
+	if err := rlp.DecodeBytes(enc, &version); err != nil {
+		return 0
+	}
+	return version
+}
+
+// WriteSchemaVersion stores the schema version of the database.
+func WriteSchemaVersion(db ethdb.KeyValueWriter, version uint64) {
+	enc, err := rlp.EncodeToBytes(version)
+	if err != nil {
+		log.Crit("Failed to encode schema version", "err", err)
+	}
+	if err = db.Put(schemaVersionKey, enc); err != nil {
+		log.Crit("Failed to store the schema version", "err", err)
+	}
+}
+
+// RunMigrations brings the database forward to the latest schema version by
+// applying, in order, the migrations newer than the version recorded in it. The
+// version is recorded after every migration, so an interrupted run resumes from
+// the failed migration. It's an error if the recorded version is newer than the
+// latest known migration, as the database was written by a newer release.
+func RunMigrations(db ethdb.KeyValueStore, migrations []Migration) error {
+	migrations = slices.Clone(migrations)
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	for i, m := range migrations {
+		if m.Version != uint64(i+1) {
+			return fmt.Errorf("%w: have %d at position %d", errMigrationVersion, m.Version, i)
+		}
+	}
+	var (
+		latest  = uint64(len(migrations))
+		version = ReadSchemaVersion(db)
+	)
+	if version > latest {
+		return fmt.Errorf("%w: have %d, latest known %d", errSchemaVersionAhead, version, latest)
+	}
+	for _, m := range migrations[version:] {
+		start := time.Now()
+		log.Info("Migrating database schema", "version", m.Version, "name", m.Name)
+		if err := m.Migrate(db); err != nil {
+			return fmt.Errorf("schema migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		WriteSchemaVersion(db, m.Version)
+		log.Info("Migrated database schema", "version", m.Version, "name", m.Name, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return nil
+}
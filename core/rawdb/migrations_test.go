@@ -0,0 +1,126 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// renameMigration moves all the entries with the old prefix to the new one.
+func renameMigration(oldPrefix, newPrefix []byte) func(db ethdb.KeyValueStore) error {
+	return func(db ethdb.KeyValueStore) error {
+		it := db.NewIterator(oldPrefix, nil)
+		defer it.Release()
+
+		batch := db.NewBatch()
+		for it.Next() {
+			key := append(bytes.Clone(newPrefix), it.Key()[len(oldPrefix):]...)
+			batch.Put(key, it.Value())
+			batch.Delete(it.Key())
+		}
+		if it.Error() != nil {
+			return it.Error()
+		}
+		return batch.Write()
+	}
+}
+
+func TestRunMigrations(t *testing.T) {
+	db := NewMemoryDatabase()
+	if v := ReadSchemaVersion(db); v != 0 {
+		t.Fatalf("unexpected version of fresh database: %d", v)
+	}
+	// Create a database at schema version 1
+	for i := 0; i < 3; i++ {
+		db.Put([]byte(fmt.Sprintf("old-%d", i)), []byte{byte(i)})
+	}
+	WriteSchemaVersion(db, 1)
+
+	var runs [2]int
+	migrations := []Migration{
+		{Version: 2, Name: "rename", Migrate: func(db ethdb.KeyValueStore) error {
+			runs[1]++
+			return renameMigration([]byte("old-"), []byte("new-"))(db)
+		}},
+		{Version: 1, Name: "initial", Migrate: func(db ethdb.KeyValueStore) error {
+			runs[0]++
+			return nil
+		}},
+	}
+	for round := 0; round < 2; round++ {
+		if err := RunMigrations(db, migrations); err != nil {
+			t.Fatalf("round %d: migration failed: %v", round, err)
+		}
+		if runs != [2]int{0, 1} {
+			t.Fatalf("round %d: wrong migration runs: %v", round, runs)
+		}
+		if v := ReadSchemaVersion(db); v != 2 {
+			t.Fatalf("round %d: wrong version: have %d, want 2", round, v)
+		}
+		for i := 0; i < 3; i++ {
+			if ok, _ := db.Has([]byte(fmt.Sprintf("old-%d", i))); ok {
+				t.Fatalf("round %d: entry %d not migrated", round, i)
+			}
+			if val, _ := db.Get([]byte(fmt.Sprintf("new-%d", i))); !bytes.Equal(val, []byte{byte(i)}) {
+				t.Fatalf("round %d: entry %d: wrong value %x", round, i, val)
+			}
+		}
+	}
+}
+
+func TestRunMigrationsFailure(t *testing.T) {
+	var (
+		db   = NewMemoryDatabase()
+		fail = errors.New("boom")
+		ok   bool
+	)
+	migrations := []Migration{
+		{Version: 1, Name: "first", Migrate: func(db ethdb.KeyValueStore) error { return nil }},
+		{Version: 2, Name: "flaky", Migrate: func(db ethdb.KeyValueStore) error {
+			if !ok {
+				return fail
+			}
+			return nil
+		}},
+	}
+	if err := RunMigrations(db, migrations); !errors.Is(err, fail) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v := ReadSchemaVersion(db); v != 1 {
+		t.Fatalf("wrong version after failure: have %d, want 1", v)
+	}
+	ok = true
+	if err := RunMigrations(db, migrations); err != nil {
+		t.Fatalf("resumed migration failed: %v", err)
+	}
+	if v := ReadSchemaVersion(db); v != 2 {
+		t.Fatalf("wrong version after resume: have %d, want 2", v)
+	}
+	// Downgrading is refused
+	if err := RunMigrations(db, migrations[:1]); !errors.Is(err, errSchemaVersionAhead) {
+		t.Fatalf("unexpected error on downgrade: %v", err)
+	}
+	// Gaps in the migration versions are rejected
+	if err := RunMigrations(db, []Migration{migrations[0], {Version: 3}}); !errors.Is(err, errMigrationVersion) {
+		t.Fatalf("unexpected error on version gap: %v", err)
+	}
+}
@@ -31,6 +31,10 @@ var (
 	// databaseVersionKey tracks the current database version.
 	databaseVersionKey = []byte("DatabaseVersion")
 
+	// schemaVersionKey tracks the version of the ancillary data schema, bumped by
+	// the migrations.
+	schemaVersionKey = []byte("SchemaVersion")
+
 	// headHeaderKey tracks the latest known header's hash.
 	headHeaderKey = []byte("LastHeader")
 
@@ -0,0 +1,133 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	errNoCandidates     = errors.New("no candidate headers")
+	errNoCommonAncestor = errors.New("no common ancestor")
+)
+
+// ReorgRisk summarizes the contention between a set of competing chain tips.
+type ReorgRisk struct {
+	Ancestor *types.Header // Latest common ancestor of all the tips
+	Depth    uint64        // Distance between the highest tip and the common ancestor
+
+	// WeightSpread is the difference of the difficulty accumulated on top of
+	// the common ancestor between the heaviest and the lightest tip. It's zero
+	// for proof-of-stake chains, where the amount of tips at the highest height
+	// is the more meaningful signal.
+	WeightSpread *big.Int
+
+	Tips         int // Number of distinct tips
+	TipsAtHeight int // Number of distinct tips at the highest height
+}
+
+// ReorgRisk computes the reorg risk summary of the given candidate tips, which
+// typically are the heads announced by different peers. The candidates don't
+// need to be stored, but all their ancestors up to the common one have to be
+// available locally.
+func (bc *BlockChain) ReorgRisk(candidates []*types.Header) (*ReorgRisk, error) {
+	if len(candidates) == 0 {
+		return nil, errNoCandidates
+	}
+	// Deduplicate the tips and track the branch of each of them
+	type branch struct {
+		head   *types.Header
+		weight *big.Int
+	}
+	var (
+		branches []*branch
+		seen     = make(map[common.Hash]struct{})
+		highest  uint64
+	)
+	for _, header := range candidates {
+		hash := header.Hash()
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		seen[hash] = struct{}{}
+		branches = append(branches, &branch{head: header, weight: new(big.Int)})
+
+		if number := header.Number.Uint64(); number > highest {
+			highest = number
+		}
+	}
+	risk := &ReorgRisk{Tips: len(branches)}
+	for _, b := range branches {
+		if b.head.Number.Uint64() == highest {
+			risk.TipsAtHeight++
+		}
+	}
+	// Walk the branches back, always stepping the highest ones, until all of
+	// them converge on the same header.
+	for {
+		var (
+			top       = branches[0].head.Number.Uint64()
+			first     = branches[0].head.Hash()
+			converged = true
+		)
+		for _, b := range branches[1:] {
+			top = max(top, b.head.Number.Uint64())
+			if b.head.Hash() != first {
+				converged = false
+			}
+		}
+		if converged {
+			break
+		}
+		if top == 0 {
+			return nil, errNoCommonAncestor
+		}
+		for _, b := range branches {
+			number := b.head.Number.Uint64()
+			if number != top {
+				continue
+			}
+			if b.head.Difficulty != nil {
+				b.weight.Add(b.weight, b.head.Difficulty)
+			}
+			parent := bc.GetHeader(b.head.ParentHash, number-1)
+			if parent == nil {
+				return nil, fmt.Errorf("missing ancestor #%d [%x]", number-1, b.head.ParentHash)
+			}
+			b.head = parent
+		}
+	}
+	risk.Ancestor = branches[0].head
+	risk.Depth = highest - risk.Ancestor.Number.Uint64()
+
+	heaviest, lightest := branches[0].weight, branches[0].weight
+	for _, b := range branches[1:] {
+		if b.weight.Cmp(heaviest) > 0 {
+			heaviest = b.weight
+		}
+		if b.weight.Cmp(lightest) < 0 {
+			lightest = b.weight
+		}
+	}
+	risk.WeightSpread = new(big.Int).Sub(heaviest, lightest)
+	return risk, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// ErrMissingPreimage is returned by ForEachContract if the address of a contract
+// is unknown, e.g. because preimage recording is disabled.
+var ErrMissingPreimage = errors.New("missing address preimage")
+
+// ForEachContract iterates over all the accounts with non-empty code in the state
+// of the given root, in the order of their hashed addresses, invoking fn with the
+// address, the code hash and the code size of each of them until it returns false.
+//
+// The accounts are read from the snapshot if it covers the root, falling back
+// to iterating the account trie otherwise. The code sizes are served from the
+// code size cache where possible. The addresses are resolved from the recorded
+// preimages, the iteration fails with ErrMissingPreimage at the first contract
+// without one.
+func (s *StateDB) ForEachContract(root common.Hash, fn func(addr common.Address, codeHash common.Hash, size int) bool) error {
+	preimages := s.db.TrieDB()
+	if preimages == nil {
+		return fmt.Errorf("%w: no preimage store", ErrMissingPreimage)
+	}
+	reader, err := s.db.Reader(root)
+	if err != nil {
+		return err
+	}
+	onAccount := func(hash common.Hash, account *types.StateAccount) (bool, error) {
+		if bytes.Equal(account.CodeHash, types.EmptyCodeHash.Bytes()) {
+			return true, nil
+		}
+		preimage := preimages.Preimage(hash)
+		if preimage == nil {
+			return false, fmt.Errorf("%w: account hash %x", ErrMissingPreimage, hash)
+		}
+		var (
+			addr     = common.BytesToAddress(preimage)
+			codeHash = common.BytesToHash(account.CodeHash)
+		)
+		size, err := reader.CodeSize(addr, codeHash)
+		if err != nil {
+			return false, err
+		}
+		return fn(addr, codeHash, size), nil
+	}
+	return s.forEachAccount(root, onAccount)
+}
+
+// forEachAccount iterates over all the accounts in the state of the given root,
+// preferring the snapshot over the account trie.
+func (s *StateDB) forEachAccount(root common.Hash, fn func(hash common.Hash, account *types.StateAccount) (bool, error)) error {
+	if snaps := s.db.Snapshot(); snaps != nil {
+		if it, err := snaps.AccountIterator(root, common.Hash{}); err == nil {
+			defer it.Release()
+
+			for it.Next() {
+				account, err := types.FullAccount(it.Account())
+				if err != nil {
+					return err
+				}
+				if ok, err := fn(it.Hash(), account); !ok || err != nil {
+					return err
+				}
+			}
+			return it.Error()
+		}
+	}
+	tr, err := s.db.OpenTrie(root)
+	if err != nil {
+		return err
+	}
+	nodeIt, err := tr.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(nodeIt)
+	for it.Next() {
+		var account types.StateAccount
+		if err := rlp.DecodeBytes(it.Value, &account); err != nil {
+			return err
+		}
+		if ok, err := fn(common.BytesToHash(it.Key), &account); !ok || err != nil {
+			return err
+		}
+	}
+	return it.Err
+}
@@ -141,6 +141,23 @@ type Trie interface {
 	IsVerkle() bool
 }
 
+// TrieBackend opens the tries the state is stored in, allowing an alternative
+// trie implementation to be plugged into the state database. The state is laid
+// out in two levels, an account trie and a storage trie per account, even if the
+// backend keeps everything in a single tree.
+//
+// The tries must commit their nodes into node sets consumable by the trie
+// database, and must implement Copy() Trie for the state to be copyable. Any
+// implementation is expected to pass the conformance suite in core/state/trietest.
+type TrieBackend interface {
+	// OpenTrie opens the main account trie at the given root.
+	OpenTrie(root common.Hash) (Trie, error)
+
+	// OpenStorageTrie opens the storage trie of an account. The account trie
+	// is supplied as self, for backends keeping all state in a single tree.
+	OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash, self Trie) (Trie, error)
+}
+
 // CachingDB is an implementation of Database interface. It leverages both trie and
 // state snapshot to provide functionalities for state access. It's meant to be a
 // long-live object and has a few caches inside for sharing between blocks.
@@ -151,6 +168,8 @@ type CachingDB struct {
 	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
 	codeSizeCache *lru.Cache[common.Hash, int]
 	pointCache    *utils.PointCache
+	storageCaches *storageCaches
+	backend       TrieBackend // Alternative trie backend, nil to use the builtin tries
 }
 
 // NewDatabase creates a state database with the provided data sources.
@@ -162,6 +181,7 @@ func NewDatabase(triedb *triedb.Database, snap *snapshot.Tree) *CachingDB {
 		codeCache:     lru.NewSizeConstrainedCache[common.Hash, []byte](codeCacheSize),
 		codeSizeCache: lru.NewCache[common.Hash, int](codeSizeCacheSize),
 		pointCache:    utils.NewPointCache(pointCacheSize),
+		storageCaches: new(storageCaches),
 	}
 }
 
@@ -171,6 +191,14 @@ func NewDatabaseForTesting() *CachingDB {
 	return NewDatabase(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil), nil)
 }
 
+// WithTrieBackend configures the state database to open its tries through the
+// given backend instead of the builtin merkle and verkle tries. The dedicated
+// storage caches are not applied to the tries of a custom backend.
+func (db *CachingDB) WithTrieBackend(backend TrieBackend) *CachingDB {
+	db.backend = backend
+	return db
+}
+
 // Reader returns a state reader associated with the specified state root.
 func (db *CachingDB) Reader(stateRoot common.Hash) (Reader, error) {
 	var readers []StateReader
@@ -195,7 +223,7 @@ func (db *CachingDB) Reader(stateRoot common.Hash) (Reader, error) {
 	}
 	// Set up the trie reader, which is expected to always be available
 	// as the gatekeeper unless the state is corrupted.
-	tr, err := newTrieReader(stateRoot, db.triedb, db.pointCache)
+	tr, err := newTrieReader(stateRoot, db)
 	if err != nil {
 		return nil, err
 	}
@@ -210,6 +238,9 @@ func (db *CachingDB) Reader(stateRoot common.Hash) (Reader, error) {
 
 // OpenTrie opens the main account trie at a specific root hash.
 func (db *CachingDB) OpenTrie(root common.Hash) (Trie, error) {
+	if db.backend != nil {
+		return db.backend.OpenTrie(root)
+	}
 	if db.triedb.IsVerkle() {
 		return trie.NewVerkleTrie(root, db.triedb, db.pointCache)
 	}
@@ -222,13 +253,17 @@ func (db *CachingDB) OpenTrie(root common.Hash) (Trie, error) {
 
 // OpenStorageTrie opens the storage trie of an account.
 func (db *CachingDB) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash, self Trie) (Trie, error) {
+	if db.backend != nil {
+		return db.backend.OpenStorageTrie(stateRoot, address, root, self)
+	}
 	// In the verkle case, there is only one tree. But the two-tree structure
 	// is hardcoded in the codebase. So we need to return the same trie in this
 	// case.
 	if db.triedb.IsVerkle() {
 		return self, nil
 	}
-	tr, err := trie.NewStateTrie(trie.StorageTrieID(stateRoot, crypto.Keccak256Hash(address.Bytes()), root), db.triedb)
+	owner := crypto.Keccak256Hash(address.Bytes())
+	tr, err := trie.NewStateTrie(trie.StorageTrieID(stateRoot, owner, root), db.storageCaches.nodeDatabase(db.triedb, owner))
 	if err != nil {
 		return nil, err
 	}
@@ -251,6 +286,14 @@ func (db *CachingDB) ContractCodeWithPrefix(address common.Address, codeHash com
 	return code
 }
 
+// SetStorageCacheSize configures a dedicated clean cache with the given size in
+// bytes for the storage trie nodes of the account, keeping them resident regardless
+// of the pressure on the shared trie node cache. A zero size removes the cache.
+// Storage tries opened earlier are not affected.
+func (db *CachingDB) SetStorageCacheSize(address common.Address, size int) {
+	db.storageCaches.setSize(crypto.Keccak256Hash(address.Bytes()), size)
+}
+
 // TrieDB retrieves any intermediate trie-node caching layer.
 func (db *CachingDB) TrieDB() *triedb.Database {
 	return db.triedb
@@ -273,6 +316,8 @@ func mustCopyTrie(t Trie) Trie {
 		return t.Copy()
 	case *trie.VerkleTrie:
 		return t.Copy()
+	case interface{ Copy() Trie }:
+		return t.Copy()
 	default:
 		panic(fmt.Errorf("unknown trie type %T", t))
 	}
@@ -396,7 +396,12 @@ func (ch codeChange) copy() journalEntry {
 }
 
 func (ch storageChange) revert(s *StateDB) {
-	s.getStateObject(ch.account).setState(ch.key, ch.prevvalue, ch.origvalue)
+	obj := s.getStateObject(ch.account)
+	if s.slotSink != nil {
+		current, _ := obj.getState(ch.key)
+		s.emitSlotChange(ch.account, ch.key, current, ch.prevvalue, true)
+	}
+	obj.setState(ch.key, ch.prevvalue, ch.origvalue)
 }
 
 func (ch storageChange) dirtied() *common.Address {
@@ -0,0 +1,148 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// StorageChange is the net modification of a single storage slot.
+type StorageChange struct {
+	Prev  common.Hash // Value of the slot before the state transition
+	Value common.Hash // Value of the slot after the state transition
+}
+
+// AccountChange is the net modification of a single account, measured against
+// the state the StateDB was opened with (or last committed).
+type AccountChange struct {
+	Address common.Address
+
+	Created bool // The account did not exist before the state transition
+	Deleted bool // The account will be removed from the state on commit
+	Wiped   bool // The original storage of the account is discarded entirely
+
+	PrevBalance  *uint256.Int
+	Balance      *uint256.Int
+	PrevNonce    uint64
+	Nonce        uint64
+	PrevCodeHash common.Hash
+	CodeHash     common.Hash
+
+	// Storage contains the slots whose value differs from the original one.
+	// If Wiped is set, slots which are cleared as a side effect of the wipe
+	// are not listed.
+	Storage map[common.Hash]StorageChange
+}
+
+// PendingChanges returns the set of accounts and storage slots which would be
+// written to the database if the state was committed now. The changes reflect
+// the net effect of all the mutations made since the last commit, including
+// the current not yet finalised transaction; reverted modifications and values
+// set back to their original one are not reported.
+//
+// Accounts emptied or touched by a transaction are reported as they are; the
+// EIP-158 deletion is only decided when the state is finalised.
+func (s *StateDB) PendingChanges() map[common.Address]*AccountChange {
+	changes := make(map[common.Address]*AccountChange)
+	for addr := range s.mutations {
+		if change := s.pendingChange(addr); change != nil {
+			changes[addr] = change
+		}
+	}
+	for addr := range s.journal.dirties {
+		if _, ok := changes[addr]; ok {
+			continue
+		}
+		if change := s.pendingChange(addr); change != nil {
+			changes[addr] = change
+		}
+	}
+	return changes
+}
+
+// pendingChange computes the net modification of the given account, or nil if
+// the account is left untouched.
+func (s *StateDB) pendingChange(addr common.Address) *AccountChange {
+	var (
+		obj        = s.stateObjects[addr]
+		origin     *types.StateAccount
+		destructed bool
+		change     = &AccountChange{Address: addr}
+	)
+	if prev, ok := s.stateObjectsDestruct[addr]; ok {
+		origin, destructed = prev.origin, true
+	} else if obj != nil {
+		origin = obj.origin
+	}
+	// Resolve the original account metadata
+	if origin == nil {
+		change.Created = true
+		origin = types.NewEmptyStateAccount()
+	}
+	change.PrevBalance = origin.Balance.Clone()
+	change.PrevNonce = origin.Nonce
+	change.PrevCodeHash = common.BytesToHash(origin.CodeHash)
+
+	// Resolve the account metadata after the state transition. The account
+	// is regarded as deleted if it's already removed from the live set or
+	// it's self-destructed within the current transaction.
+	if obj == nil || obj.selfDestructed {
+		if change.Created {
+			return nil // created and deleted without leaving any trace
+		}
+		change.Deleted, change.Wiped = true, true
+		change.Balance = new(uint256.Int)
+		change.CodeHash = types.EmptyCodeHash
+		return change
+	}
+	change.Wiped = destructed
+	change.Balance = obj.data.Balance.Clone()
+	change.Nonce = obj.data.Nonce
+	change.CodeHash = common.BytesToHash(obj.data.CodeHash)
+
+	// Aggregate the storage changes, dirty slots of the current transaction
+	// take precedence over the pending ones.
+	for key, value := range obj.pendingStorage {
+		if _, dirty := obj.dirtyStorage[key]; dirty {
+			continue
+		}
+		if prev := obj.originStorage[key]; prev != value {
+			if change.Storage == nil {
+				change.Storage = make(map[common.Hash]StorageChange)
+			}
+			change.Storage[key] = StorageChange{Prev: prev, Value: value}
+		}
+	}
+	for key, value := range obj.dirtyStorage {
+		if prev := obj.originStorage[key]; prev != value {
+			if change.Storage == nil {
+				change.Storage = make(map[common.Hash]StorageChange)
+			}
+			change.Storage[key] = StorageChange{Prev: prev, Value: value}
+		}
+	}
+	// Drop the account if nothing has been changed at all
+	if !change.Wiped && len(change.Storage) == 0 &&
+		change.PrevBalance.Eq(change.Balance) &&
+		change.PrevNonce == change.Nonce &&
+		change.PrevCodeHash == change.CodeHash {
+		return nil
+	}
+	return change
+}
@@ -18,6 +18,8 @@ package state
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
@@ -27,8 +29,6 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
-	"github.com/ethereum/go-ethereum/trie/utils"
-	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/database"
 )
 
@@ -198,8 +198,7 @@ func (r *flatReader) Storage(addr common.Address, key common.Hash) (common.Hash,
 // state from the referenced trie.
 type trieReader struct {
 	root     common.Hash                    // State root which uniquely represent a state
-	db       *triedb.Database               // Database for loading trie
-	buff     crypto.KeccakState             // Buffer for keccak256 hashing
+	db       *CachingDB                     // Database for opening tries
 	mainTrie Trie                           // Main trie, resolved in constructor
 	subRoots map[common.Address]common.Hash // Set of storage roots, cached when the account is resolved
 	subTries map[common.Address]Trie        // Group of storage tries, cached when it's resolved
@@ -207,23 +206,14 @@ type trieReader struct {
 
 // trieReader constructs a trie reader of the specific state. An error will be
 // returned if the associated trie specified by root is not existent.
-func newTrieReader(root common.Hash, db *triedb.Database, cache *utils.PointCache) (*trieReader, error) {
-	var (
-		tr  Trie
-		err error
-	)
-	if !db.IsVerkle() {
-		tr, err = trie.NewStateTrie(trie.StateTrieID(root), db)
-	} else {
-		tr, err = trie.NewVerkleTrie(root, db, cache)
-	}
+func newTrieReader(root common.Hash, db *CachingDB) (*trieReader, error) {
+	tr, err := db.OpenTrie(root)
 	if err != nil {
 		return nil, err
 	}
 	return &trieReader{
 		root:     root,
 		db:       db,
-		buff:     crypto.NewKeccakState(),
 		mainTrie: tr,
 		subRoots: make(map[common.Address]common.Hash),
 		subTries: make(map[common.Address]Trie),
@@ -253,34 +243,28 @@ func (r *trieReader) Account(addr common.Address) (*types.StateAccount, error) {
 // An error will be returned if the trie state is corrupted. An empty storage
 // slot will be returned if it's not existent in the trie.
 func (r *trieReader) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
-	var (
-		tr    Trie
-		found bool
-		value common.Hash
-	)
-	if r.db.IsVerkle() {
-		tr = r.mainTrie
-	} else {
-		tr, found = r.subTries[addr]
-		if !found {
-			root, ok := r.subRoots[addr]
-
-			// The storage slot is accessed without account caching. It's unexpected
-			// behavior but try to resolve the account first anyway.
-			if !ok {
-				_, err := r.Account(addr)
-				if err != nil {
-					return common.Hash{}, err
-				}
-				root = r.subRoots[addr]
-			}
-			var err error
-			tr, err = trie.NewStateTrie(trie.StorageTrieID(r.root, crypto.HashData(r.buff, addr.Bytes()), root), r.db)
+	var value common.Hash
+
+	tr, found := r.subTries[addr]
+	if !found {
+		root, ok := r.subRoots[addr]
+
+		// The storage slot is accessed without account caching. It's unexpected
+		// behavior but try to resolve the account first anyway.
+		if !ok {
+			_, err := r.Account(addr)
 			if err != nil {
 				return common.Hash{}, err
 			}
-			r.subTries[addr] = tr
+			root = r.subRoots[addr]
 		}
+		// In the verkle case there is only one tree, which is returned here as is.
+		var err error
+		tr, err = r.db.OpenStorageTrie(r.root, addr, root, r.mainTrie)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		r.subTries[addr] = tr
 	}
 	ret, err := tr.GetStorage(addr, key.Bytes())
 	if err != nil {
@@ -358,3 +342,129 @@ func newReader(codeReader ContractCodeReader, stateReader StateReader) *reader {
 		StateReader:        stateReader,
 	}
 }
+
+// fallbackCache holds the state retrieved from a fallback reader, shared by the
+// copies of a state.
+type fallbackCache struct {
+	remote   Reader
+	accounts map[common.Address]*types.StateAccount
+	storage  map[common.Address]map[common.Hash]common.Hash
+	code     map[common.Hash][]byte
+	lock     sync.Mutex
+}
+
+func newFallbackCache(remote Reader) *fallbackCache {
+	return &fallbackCache{
+		remote:   remote,
+		accounts: make(map[common.Address]*types.StateAccount),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+		code:     make(map[common.Hash][]byte),
+	}
+}
+
+// fallbackReader implements Reader, consulting a fallback reader for the
+// accounts, storage slots and contract code missing from the local one. The
+// retrieved values are cached, not fetched again on repeated reads.
+//
+// An account or slot is only considered missing if the local trie nodes holding
+// it are, as absent, deleted and zero values can't be told apart otherwise.
+type fallbackReader struct {
+	local Reader
+	cache *fallbackCache
+}
+
+// newFallbackReader constructs a reader falling back to the cached remote state
+// for the values missing from the local one.
+func newFallbackReader(local Reader, cache *fallbackCache) *fallbackReader {
+	return &fallbackReader{local: local, cache: cache}
+}
+
+// isMissingNode reports whether the error is caused by trie nodes missing from
+// the local database.
+func isMissingNode(err error) bool {
+	var missing *trie.MissingNodeError
+	return errors.As(err, &missing)
+}
+
+// Account implementing StateReader interface, retrieving the account associated
+// with a particular address from the local state, or the fallback if missing.
+func (r *fallbackReader) Account(addr common.Address) (*types.StateAccount, error) {
+	acct, err := r.local.Account(addr)
+	if !isMissingNode(err) {
+		return acct, err
+	}
+	r.cache.lock.Lock()
+	defer r.cache.lock.Unlock()
+
+	acct, ok := r.cache.accounts[addr]
+	if !ok {
+		if acct, err = r.cache.remote.Account(addr); err != nil {
+			return nil, err
+		}
+		r.cache.accounts[addr] = acct
+	}
+	if acct == nil {
+		return nil, nil
+	}
+	return acct.Copy(), nil
+}
+
+// Storage implementing StateReader interface, retrieving the storage slot
+// associated with a particular account address and slot key from the local
+// state, or the fallback if missing, e.g. if the storage trie of the account
+// can't be resolved.
+func (r *fallbackReader) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	value, err := r.local.Storage(addr, slot)
+	if !isMissingNode(err) {
+		return value, err
+	}
+	r.cache.lock.Lock()
+	defer r.cache.lock.Unlock()
+
+	if value, ok := r.cache.storage[addr][slot]; ok {
+		return value, nil
+	}
+	if value, err = r.cache.remote.Storage(addr, slot); err != nil {
+		return common.Hash{}, err
+	}
+	if r.cache.storage[addr] == nil {
+		r.cache.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	r.cache.storage[addr][slot] = value
+	return value, nil
+}
+
+// Code implementing ContractCodeReader interface, retrieving a particular
+// contract's code from the local state, or the fallback if missing. The code
+// retrieved from the fallback is verified against the code hash.
+func (r *fallbackReader) Code(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	code, err := r.local.Code(addr, codeHash)
+	if err != nil || len(code) != 0 || codeHash == types.EmptyCodeHash {
+		return code, err
+	}
+	r.cache.lock.Lock()
+	defer r.cache.lock.Unlock()
+
+	if code, ok := r.cache.code[codeHash]; ok {
+		return code, nil
+	}
+	if code, err = r.cache.remote.Code(addr, codeHash); err != nil {
+		return nil, err
+	}
+	if len(code) != 0 && crypto.Keccak256Hash(code) != codeHash {
+		return nil, fmt.Errorf("fallback code hash mismatch: have %x, want %x", crypto.Keccak256Hash(code), codeHash)
+	}
+	r.cache.code[codeHash] = code
+	return code, nil
+}
+
+// CodeSize implementing ContractCodeReader interface, retrieving a particular
+// contract code's size from the local state, or the fallback if missing.
+func (r *fallbackReader) CodeSize(addr common.Address, codeHash common.Hash) (int, error) {
+	size, err := r.local.CodeSize(addr, codeHash)
+	if err != nil || size != 0 {
+		return size, err
+	}
+	code, err := r.Code(addr, codeHash)
+	return len(code), err
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import "github.com/ethereum/go-ethereum/common"
+
+// SlotChange is a modification of a storage slot during execution.
+type SlotChange struct {
+	Address  common.Address
+	Slot     common.Hash
+	Old      common.Hash // Value of the slot before the change
+	New      common.Hash // Value of the slot after the change
+	TxIndex  int         // Index of the transaction within the block, as set by SetTxContext
+	Reverted bool        // Whether the change compensates an earlier one being reverted
+}
+
+// SlotChangeSink receives the storage slot changes of a state as they happen.
+type SlotChangeSink func(change SlotChange)
+
+// SetSlotChangeSink sets the receiver of the storage slot changes done through
+// SetState, or removes it if nil. Reverting a change, e.g. due to a failed call,
+// emits a compensating change restoring the previous value, so that replaying all
+// the changes in order yields the final state. The same holds across rolling back
+// to a block checkpoint.
+//
+// The sink is called synchronously, blocking execution, and is not carried over
+// by copies of the state.
+func (s *StateDB) SetSlotChangeSink(sink SlotChangeSink) {
+	s.slotSink = sink
+}
+
+// emitSlotChange reports a storage slot change to the sink, if any.
+func (s *StateDB) emitSlotChange(addr common.Address, slot, prev, value common.Hash, reverted bool) {
+	if s.slotSink == nil {
+		return
+	}
+	if s.checkpointSlots != nil {
+		if s.checkpointSlots[addr] == nil {
+			s.checkpointSlots[addr] = make(map[common.Hash]common.Hash)
+		}
+		s.checkpointSlots[addr][slot] = value
+	}
+	s.slotSink(SlotChange{
+		Address:  addr,
+		Slot:     slot,
+		Old:      prev,
+		New:      value,
+		TxIndex:  s.txIndex,
+		Reverted: reverted,
+	})
+}
@@ -53,7 +53,7 @@ type (
 // GenerateTrie takes the whole snapshot tree as the input, traverses all the
 // accounts as well as the corresponding storages and regenerate the whole state
 // (account trie + all storage tries).
-func GenerateTrie(snaptree *Tree, root common.Hash, src ethdb.Database, dst ethdb.KeyValueWriter) error {
+func GenerateTrie(snaptree *Tree, root common.Hash, src ethdb.KeyValueReader, dst ethdb.KeyValueWriter) error {
 	// Traverse all state by snapshot, re-generate the whole state trie
 	acctIt, err := snaptree.AccountIterator(root, common.Hash{})
 	if err != nil {
@@ -94,6 +94,76 @@ func GenerateTrie(snaptree *Tree, root common.Hash, src ethdb.Database, dst ethd
 	return nil
 }
 
+// RebuildTrieFromSnapshot regenerates the account trie and the storage tries of
+// the given state root from the snapshot, e.g. to recover from a corrupted trie,
+// and verifies the regenerated root matches the expected one. The trie nodes are
+// written into the snapshot database in batches as they are generated, so the
+// whole state is never held in memory. Progress is logged periodically.
+//
+// Only the hash scheme is supported: trie nodes being keyed by their hash, the
+// nodes regenerated for a mismatching state are merely left unreferenced, while
+// the path scheme would overwrite the nodes of the persistent state in place.
+func RebuildTrieFromSnapshot(snaptree *Tree, root common.Hash) error {
+	if scheme := snaptree.triedb.Scheme(); scheme != rawdb.HashScheme {
+		return fmt.Errorf("trie rebuild unsupported in %s scheme", scheme)
+	}
+	writer := &batchWriter{batch: snaptree.diskdb.NewBatch()}
+	if err := GenerateTrie(snaptree, root, snaptree.diskdb, writer); err != nil {
+		return err
+	}
+	return writer.flush()
+}
+
+// batchWriter is a key-value writer accumulating the writes into a batch which
+// is flushed into the database whenever its size exceeds ethdb.IdealBatchSize.
+// It is safe for concurrent use, as the tries are regenerated concurrently.
+type batchWriter struct {
+	batch ethdb.Batch
+	lock  sync.Mutex
+}
+
+// Put inserts the given value into the batch, flushing it if full.
+func (w *batchWriter) Put(key []byte, value []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := w.batch.Put(key, value); err != nil {
+		return err
+	}
+	return w.maybeFlush()
+}
+
+// Delete removes the key from the database through the batch, flushing it if full.
+func (w *batchWriter) Delete(key []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := w.batch.Delete(key); err != nil {
+		return err
+	}
+	return w.maybeFlush()
+}
+
+// maybeFlush writes out the batch if full. The lock is assumed to be held.
+func (w *batchWriter) maybeFlush() error {
+	if w.batch.ValueSize() <= ethdb.IdealBatchSize {
+		return nil
+	}
+	if err := w.batch.Write(); err != nil {
+		return err
+	}
+	w.batch.Reset()
+	return nil
+}
+
+// flush writes out any pending writes of the batch.
+func (w *batchWriter) flush() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.batch.Write()
+}
+
 // generateStats is a collection of statistics gathered by the trie generator
 // for logging purposes.
 type generateStats struct {
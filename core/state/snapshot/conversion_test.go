@@ -0,0 +1,95 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/hashdb"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the tries of a state are regenerated from the snapshot after being
+// corrupted.
+func TestRebuildTrieFromSnapshot(t *testing.T) {
+	var (
+		helper   = newHelper(rawdb.HashScheme)
+		code     = []byte{0x60, 0x00}
+		codeHash = crypto.Keccak256(code)
+	)
+	rawdb.WriteCode(helper.diskdb, common.BytesToHash(codeHash), code)
+
+	stRoot := helper.makeStorageTrie("acc-1", []string{"key-1", "key-2", "key-3"}, []string{"val-1", "val-2", "val-3"}, true)
+	helper.addTrieAccount("acc-1", &types.StateAccount{Balance: uint256.NewInt(1), Root: stRoot, CodeHash: codeHash})
+	helper.addTrieAccount("acc-2", &types.StateAccount{Balance: uint256.NewInt(2), Root: types.EmptyRootHash, CodeHash: types.EmptyCodeHash.Bytes()})
+
+	root, snap := helper.CommitAndGenerate()
+	select {
+	case <-snap.genPending:
+	case <-time.After(3 * time.Second):
+		t.Fatal("snapshot generation failed")
+	}
+	stop := make(chan *generatorStats)
+	snap.genAbort <- stop
+	<-stop
+
+	snaps := &Tree{
+		diskdb: helper.diskdb,
+		triedb: helper.triedb,
+		layers: map[common.Hash]snapshot{root: snap},
+	}
+	// Corrupt both the account and the storage trie
+	rawdb.DeleteLegacyTrieNode(helper.diskdb, root)
+	rawdb.DeleteLegacyTrieNode(helper.diskdb, stRoot)
+
+	fresh := triedb.NewDatabase(helper.diskdb, &triedb.Config{HashDB: &hashdb.Config{}})
+	if _, err := trie.NewStateTrie(trie.StateTrieID(root), fresh); err == nil {
+		t.Fatal("account trie not corrupted")
+	}
+	if err := RebuildTrieFromSnapshot(snaps, root); err != nil {
+		t.Fatalf("failed to rebuild trie: %v", err)
+	}
+	// Read the state back through a fresh trie database
+	db := triedb.NewDatabase(helper.diskdb, &triedb.Config{HashDB: &hashdb.Config{}})
+	accTrie, err := trie.NewStateTrie(trie.StateTrieID(root), db)
+	if err != nil {
+		t.Fatalf("failed to open account trie: %v", err)
+	}
+	var acc types.StateAccount
+	if err := rlp.DecodeBytes(accTrie.MustGet([]byte("acc-1")), &acc); err != nil || acc.Root != stRoot {
+		t.Fatalf("account mismatch: %v, %v", acc, err)
+	}
+	stTrie, err := trie.NewStateTrie(trie.StorageTrieID(root, hashData([]byte("acc-1")), stRoot), db)
+	if err != nil {
+		t.Fatalf("failed to open storage trie: %v", err)
+	}
+	if val := stTrie.MustGet([]byte("key-2")); string(val) != "val-2" {
+		t.Fatalf("storage mismatch: have %q, want %q", val, "val-2")
+	}
+	// States without a snapshot can't be rebuilt
+	if err := RebuildTrieFromSnapshot(snaps, common.Hash{0x01}); err == nil {
+		t.Fatal("rebuilt trie of unknown state")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxHash is the last possible account hash.
+var maxHash = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+// AccountCursor is a serializable position within the ordered iteration of the
+// accounts of a state, covering the account hashes from Next to Limit included.
+// It allows an interrupted iteration to be resumed, possibly on another node
+// holding the same state.
+type AccountCursor struct {
+	Root  common.Hash `json:"root"`  // State root being iterated
+	Next  common.Hash `json:"next"`  // Hash of the next account to iterate
+	Limit common.Hash `json:"limit"` // Hash of the last account of the range
+	Done  bool        `json:"done"`  // Whether the range was iterated completely
+}
+
+// NewAccountCursor creates a cursor iterating all the accounts of a state.
+func NewAccountCursor(root common.Hash) AccountCursor {
+	return AccountCursor{Root: root, Limit: maxHash}
+}
+
+// Split divides the remaining range of the cursor into n consecutive cursors of
+// roughly equal width, e.g. to shard an iteration across multiple workers.
+func (c AccountCursor) Split(n int) ([]AccountCursor, error) {
+	if n <= 0 {
+		return nil, errors.New("invalid number of shards")
+	}
+	if c.Done {
+		return []AccountCursor{c}, nil
+	}
+	var (
+		start = new(big.Int).SetBytes(c.Next[:])
+		end   = new(big.Int).SetBytes(c.Limit[:])
+		width = new(big.Int).Sub(end, start)
+	)
+	if width.Sign() < 0 {
+		return nil, errors.New("cursor start beyond limit")
+	}
+	width.Add(width, common.Big1)
+	if width.Cmp(big.NewInt(int64(n))) < 0 {
+		n = int(width.Int64())
+	}
+	width.Div(width, big.NewInt(int64(n)))
+
+	shards := make([]AccountCursor, n)
+	for i := range shards {
+		shards[i] = AccountCursor{Root: c.Root, Next: common.BigToHash(start)}
+		if i == n-1 {
+			shards[i].Limit = c.Limit
+		} else {
+			start.Add(start, width)
+			shards[i].Limit = common.BigToHash(new(big.Int).Sub(start, common.Big1))
+		}
+	}
+	return shards, nil
+}
+
+// CursorAccountIterator is an account iterator walking the range of an account
+// cursor in ascending account hash order, tracking its position.
+type CursorAccountIterator struct {
+	it     AccountIterator
+	cursor AccountCursor
+}
+
+// CursorAccountIterator creates an account iterator resuming the iteration at
+// the position of the given cursor.
+func (t *Tree) CursorAccountIterator(cursor AccountCursor) (*CursorAccountIterator, error) {
+	if cursor.Done {
+		return &CursorAccountIterator{cursor: cursor}, nil
+	}
+	it, err := t.AccountIterator(cursor.Root, cursor.Next)
+	if err != nil {
+		return nil, err
+	}
+	return &CursorAccountIterator{it: it, cursor: cursor}, nil
+}
+
+// Next steps the iterator forward one account within the range of the cursor,
+// returning false if exhausted or failed.
+func (it *CursorAccountIterator) Next() bool {
+	if it.cursor.Done {
+		return false
+	}
+	if !it.it.Next() {
+		if it.it.Error() == nil {
+			it.cursor.Done = true
+		}
+		return false
+	}
+	hash := it.it.Hash()
+	if bytes.Compare(hash[:], it.cursor.Limit[:]) > 0 {
+		it.cursor.Done = true
+		return false
+	}
+	if next := increaseKey(common.CopyBytes(hash[:])); next == nil || hash == it.cursor.Limit {
+		it.cursor.Done = true
+	} else {
+		it.cursor.Next = common.BytesToHash(next)
+	}
+	return true
+}
+
+// Cursor returns the position of the iterator, which resumes the iteration
+// right after the current account.
+func (it *CursorAccountIterator) Cursor() AccountCursor {
+	return it.cursor
+}
+
+// Error returns any failure that occurred during iteration.
+func (it *CursorAccountIterator) Error() error {
+	if it.it == nil {
+		return nil
+	}
+	return it.it.Error()
+}
+
+// Hash returns the hash of the account the iterator is currently at.
+func (it *CursorAccountIterator) Hash() common.Hash {
+	return it.it.Hash()
+}
+
+// Account returns the RLP encoded slim account the iterator is currently at.
+func (it *CursorAccountIterator) Account() []byte {
+	return it.it.Account()
+}
+
+// Release releases associated resources.
+func (it *CursorAccountIterator) Release() {
+	if it.it != nil {
+		it.it.Release()
+	}
+}
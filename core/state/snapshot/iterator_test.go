@@ -20,8 +20,11 @@ import (
 	"bytes"
 	crand "crypto/rand"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/VictoriaMetrics/fastcache"
@@ -677,6 +680,87 @@ func testAccountIteratorSeek(t *testing.T, newIterator func(snaps *Tree, root, s
 	verifyIterator(t, 0, it, verifyAccount) // expected: nothing
 }
 
+// TestCursorAccountIterator tests that cursor iterations can be interrupted,
+// serialized and resumed, and that split cursors cover the same accounts.
+func TestCursorAccountIterator(t *testing.T) {
+	base := &diskLayer{
+		diskdb: rawdb.NewMemoryDatabase(),
+		root:   common.HexToHash("0x01"),
+		cache:  fastcache.New(1024 * 500),
+	}
+	snaps := &Tree{
+		layers: map[common.Hash]snapshot{
+			base.root: base,
+		},
+	}
+	snaps.Update(common.HexToHash("0x02"), common.HexToHash("0x01"),
+		randomAccountSet("0xaa", "0xee", "0xff", "0xf0"), nil)
+	snaps.Update(common.HexToHash("0x03"), common.HexToHash("0x02"),
+		randomAccountSet("0xbb", "0xdd", "0xf0", "0x10"), nil)
+	root := common.HexToHash("0x03")
+
+	collect := func(cursor AccountCursor, max int) ([]common.Hash, AccountCursor) {
+		it, err := snaps.CursorAccountIterator(cursor)
+		if err != nil {
+			t.Fatalf("failed to create iterator: %v", err)
+		}
+		defer it.Release()
+
+		var hashes []common.Hash
+		for len(hashes) < max && it.Next() {
+			hashes = append(hashes, it.Hash())
+		}
+		if err := it.Error(); err != nil {
+			t.Fatalf("iteration failed: %v", err)
+		}
+		return hashes, it.Cursor()
+	}
+	all, cursor := collect(NewAccountCursor(root), math.MaxInt)
+	if len(all) != 7 || !cursor.Done {
+		t.Fatalf("full iteration mismatch: %d accounts, done %v", len(all), cursor.Done)
+	}
+	// Interrupt the iteration, round-trip the cursor and resume it
+	var (
+		resumed []common.Hash
+		hashes  []common.Hash
+	)
+	for cursor = NewAccountCursor(root); !cursor.Done; {
+		hashes, cursor = collect(cursor, 4)
+		resumed = append(resumed, hashes...)
+
+		blob, err := json.Marshal(cursor)
+		if err != nil {
+			t.Fatalf("failed to encode cursor: %v", err)
+		}
+		cursor = AccountCursor{}
+		if err := json.Unmarshal(blob, &cursor); err != nil {
+			t.Fatalf("failed to decode cursor: %v", err)
+		}
+	}
+	if !reflect.DeepEqual(resumed, all) {
+		t.Fatalf("resumed iteration mismatch: have %x, want %x", resumed, all)
+	}
+	// Split the range into shards, iterated in order
+	shards, err := (AccountCursor{Root: root, Limit: common.HexToHash("0xff")}).Split(3)
+	if err != nil {
+		t.Fatalf("failed to split cursor: %v", err)
+	}
+	if len(shards) != 3 || shards[1].Next != common.HexToHash("0x55") || shards[2].Next != common.HexToHash("0xaa") {
+		t.Fatalf("unexpected shards: %v", shards)
+	}
+	var sharded []common.Hash
+	for _, shard := range shards {
+		hashes, cursor := collect(shard, math.MaxInt)
+		if !cursor.Done {
+			t.Fatalf("shard %v not completed", shard)
+		}
+		sharded = append(sharded, hashes...)
+	}
+	if !reflect.DeepEqual(sharded, all) {
+		t.Fatalf("sharded iteration mismatch: have %x, want %x", sharded, all)
+	}
+}
+
 func TestStorageIteratorSeek(t *testing.T) {
 	t.Run("fast", func(t *testing.T) {
 		testStorageIteratorSeek(t, func(snaps *Tree, root, account, seek common.Hash) StorageIterator {
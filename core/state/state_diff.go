@@ -0,0 +1,177 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// StateDiffResult is the set of accounts and storage slots differing between two
+// states, keyed by their hashes.
+type StateDiffResult struct {
+	Accounts map[common.Hash]*AccountDiff
+}
+
+// AccountDiff is the difference of an account between two states. Prev is nil
+// for accounts added and Next is nil for accounts deleted.
+type AccountDiff struct {
+	Prev    *types.StateAccount
+	Next    *types.StateAccount
+	Storage map[common.Hash]SlotDiff // Slots differing, all of them for added and deleted accounts
+}
+
+// SlotDiff is the difference of a storage slot between two states, the value of
+// a missing slot being zero.
+type SlotDiff struct {
+	Prev common.Hash
+	Next common.Hash
+}
+
+// StateDiff computes the accounts and storage slots differing between the states
+// of the given roots, both of which must be available. The tries are walked in
+// tandem, skipping the subtries identical in both states.
+func (s *StateDB) StateDiff(a, b common.Hash) (*StateDiffResult, error) {
+	if s.db.TrieDB().IsVerkle() {
+		return nil, errors.New("state diff is not supported for verkle")
+	}
+	result := &StateDiffResult{Accounts: make(map[common.Hash]*AccountDiff)}
+	err := s.diffTrie(trie.StateTrieID(a), trie.StateTrieID(b), func(hash common.Hash, prev, next []byte) error {
+		diff := new(AccountDiff)
+		if prev != nil {
+			diff.Prev = new(types.StateAccount)
+			if err := rlp.DecodeBytes(prev, diff.Prev); err != nil {
+				return err
+			}
+		}
+		if next != nil {
+			diff.Next = new(types.StateAccount)
+			if err := rlp.DecodeBytes(next, diff.Next); err != nil {
+				return err
+			}
+		}
+		storage, err := s.diffStorage(a, b, hash, diff)
+		if err != nil {
+			return err
+		}
+		diff.Storage = storage
+		result.Accounts[hash] = diff
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// diffStorage computes the storage slots of an account differing between the
+// states of the given roots.
+func (s *StateDB) diffStorage(a, b common.Hash, hash common.Hash, diff *AccountDiff) (map[common.Hash]SlotDiff, error) {
+	prevRoot, nextRoot := types.EmptyRootHash, types.EmptyRootHash
+	if diff.Prev != nil {
+		prevRoot = diff.Prev.Root
+	}
+	if diff.Next != nil {
+		nextRoot = diff.Next.Root
+	}
+	if prevRoot == nextRoot {
+		return nil, nil
+	}
+	storage := make(map[common.Hash]SlotDiff)
+	err := s.diffTrie(trie.StorageTrieID(a, hash, prevRoot), trie.StorageTrieID(b, hash, nextRoot), func(slot common.Hash, prev, next []byte) error {
+		var diff SlotDiff
+		if prev != nil {
+			_, content, _, err := rlp.Split(prev)
+			if err != nil {
+				return err
+			}
+			diff.Prev = common.BytesToHash(content)
+		}
+		if next != nil {
+			_, content, _, err := rlp.Split(next)
+			if err != nil {
+				return err
+			}
+			diff.Next = common.BytesToHash(content)
+		}
+		storage[slot] = diff
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return storage, nil
+}
+
+// diffTrie invokes fn with the previous and next values of each leaf differing
+// between the two tries, nil being passed for missing leaves.
+func (s *StateDB) diffTrie(a, b *trie.ID, fn func(key common.Hash, prev, next []byte) error) error {
+	trA, err := trie.New(a, s.db.TrieDB())
+	if err != nil {
+		return err
+	}
+	trB, err := trie.New(b, s.db.TrieDB())
+	if err != nil {
+		return err
+	}
+	// Walk the leaves added or modified in the second trie
+	if err := walkDifference(trA, trB, func(key, value []byte) error {
+		prev, err := trA.Get(key)
+		if err != nil {
+			return err
+		}
+		return fn(common.BytesToHash(key), prev, value)
+	}); err != nil {
+		return err
+	}
+	// Walk the leaves deleted from the first trie, the modified ones being
+	// already reported
+	return walkDifference(trB, trA, func(key, value []byte) error {
+		next, err := trB.Get(key)
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			return nil
+		}
+		return fn(common.BytesToHash(key), value, nil)
+	})
+}
+
+// walkDifference invokes fn with the leaves of trie b not present in trie a.
+func walkDifference(a, b *trie.Trie, fn func(key, value []byte) error) error {
+	itA, err := a.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	itB, err := b.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	diff, _ := trie.NewDifferenceIterator(itA, itB)
+	it := trie.NewIterator(diff)
+	for it.Next() {
+		if err := fn(it.Key, it.Value); err != nil {
+			return err
+		}
+	}
+	return it.Err
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
+	"github.com/holiman/uint256"
+)
+
+func TestStateDiff(t *testing.T) {
+	testStateDiff(t, rawdb.HashScheme)
+	testStateDiff(t, rawdb.PathScheme)
+}
+
+func testStateDiff(t *testing.T, scheme string) {
+	config := triedb.HashDefaults
+	if scheme == rawdb.PathScheme {
+		config = &triedb.Config{PathDB: pathdb.Defaults}
+	}
+	db := NewDatabase(triedb.NewDatabase(rawdb.NewMemoryDatabase(), config), nil)
+
+	var (
+		modified  = common.HexToAddress("0x01")
+		untouched = common.HexToAddress("0x02")
+		deleted   = common.HexToAddress("0x03")
+		added     = common.HexToAddress("0x04")
+		slot1     = common.Hash{0x01}
+		slot2     = common.Hash{0x02}
+		slot3     = common.Hash{0x03}
+	)
+	state, _ := New(types.EmptyRootHash, db)
+	for _, addr := range []common.Address{modified, untouched, deleted} {
+		state.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		state.SetState(addr, slot1, common.Hash{0x01})
+		state.SetState(addr, slot2, common.Hash{0x02})
+	}
+	rootA, err := state.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(rootA, db)
+	state.SetBalance(modified, uint256.NewInt(2), tracing.BalanceChangeUnspecified)
+	state.SetState(modified, slot1, common.Hash{0x11}) // modified slot
+	state.SetState(modified, slot2, common.Hash{})     // deleted slot
+	state.SetState(modified, slot3, common.Hash{0x03}) // added slot
+	state.SelfDestruct(deleted)
+	state.SetBalance(added, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.SetState(added, slot1, common.Hash{0x01})
+	rootB, err := state.Commit(1, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(rootB, db)
+
+	diff, err := state.StateDiff(rootA, rootB)
+	if err != nil {
+		t.Fatalf("%s: failed to diff states: %v", scheme, err)
+	}
+	if len(diff.Accounts) != 3 {
+		t.Fatalf("%s: account diff count mismatch: have %d, want 3", scheme, len(diff.Accounts))
+	}
+	check := func(addr common.Address, prev, next bool, storage map[common.Hash]SlotDiff) {
+		t.Helper()
+		acct := diff.Accounts[crypto.Keccak256Hash(addr.Bytes())]
+		if acct == nil {
+			t.Fatalf("%s: missing diff of %x", scheme, addr)
+		}
+		if (acct.Prev != nil) != prev || (acct.Next != nil) != next {
+			t.Fatalf("%s: %x: presence mismatch: have prev %v next %v", scheme, addr, acct.Prev, acct.Next)
+		}
+		if !maps.Equal(acct.Storage, storage) {
+			t.Fatalf("%s: %x: storage diff mismatch: have %v, want %v", scheme, addr, acct.Storage, storage)
+		}
+	}
+	check(modified, true, true, map[common.Hash]SlotDiff{
+		crypto.Keccak256Hash(slot1[:]): {Prev: common.Hash{0x01}, Next: common.Hash{0x11}},
+		crypto.Keccak256Hash(slot2[:]): {Prev: common.Hash{0x02}},
+		crypto.Keccak256Hash(slot3[:]): {Next: common.Hash{0x03}},
+	})
+	check(deleted, true, false, map[common.Hash]SlotDiff{
+		crypto.Keccak256Hash(slot1[:]): {Prev: common.Hash{0x01}},
+		crypto.Keccak256Hash(slot2[:]): {Prev: common.Hash{0x02}},
+	})
+	check(added, false, true, map[common.Hash]SlotDiff{
+		crypto.Keccak256Hash(slot1[:]): {Next: common.Hash{0x01}},
+	})
+	acct := diff.Accounts[crypto.Keccak256Hash(modified.Bytes())]
+	if acct.Prev.Balance.Uint64() != 1 || acct.Next.Balance.Uint64() != 2 {
+		t.Fatalf("%s: balance diff mismatch: have %v -> %v", scheme, acct.Prev.Balance, acct.Next.Balance)
+	}
+	// Identical states don't differ
+	if diff, err := state.StateDiff(rootB, rootB); err != nil || len(diff.Accounts) != 0 {
+		t.Fatalf("%s: identical states differ: %v, err %v", scheme, diff.Accounts, err)
+	}
+}
@@ -219,6 +219,7 @@ func (s *stateObject) SetState(key, value common.Hash) common.Hash {
 	// New value is different, update and journal the change
 	s.db.journal.storageChange(s.address, key, prev, origin)
 	s.setState(key, value, origin)
+	s.db.emitSlotChange(s.address, key, prev, value, false)
 	return prev
 }
 
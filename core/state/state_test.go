@@ -19,10 +19,14 @@ package state
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"maps"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -209,3 +213,62 @@ func TestCreateObjectRevert(t *testing.T) {
 		t.Error("Unexpected account after revert")
 	}
 }
+
+func TestForEachContract(t *testing.T) {
+	var (
+		disk     = rawdb.NewMemoryDatabase()
+		tdb      = triedb.NewDatabase(disk, &triedb.Config{Preimages: true})
+		snaps, _ = snapshot.New(snapshot.Config{CacheSize: 10}, disk, tdb, types.EmptyRootHash)
+		sdb, _   = New(types.EmptyRootHash, NewDatabase(tdb, snaps))
+		want     = make(map[common.Address]int)
+	)
+	for i := byte(0); i < 16; i++ {
+		addr := common.BytesToAddress([]byte{0x01, i})
+		sdb.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		if i%3 == 0 {
+			code := bytes.Repeat([]byte{0x60}, int(i)+1)
+			sdb.SetCode(addr, code)
+			want[addr] = len(code)
+		}
+	}
+	root, err := sdb.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	for name, db := range map[string]Database{"snapshot": NewDatabase(tdb, snaps), "trie": NewDatabase(tdb, nil)} {
+		state, _ := New(root, db)
+		have := make(map[common.Address]int)
+		err := state.ForEachContract(root, func(addr common.Address, codeHash common.Hash, size int) bool {
+			if codeHash != crypto.Keccak256Hash(state.GetCode(addr)) {
+				t.Errorf("%s: code hash mismatch for %x", name, addr)
+			}
+			have[addr] = size
+			return true
+		})
+		if err != nil {
+			t.Fatalf("%s: iteration failed: %v", name, err)
+		}
+		if !maps.Equal(have, want) {
+			t.Errorf("%s: contracts mismatch: have %v, want %v", name, have, want)
+		}
+		// Iteration stops once the callback returns false
+		var visited int
+		state.ForEachContract(root, func(common.Address, common.Hash, int) bool {
+			visited++
+			return false
+		})
+		if visited != 1 {
+			t.Errorf("%s: iteration not aborted, visited %d contracts", name, visited)
+		}
+	}
+	// Contracts can't be iterated without the address preimages
+	state, _ := New(types.EmptyRootHash, NewDatabaseForTesting())
+	state.SetCode(common.Address{0x01}, []byte{0x60})
+	root, err = state.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	if err := state.ForEachContract(root, func(common.Address, common.Hash, int) bool { return true }); !errors.Is(err, ErrMissingPreimage) {
+		t.Errorf("iteration error mismatch: have %v, want %v", err, ErrMissingPreimage)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
@@ -138,6 +139,18 @@ type StateDB struct {
 	// State witness if cross validation is needed
 	witness *stateless.Witness
 
+	// Optional receiver of the storage slot changes, not carried over by Copy
+	slotSink SlotChangeSink
+
+	// Optional state consulted for the values missing locally, shared by copies
+	fallback *fallbackCache
+
+	// Copy of the state taken by BlockCheckpoint, not carried over by Copy
+	blockCheckpoint *StateDB
+
+	// Last slot values reported to the sink since the block checkpoint
+	checkpointSlots map[common.Address]map[common.Hash]common.Hash
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads    time.Duration
 	AccountHashes   time.Duration
@@ -187,6 +200,27 @@ func New(root common.Hash, db Database) (*StateDB, error) {
 	return sdb, nil
 }
 
+// SetFallbackReader sets a reader consulted for the accounts, storage slots and
+// contract code missing from the local database, e.g. to run simulations on a
+// partially synced or pruned state with the help of a remote archive node. The
+// values retrieved from the fallback are cached, shared by the copies of the state.
+// A nil fallback removes any previously set one.
+//
+// Accounts and slots are only fetched if the local trie nodes holding them are
+// missing, absent, deleted and zero values found locally are never overridden.
+// The fallback remains in use after committing the state. Committing changes to
+// the accounts or storage retrieved through the fallback fails though, as only
+// the values are fetched, not the trie nodes needed to update them.
+func (s *StateDB) SetFallbackReader(fallback Reader) {
+	if r, ok := s.reader.(*fallbackReader); ok {
+		s.reader, s.fallback = r.local, nil
+	}
+	if fallback != nil {
+		s.fallback = newFallbackCache(fallback)
+		s.reader = newFallbackReader(s.reader, s.fallback)
+	}
+}
+
 // StartPrefetcher initializes a new trie prefetcher to pull in nodes from the
 // state trie concurrently while the state is mutated so that when we reach the
 // commit phase, most of the needed data is already hot.
@@ -304,6 +338,15 @@ func (s *StateDB) Empty(addr common.Address) bool {
 	return so == nil || so.empty()
 }
 
+// IsEmptyEIP161 returns whether the account exists in the state while being
+// empty according to the EIP161 specification (balance = nonce = code = 0),
+// regardless of its storage. Unlike Empty, it reports false for non-existent
+// accounts, allowing to verify that touched empty accounts were removed.
+func (s *StateDB) IsEmptyEIP161(addr common.Address) bool {
+	so := s.getStateObject(addr)
+	return so != nil && so.empty()
+}
+
 // GetBalance retrieves the balance from the given address or 0 if object not found
 func (s *StateDB) GetBalance(addr common.Address) *uint256.Int {
 	stateObject := s.getStateObject(addr)
@@ -543,6 +586,16 @@ func (s *StateDB) GetTransientState(addr common.Address, key common.Hash) common
 	return s.transientStorage.Get(addr, key)
 }
 
+// GetTransientStorage returns a copy of the non-zero transient storage slots of
+// the given account, or nil if there are none.
+func (s *StateDB) GetTransientStorage(addr common.Address) map[common.Hash]common.Hash {
+	storage, ok := s.transientStorage[addr]
+	if !ok {
+		return nil
+	}
+	return storage.Copy()
+}
+
 //
 // Setting, updating & deleting state object methods.
 //
@@ -651,11 +704,16 @@ func (s *StateDB) CreateContract(addr common.Address) {
 // Snapshots of the copied state cannot be applied to the copy.
 func (s *StateDB) Copy() *StateDB {
 	// Copy all the basic fields, initialize the memory ones
-	reader, _ := s.db.Reader(s.originalRoot) // impossible to fail
+	var reader Reader
+	reader, _ = s.db.Reader(s.originalRoot) // impossible to fail
+	if s.fallback != nil {
+		reader = newFallbackReader(reader, s.fallback)
+	}
 	state := &StateDB{
 		db:                   s.db,
 		trie:                 mustCopyTrie(s.trie),
 		reader:               reader,
+		fallback:             s.fallback,
 		originalRoot:         s.originalRoot,
 		stateObjects:         make(map[common.Address]*stateObject, len(s.stateObjects)),
 		stateObjectsDestruct: make(map[common.Address]*stateObject, len(s.stateObjectsDestruct)),
@@ -718,6 +776,66 @@ func (s *StateDB) RevertToSnapshot(revid int) {
 	s.journal.revertToSnapshot(revid, s)
 }
 
+// BlockCheckpoint records the current state, so that all the changes made from
+// now on, including the ones of finalised transactions, can be discarded with
+// RollbackToBlockCheckpoint, e.g. to abandon a speculatively built block. Unlike
+// revision snapshots, the checkpoint survives Finalise. Taking a checkpoint
+// replaces the previous one, and costs as much as copying the state. Committing
+// the state discards the checkpoint, as it can't be rolled back.
+func (s *StateDB) BlockCheckpoint() {
+	s.blockCheckpoint = s.Copy()
+	s.checkpointSlots = make(map[common.Address]map[common.Hash]common.Hash)
+}
+
+// RollbackToBlockCheckpoint discards all the changes made since the last block
+// checkpoint, which is consumed. The slot change sink, if any, is sent reverted
+// changes restoring the checkpointed value of every slot reported since. It
+// panics if no checkpoint was taken, or if the state was committed since.
+func (s *StateDB) RollbackToBlockCheckpoint() {
+	cp := s.blockCheckpoint
+	if cp == nil {
+		panic("no block checkpoint to roll back to")
+	}
+	for addr, slots := range s.checkpointSlots {
+		for slot, value := range slots {
+			if prev := cp.GetState(addr, slot); prev != value {
+				s.emitSlotChange(addr, slot, value, prev, true)
+			}
+		}
+	}
+	s.blockCheckpoint, s.checkpointSlots = nil, nil
+
+	// The prefetched tries might have been used up by the discarded changes
+	s.StopPrefetcher()
+
+	s.trie = cp.trie
+	s.reader = cp.reader
+	s.originalRoot = cp.originalRoot
+	s.stateObjects = cp.stateObjects
+	s.stateObjectsDestruct = cp.stateObjectsDestruct
+	s.mutations = cp.mutations
+	s.dbErr = cp.dbErr
+	s.refund = cp.refund
+	s.thash = cp.thash
+	s.txIndex = cp.txIndex
+	s.logs = cp.logs
+	s.logSize = cp.logSize
+	s.preimages = cp.preimages
+	s.accessList = cp.accessList
+	s.accessEvents = cp.accessEvents
+	s.transientStorage = cp.transientStorage
+	s.journal = cp.journal
+	s.witness = cp.witness
+
+	// The state objects were copied for the checkpoint, rebind them
+	for _, obj := range s.stateObjects {
+		obj.db = s
+	}
+	for _, obj := range s.stateObjectsDestruct {
+		obj.db = s
+	}
+}
+
 // GetRefund returns the current value of the refund counter.
 func (s *StateDB) GetRefund() uint64 {
 	return s.refund
@@ -1262,6 +1380,9 @@ func (s *StateDB) commit(deleteEmptyObjects bool, noStorageWiping bool) (*stateU
 // commitAndFlush is a wrapper of commit which also commits the state mutations
 // to the configured data stores.
 func (s *StateDB) commitAndFlush(block uint64, deleteEmptyObjects bool, noStorageWiping bool) (*stateUpdate, error) {
+	// The checkpointed tries are stale once committed
+	s.blockCheckpoint, s.checkpointSlots = nil, nil
+
 	ret, err := s.commit(deleteEmptyObjects, noStorageWiping)
 	if err != nil {
 		return nil, err
@@ -1302,6 +1423,9 @@ func (s *StateDB) commitAndFlush(block uint64, deleteEmptyObjects bool, noStorag
 		}
 	}
 	s.reader, _ = s.db.Reader(s.originalRoot)
+	if s.fallback != nil {
+		s.reader = newFallbackReader(s.reader, s.fallback)
+	}
 	return ret, err
 }
 
@@ -1327,6 +1451,38 @@ func (s *StateDB) Commit(block uint64, deleteEmptyObjects bool, noStorageWiping
 	return ret.root, nil
 }
 
+// CommitTo writes the state mutations into the given database instead of the
+// configured data stores, which are left untouched. The trie nodes changed by the
+// mutations are written in the node scheme of the trie database, along with the
+// dirty contract code, so the database receives the difference from the original
+// state. Empty objects are deleted as per EIP-158.
+//
+// The mutations are committed from a copy of the state, which remains usable on
+// top of its original database afterwards.
+func (s *StateDB) CommitTo(db ethdb.KeyValueWriter) (common.Hash, error) {
+	ret, err := s.Copy().commit(true, false)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	for _, code := range ret.codes {
+		rawdb.WriteCode(db, code.hash, code.blob)
+	}
+	scheme := s.db.TrieDB().Scheme()
+	for owner, set := range ret.nodes.Sets {
+		set.ForEachWithOrder(func(path string, n *trienode.Node) {
+			if !n.IsDeleted() {
+				rawdb.WriteTrieNode(db, owner, []byte(path), n.Hash, n.Blob, scheme)
+				return
+			}
+			// Nodes are shared by content in the hash scheme, they can't be deleted
+			if scheme == rawdb.PathScheme {
+				rawdb.DeleteTrieNode(db, owner, []byte(path), n.Hash, scheme)
+			}
+		})
+	}
+	return ret.root, nil
+}
+
 // Prepare handles the preparatory steps for executing a state transition with.
 // This method must be invoked before state transition.
 //
@@ -1403,6 +1559,20 @@ func (s *StateDB) SlotInAccessList(addr common.Address, slot common.Hash) (addre
 	return s.accessList.Contains(addr, slot)
 }
 
+// PrewarmAccessList adds all the addresses and storage slots of the given access
+// list to the access list of the state, as if they had been accessed already.
+// It allows reproducing the EIP-2929 gas costs a transaction carrying the access
+// list would be charged. As Prepare resets the access list, it must be invoked
+// after preparing the state for a transaction.
+func (s *StateDB) PrewarmAccessList(al types.AccessList) {
+	for _, el := range al {
+		s.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			s.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
 // markDelete is invoked when an account is deleted but the deletion is
 // not yet committed. The pending mutation is cached and will be applied
 // all together
@@ -101,6 +101,10 @@ func (s *hookedStateDB) GetTransientState(addr common.Address, key common.Hash)
 	return s.inner.GetTransientState(addr, key)
 }
 
+func (s *hookedStateDB) GetTransientStorage(addr common.Address) map[common.Hash]common.Hash {
+	return s.inner.GetTransientStorage(addr)
+}
+
 func (s *hookedStateDB) SetTransientState(addr common.Address, key, value common.Hash) {
 	s.inner.SetTransientState(addr, key, value)
 }
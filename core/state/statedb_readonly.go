@@ -0,0 +1,243 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie/utils"
+	"github.com/holiman/uint256"
+)
+
+// ReadOnlyStateDB is a view of a statedb which panics on any operation modifying
+// the state, the refund counter or the logs. It shares the data of the wrapped
+// statedb, so writes made through the wrapped statedb are visible in the view.
+// Balances and code are returned as copies, so they can't be modified in place
+// either.
+//
+// The view implements the state database interface of the EVM, so calls not
+// modifying the state, e.g. static ones, can be run on it. The bookkeeping done
+// by any execution, i.e. journal snapshots, the access list and the transient
+// storage, is thus forwarded to the wrapped statedb. Zero value balance changes,
+// done to touch accounts, are ignored.
+type ReadOnlyStateDB struct {
+	inner *StateDB
+}
+
+// ReadOnly returns a read-only view of the statedb. Creating the view doesn't
+// copy nor allocate anything.
+func (s *StateDB) ReadOnly() ReadOnlyStateDB {
+	return ReadOnlyStateDB{inner: s}
+}
+
+// rejectWrite panics on an attempt to modify the state through a read-only view.
+func rejectWrite(op string) {
+	panic("state: " + op + " on read-only statedb")
+}
+
+func (s ReadOnlyStateDB) Error() error {
+	return s.inner.Error()
+}
+
+func (s ReadOnlyStateDB) Exist(addr common.Address) bool {
+	return s.inner.Exist(addr)
+}
+
+func (s ReadOnlyStateDB) Empty(addr common.Address) bool {
+	return s.inner.Empty(addr)
+}
+
+func (s ReadOnlyStateDB) IsEmptyEIP161(addr common.Address) bool {
+	return s.inner.IsEmptyEIP161(addr)
+}
+
+func (s ReadOnlyStateDB) GetBalance(addr common.Address) *uint256.Int {
+	return new(uint256.Int).Set(s.inner.GetBalance(addr))
+}
+
+func (s ReadOnlyStateDB) GetNonce(addr common.Address) uint64 {
+	return s.inner.GetNonce(addr)
+}
+
+func (s ReadOnlyStateDB) GetCodeHash(addr common.Address) common.Hash {
+	return s.inner.GetCodeHash(addr)
+}
+
+func (s ReadOnlyStateDB) GetCode(addr common.Address) []byte {
+	return common.CopyBytes(s.inner.GetCode(addr))
+}
+
+func (s ReadOnlyStateDB) GetCodeSize(addr common.Address) int {
+	return s.inner.GetCodeSize(addr)
+}
+
+func (s ReadOnlyStateDB) GetRefund() uint64 {
+	return s.inner.GetRefund()
+}
+
+func (s ReadOnlyStateDB) GetCommittedState(addr common.Address, hash common.Hash) common.Hash {
+	return s.inner.GetCommittedState(addr, hash)
+}
+
+func (s ReadOnlyStateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
+	return s.inner.GetState(addr, hash)
+}
+
+func (s ReadOnlyStateDB) GetStorageRoot(addr common.Address) common.Hash {
+	return s.inner.GetStorageRoot(addr)
+}
+
+func (s ReadOnlyStateDB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
+	return s.inner.GetTransientState(addr, key)
+}
+
+func (s ReadOnlyStateDB) GetTransientStorage(addr common.Address) map[common.Hash]common.Hash {
+	return s.inner.GetTransientStorage(addr)
+}
+
+func (s ReadOnlyStateDB) HasSelfDestructed(addr common.Address) bool {
+	return s.inner.HasSelfDestructed(addr)
+}
+
+func (s ReadOnlyStateDB) AddressInAccessList(addr common.Address) bool {
+	return s.inner.AddressInAccessList(addr)
+}
+
+func (s ReadOnlyStateDB) SlotInAccessList(addr common.Address, slot common.Hash) (addressOk bool, slotOk bool) {
+	return s.inner.SlotInAccessList(addr, slot)
+}
+
+func (s ReadOnlyStateDB) Logs() []*types.Log {
+	return s.inner.Logs()
+}
+
+func (s ReadOnlyStateDB) TxIndex() int {
+	return s.inner.TxIndex()
+}
+
+func (s ReadOnlyStateDB) PointCache() *utils.PointCache {
+	return s.inner.PointCache()
+}
+
+func (s ReadOnlyStateDB) Witness() *stateless.Witness {
+	return s.inner.Witness()
+}
+
+func (s ReadOnlyStateDB) AccessEvents() *AccessEvents {
+	return s.inner.AccessEvents()
+}
+
+func (s ReadOnlyStateDB) CreateAccount(common.Address) {
+	rejectWrite("CreateAccount")
+}
+
+func (s ReadOnlyStateDB) CreateContract(common.Address) {
+	rejectWrite("CreateContract")
+}
+
+func (s ReadOnlyStateDB) SubBalance(addr common.Address, amount *uint256.Int, _ tracing.BalanceChangeReason) uint256.Int {
+	if !amount.IsZero() {
+		rejectWrite("SubBalance")
+	}
+	return *s.inner.GetBalance(addr)
+}
+
+func (s ReadOnlyStateDB) AddBalance(addr common.Address, amount *uint256.Int, _ tracing.BalanceChangeReason) uint256.Int {
+	if !amount.IsZero() {
+		rejectWrite("AddBalance")
+	}
+	return *s.inner.GetBalance(addr)
+}
+
+func (s ReadOnlyStateDB) SetBalance(common.Address, *uint256.Int, tracing.BalanceChangeReason) {
+	rejectWrite("SetBalance")
+}
+
+func (s ReadOnlyStateDB) SetNonce(common.Address, uint64, tracing.NonceChangeReason) {
+	rejectWrite("SetNonce")
+}
+
+func (s ReadOnlyStateDB) SetCode(common.Address, []byte) []byte {
+	rejectWrite("SetCode")
+	return nil
+}
+
+func (s ReadOnlyStateDB) SetState(common.Address, common.Hash, common.Hash) common.Hash {
+	rejectWrite("SetState")
+	return common.Hash{}
+}
+
+func (s ReadOnlyStateDB) SetStorage(common.Address, map[common.Hash]common.Hash) {
+	rejectWrite("SetStorage")
+}
+
+func (s ReadOnlyStateDB) SetTransientState(addr common.Address, key, value common.Hash) {
+	s.inner.SetTransientState(addr, key, value)
+}
+
+func (s ReadOnlyStateDB) SelfDestruct(common.Address) uint256.Int {
+	rejectWrite("SelfDestruct")
+	return uint256.Int{}
+}
+
+func (s ReadOnlyStateDB) SelfDestruct6780(common.Address) (uint256.Int, bool) {
+	rejectWrite("SelfDestruct6780")
+	return uint256.Int{}, false
+}
+
+func (s ReadOnlyStateDB) AddRefund(uint64) {
+	rejectWrite("AddRefund")
+}
+
+func (s ReadOnlyStateDB) SubRefund(uint64) {
+	rejectWrite("SubRefund")
+}
+
+func (s ReadOnlyStateDB) AddAddressToAccessList(addr common.Address) {
+	s.inner.AddAddressToAccessList(addr)
+}
+
+func (s ReadOnlyStateDB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	s.inner.AddSlotToAccessList(addr, slot)
+}
+
+func (s ReadOnlyStateDB) Prepare(rules params.Rules, sender, coinbase common.Address, dest *common.Address, precompiles []common.Address, txAccesses types.AccessList) {
+	s.inner.Prepare(rules, sender, coinbase, dest, precompiles, txAccesses)
+}
+
+func (s ReadOnlyStateDB) Snapshot() int {
+	return s.inner.Snapshot()
+}
+
+func (s ReadOnlyStateDB) RevertToSnapshot(revid int) {
+	s.inner.RevertToSnapshot(revid)
+}
+
+func (s ReadOnlyStateDB) AddLog(*types.Log) {
+	rejectWrite("AddLog")
+}
+
+func (s ReadOnlyStateDB) AddPreimage(common.Hash, []byte) {
+	rejectWrite("AddPreimage")
+}
+
+func (s ReadOnlyStateDB) Finalise(bool) {
+	rejectWrite("Finalise")
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestReadOnlyStateDB(t *testing.T) {
+	var (
+		addr = common.Address{0x01}
+		slot = common.Hash{0x02}
+	)
+	state, _ := New(types.EmptyRootHash, NewDatabaseForTesting())
+	state.SetBalance(addr, uint256.NewInt(42), tracing.BalanceChangeUnspecified)
+	state.SetCode(addr, []byte{0x60, 0x00})
+	state.SetState(addr, slot, common.Hash{0x03})
+
+	view := state.ReadOnly()
+	if have := view.GetBalance(addr); !have.Eq(uint256.NewInt(42)) {
+		t.Errorf("balance mismatch: have %v, want %d", have, 42)
+	}
+	if have := view.GetState(addr, slot); have != (common.Hash{0x03}) {
+		t.Errorf("storage mismatch: have %x, want %x", have, common.Hash{0x03})
+	}
+	// Values returned by the view can't be used to modify the state in place
+	view.GetBalance(addr).SetUint64(0)
+	view.GetCode(addr)[0] = 0xff
+	if have := state.GetBalance(addr); !have.Eq(uint256.NewInt(42)) {
+		t.Errorf("balance modified through the view: %v", have)
+	}
+	if have := state.GetCode(addr); have[0] != 0x60 {
+		t.Errorf("code modified through the view: %x", have)
+	}
+	// Writes to the wrapped statedb are visible through the view
+	state.SetNonce(addr, 7, tracing.NonceChangeUnspecified)
+	if have := view.GetNonce(addr); have != 7 {
+		t.Errorf("nonce mismatch: have %d, want %d", have, 7)
+	}
+	// Execution bookkeeping and zero value touches are allowed
+	view.Prepare(params.Rules{IsBerlin: true}, addr, addr, nil, nil, nil)
+	snap := view.Snapshot()
+	view.AddSlotToAccessList(addr, slot)
+	view.SetTransientState(addr, slot, common.Hash{0x01})
+	view.AddBalance(addr, new(uint256.Int), tracing.BalanceChangeTouchAccount)
+	view.SubBalance(addr, new(uint256.Int), tracing.BalanceChangeTouchAccount)
+	view.RevertToSnapshot(snap)
+	if _, ok := view.SlotInAccessList(addr, slot); ok || view.GetTransientState(addr, slot) != (common.Hash{}) {
+		t.Errorf("bookkeeping not reverted")
+	}
+	// Modifications of the state are rejected
+	writes := map[string]func(){
+		"CreateAccount":    func() { view.CreateAccount(addr) },
+		"CreateContract":   func() { view.CreateContract(addr) },
+		"SubBalance":       func() { view.SubBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified) },
+		"AddBalance":       func() { view.AddBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified) },
+		"SetBalance":       func() { view.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified) },
+		"SetNonce":         func() { view.SetNonce(addr, 1, tracing.NonceChangeUnspecified) },
+		"SetCode":          func() { view.SetCode(addr, nil) },
+		"SetState":         func() { view.SetState(addr, slot, common.Hash{}) },
+		"SetStorage":       func() { view.SetStorage(addr, nil) },
+		"SelfDestruct":     func() { view.SelfDestruct(addr) },
+		"SelfDestruct6780": func() { view.SelfDestruct6780(addr) },
+		"AddRefund":        func() { view.AddRefund(1) },
+		"SubRefund":        func() { view.SubRefund(1) },
+		"AddLog":           func() { view.AddLog(&types.Log{}) },
+		"AddPreimage":      func() { view.AddPreimage(common.Hash{}, nil) },
+		"Finalise":         func() { view.Finalise(true) },
+	}
+	for name, write := range writes {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: write not rejected", name)
+				}
+			}()
+			write()
+		}()
+	}
+	if have := state.GetBalance(addr); !have.Eq(uint256.NewInt(42)) {
+		t.Errorf("balance modified through the view: %v", have)
+	}
+	if have := state.GetNonce(addr); have != 7 {
+		t.Errorf("nonce modified through the view: %d", have)
+	}
+}
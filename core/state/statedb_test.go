@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"maps"
 	"math"
+	"math/big"
 	"math/rand"
 	"reflect"
 	"slices"
@@ -36,6 +37,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/trie/trienode"
@@ -1028,6 +1031,82 @@ func testMissingTrieNodes(t *testing.T, scheme string) {
 	}
 }
 
+func TestIsEmptyEIP161(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(s *StateDB, addr common.Address)
+		empty bool
+	}{
+		{name: "missing", setup: func(s *StateDB, addr common.Address) {}},
+		{name: "created", setup: func(s *StateDB, addr common.Address) { s.CreateAccount(addr) }, empty: true},
+		{name: "balance", setup: func(s *StateDB, addr common.Address) {
+			s.AddBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		}},
+		{name: "nonce", setup: func(s *StateDB, addr common.Address) { s.SetNonce(addr, 1, tracing.NonceChangeUnspecified) }},
+		{name: "code", setup: func(s *StateDB, addr common.Address) { s.SetCode(addr, []byte{0x00}) }},
+		{name: "storage", setup: func(s *StateDB, addr common.Address) {
+			s.SetState(addr, common.Hash{0x01}, common.Hash{0x01})
+		}, empty: true},
+		{name: "drained", setup: func(s *StateDB, addr common.Address) {
+			s.AddBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+			s.SubBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		}, empty: true},
+	}
+	addr := common.HexToAddress("0xaa")
+	for _, tt := range tests {
+		state, _ := New(types.EmptyRootHash, NewDatabaseForTesting())
+		tt.setup(state, addr)
+		if have := state.IsEmptyEIP161(addr); have != tt.empty {
+			t.Errorf("%s: emptiness mismatch: have %t, want %t", tt.name, have, tt.empty)
+		}
+		// Empty accounts are removed when finalising with EIP-161 rules
+		state.Finalise(true)
+		if state.IsEmptyEIP161(addr) {
+			t.Errorf("%s: empty account not removed", tt.name)
+		}
+		if exist := state.Exist(addr); exist != (tt.name != "missing" && !tt.empty) {
+			t.Errorf("%s: existence mismatch after finalisation: %t", tt.name, exist)
+		}
+	}
+}
+
+func TestStateDBPrewarmAccessList(t *testing.T) {
+	var (
+		addrA = common.HexToAddress("0xaa")
+		addrB = common.HexToAddress("0xbb")
+		slot1 = common.HexToHash("0x01")
+		slot2 = common.HexToHash("0x02")
+	)
+	state, _ := New(types.EmptyRootHash, NewDatabaseForTesting())
+	state.Prepare(params.Rules{IsBerlin: true, IsEIP2929: true}, common.Address{}, common.Address{}, nil, nil, nil)
+
+	state.PrewarmAccessList(types.AccessList{
+		{Address: addrA, StorageKeys: []common.Hash{slot1, slot2}},
+		{Address: addrB},
+	})
+	if !state.AddressInAccessList(addrA) || !state.AddressInAccessList(addrB) {
+		t.Fatal("prewarmed addresses missing from access list")
+	}
+	for _, slot := range []common.Hash{slot1, slot2} {
+		if _, ok := state.SlotInAccessList(addrA, slot); !ok {
+			t.Fatalf("prewarmed slot %x missing from access list", slot)
+		}
+	}
+	if _, ok := state.SlotInAccessList(addrB, slot1); ok {
+		t.Fatal("unexpected slot in access list")
+	}
+	// Prewarming is journaled like any other access
+	id := state.Snapshot()
+	state.PrewarmAccessList(types.AccessList{{Address: addrB, StorageKeys: []common.Hash{slot1}}})
+	state.RevertToSnapshot(id)
+	if _, ok := state.SlotInAccessList(addrB, slot1); ok {
+		t.Fatal("reverted slot still in access list")
+	}
+	if !state.AddressInAccessList(addrB) {
+		t.Fatal("address prewarmed before the snapshot reverted")
+	}
+}
+
 func TestStateDBAccessList(t *testing.T) {
 	// Some helpers
 	addr := common.HexToAddress
@@ -1368,3 +1447,496 @@ func TestStorageDirtiness(t *testing.T) {
 	state.RevertToSnapshot(snap)
 	checkDirty(common.Hash{0x1}, common.Hash{0x1}, true)
 }
+
+func TestPendingChanges(t *testing.T) {
+	var (
+		db    = NewDatabaseForTesting()
+		addrA = common.HexToAddress("0xa")
+		addrB = common.HexToAddress("0xb")
+		addrC = common.HexToAddress("0xc")
+		slot1 = common.HexToHash("0x1")
+		slot2 = common.HexToHash("0x2")
+	)
+	state, _ := New(types.EmptyRootHash, db)
+	state.SetBalance(addrA, uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+	state.SetState(addrA, slot1, common.HexToHash("0x11"))
+	state.SetState(addrA, slot2, common.HexToHash("0x22"))
+	state.SetBalance(addrB, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	root, _ := state.Commit(0, false, false)
+
+	state, _ = New(root, db)
+	if changes := state.PendingChanges(); len(changes) != 0 {
+		t.Fatalf("unexpected changes on a clean state: %v", changes)
+	}
+	// Mutations reverted or set back to the original value must not show up
+	state.SetNonce(addrA, 1, tracing.NonceChangeUnspecified)
+	state.SetState(addrA, slot1, common.HexToHash("0x12"))
+	id := state.Snapshot()
+	state.SetState(addrA, slot2, common.HexToHash("0x23"))
+	state.SetBalance(addrC, uint256.NewInt(5), tracing.BalanceChangeUnspecified)
+	state.RevertToSnapshot(id)
+	state.Finalise(true)
+
+	state.SetState(addrA, slot1, common.HexToHash("0x13"))
+	state.SetBalance(addrB, uint256.NewInt(2), tracing.BalanceChangeUnspecified)
+	state.SetBalance(addrB, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.SelfDestruct(addrB)
+
+	changes := state.PendingChanges()
+	if len(changes) != 2 {
+		t.Fatalf("unexpected number of changed accounts: have %d, want 2", len(changes))
+	}
+	a := changes[addrA]
+	if a == nil || a.Created || a.Deleted || a.Wiped {
+		t.Fatalf("unexpected change for account A: %+v", a)
+	}
+	if a.PrevNonce != 0 || a.Nonce != 1 || !a.PrevBalance.Eq(a.Balance) {
+		t.Fatalf("unexpected metadata change for account A: %+v", a)
+	}
+	want := map[common.Hash]StorageChange{
+		slot1: {Prev: common.HexToHash("0x11"), Value: common.HexToHash("0x13")},
+	}
+	if !reflect.DeepEqual(a.Storage, want) {
+		t.Fatalf("unexpected storage change for account A: have %v, want %v", a.Storage, want)
+	}
+	b := changes[addrB]
+	if b == nil || !b.Deleted || !b.Wiped || b.PrevBalance.Uint64() != 1 || !b.Balance.IsZero() {
+		t.Fatalf("unexpected change for account B: %+v", b)
+	}
+}
+
+// Tests that the storage proofs are verifiable against the storage root, for
+// both existent and non-existent slots.
+func TestGetStorageProofs(t *testing.T) {
+	var (
+		db      = NewDatabaseForTesting()
+		addr    = common.HexToAddress("0xa")
+		missing = common.HexToAddress("0xb")
+		slots   = []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")}
+	)
+	state, _ := New(types.EmptyRootHash, db)
+	state.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	for i := 0; i < 16; i++ {
+		state.SetState(addr, common.BigToHash(big.NewInt(int64(i+1))), common.BigToHash(big.NewInt(int64(i+100))))
+	}
+	root, _ := state.Commit(0, false, false)
+
+	state, _ = New(root, db)
+	query := append(slots, common.HexToHash("0xdead")) // the last slot does not exist
+	proofs, err := state.GetStorageProofs(addr, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storageRoot := state.GetStorageRoot(addr)
+	for i, proof := range proofs {
+		if proof.Key != query[i] {
+			t.Fatalf("proof %d: key mismatch: have %x, want %x", i, proof.Key, query[i])
+		}
+		if len(proof.Proof) == 0 {
+			t.Fatalf("proof %d: empty proof", i)
+		}
+		val, err := trie.VerifyProof(storageRoot, crypto.Keccak256(proof.Key.Bytes()), proof.Proof.Set())
+		if err != nil {
+			t.Fatalf("proof %d: verification failed: %v", i, err)
+		}
+		var want common.Hash
+		if i < len(slots) {
+			want = common.BigToHash(big.NewInt(int64(i + 100)))
+		}
+		if len(val) > 0 {
+			_, content, _, _ := rlp.Split(val)
+			val = content
+		}
+		if have := common.BytesToHash(val); have != want || proof.Value != want {
+			t.Fatalf("proof %d: value mismatch: have %x (proven %x), want %x", i, proof.Value, have, want)
+		}
+	}
+	// Accounts without storage have nothing to prove
+	proofs, err = state.GetStorageProofs(missing, slots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, proof := range proofs {
+		if proof.Key != slots[i] || proof.Value != (common.Hash{}) || len(proof.Proof) != 0 {
+			t.Fatalf("proof %d: unexpected proof for missing account: %+v", i, proof)
+		}
+	}
+}
+
+func TestCommitTo(t *testing.T) {
+	var (
+		disk = rawdb.NewMemoryDatabase()
+		db   = NewDatabase(triedb.NewDatabase(disk, nil), nil)
+		addr = common.HexToAddress("0xa")
+		code = []byte{0x60, 0x00}
+	)
+	state, _ := New(types.EmptyRootHash, db)
+	state.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.SetState(addr, common.HexToHash("0x1"), common.HexToHash("0x1"))
+	root, _ := state.Commit(0, false, false)
+	db.TrieDB().Commit(root, false)
+
+	// Fork the state into a separate database
+	state, _ = New(root, db)
+	state.SetBalance(addr, uint256.NewInt(2), tracing.BalanceChangeUnspecified)
+	state.SetState(addr, common.HexToHash("0x2"), common.HexToHash("0x2"))
+	state.SetCode(addr, code)
+
+	fork := rawdb.NewMemoryDatabase()
+	forkRoot, err := state.CommitTo(fork)
+	if err != nil {
+		t.Fatalf("failed to commit fork: %v", err)
+	}
+	if want := state.IntermediateRoot(true); forkRoot != want {
+		t.Fatalf("fork root mismatch: have %x, want %x", forkRoot, want)
+	}
+	if ok, _ := disk.Has(forkRoot.Bytes()); ok {
+		t.Fatal("fork committed into the original database")
+	}
+	// Promote the fork on top of a copy of the original database
+	promoted := rawdb.NewMemoryDatabase()
+	for _, src := range []ethdb.Iteratee{disk, fork} {
+		it := src.NewIterator(nil, nil)
+		for it.Next() {
+			promoted.Put(it.Key(), it.Value())
+		}
+		it.Release()
+	}
+	forked, err := New(forkRoot, NewDatabase(triedb.NewDatabase(promoted, nil), nil))
+	if err != nil {
+		t.Fatalf("failed to open fork: %v", err)
+	}
+	if have := forked.GetBalance(addr); have.Uint64() != 2 {
+		t.Fatalf("fork balance mismatch: have %v, want 2", have)
+	}
+	if have := forked.GetState(addr, common.HexToHash("0x1")); have != common.HexToHash("0x1") {
+		t.Fatalf("fork original slot mismatch: have %x", have)
+	}
+	if have := forked.GetState(addr, common.HexToHash("0x2")); have != common.HexToHash("0x2") {
+		t.Fatalf("fork new slot mismatch: have %x", have)
+	}
+	if have := forked.GetCode(addr); !bytes.Equal(have, code) {
+		t.Fatalf("fork code mismatch: have %x, want %x", have, code)
+	}
+	// The original state remains usable on its own database
+	if root, err := state.Commit(1, true, false); err != nil || root != forkRoot {
+		t.Fatalf("failed to commit original state: root %x, want %x, err %v", root, forkRoot, err)
+	}
+}
+
+// Tests that the slot change sink receives the storage writes, including the
+// compensating changes of reverted ones.
+func TestSlotChangeSink(t *testing.T) {
+	var (
+		state, _ = New(types.EmptyRootHash, NewDatabaseForTesting())
+		addr     = common.HexToAddress("0x01")
+		slot     = common.HexToHash("0x02")
+		changes  []SlotChange
+	)
+	state.SetSlotChangeSink(func(change SlotChange) {
+		changes = append(changes, change)
+	})
+	state.SetTxContext(common.Hash{}, 3)
+	state.SetState(addr, slot, common.HexToHash("0x0a"))
+	state.SetState(addr, slot, common.HexToHash("0x0a")) // no-op, not reported
+
+	snap := state.Snapshot()
+	state.SetState(addr, slot, common.HexToHash("0x0b"))
+	state.RevertToSnapshot(snap)
+
+	want := []SlotChange{
+		{Address: addr, Slot: slot, Old: common.Hash{}, New: common.HexToHash("0x0a"), TxIndex: 3},
+		{Address: addr, Slot: slot, Old: common.HexToHash("0x0a"), New: common.HexToHash("0x0b"), TxIndex: 3},
+		{Address: addr, Slot: slot, Old: common.HexToHash("0x0b"), New: common.HexToHash("0x0a"), TxIndex: 3, Reverted: true},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("slot changes mismatch:\nhave %+v\nwant %+v", changes, want)
+	}
+	if have := state.GetState(addr, slot); have != common.HexToHash("0x0a") {
+		t.Fatalf("slot value mismatch: have %x", have)
+	}
+	// Copies don't report to the sink
+	state.Copy().SetState(addr, slot, common.HexToHash("0x0c"))
+	if len(changes) != len(want) {
+		t.Fatalf("copy reported %d slot changes", len(changes)-len(want))
+	}
+}
+
+// countingReader wraps a reader, counting the retrievals.
+type countingReader struct {
+	Reader
+	accounts, slots, codes int
+}
+
+func (r *countingReader) Account(addr common.Address) (*types.StateAccount, error) {
+	r.accounts++
+	return r.Reader.Account(addr)
+}
+
+func (r *countingReader) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	r.slots++
+	return r.Reader.Storage(addr, slot)
+}
+
+func (r *countingReader) Code(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	r.codes++
+	return r.Reader.Code(addr, codeHash)
+}
+
+// Tests that the values missing from the local database are retrieved from the
+// fallback reader, once, while the ones found locally, even if empty, are not.
+func TestFallbackReader(t *testing.T) {
+	var (
+		addr     = common.HexToAddress("0x01") // Account trie node missing locally
+		contract = common.HexToAddress("0x02") // Storage trie missing locally
+		deleted  = common.HexToAddress("0x03") // Deleted locally
+		local    = common.HexToAddress("0x04") // Modified locally
+		slot     = common.HexToHash("0x05")
+		cleared  = common.HexToHash("0x06") // Cleared locally
+		value    = common.HexToHash("0x07")
+		code     = []byte{0x60, 0x00}
+	)
+	// Create the remote state and an identical local one
+	build := func() (*CachingDB, common.Hash) {
+		db := NewDatabaseForTesting()
+		state, _ := New(types.EmptyRootHash, db)
+		state.SetBalance(addr, uint256.NewInt(42), tracing.BalanceChangeUnspecified)
+		state.SetCode(addr, code)
+		state.SetState(addr, slot, value)
+		state.SetBalance(contract, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		state.SetState(contract, slot, value)
+		state.SetBalance(deleted, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		state.SetBalance(local, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		state.SetState(local, cleared, value)
+		root, err := state.Commit(0, false, false)
+		if err != nil {
+			t.Fatalf("failed to commit state: %v", err)
+		}
+		return db, root
+	}
+	remoteDB, root := build()
+	reader, err := remoteDB.Reader(root)
+	if err != nil {
+		t.Fatalf("failed to open remote state: %v", err)
+	}
+	fallback := &countingReader{Reader: reader}
+
+	// Diverge the local state and drop parts of it from the database
+	localDB, _ := build()
+	state, _ := New(root, localDB)
+	state.SelfDestruct(deleted)
+	state.SetState(local, cleared, common.Hash{})
+	if root, err = state.Commit(1, true, false); err != nil {
+		t.Fatalf("failed to commit local state: %v", err)
+	}
+	if err := localDB.TrieDB().Commit(root, false); err != nil {
+		t.Fatalf("failed to flush local state: %v", err)
+	}
+	disk := localDB.TrieDB().Disk()
+	rawdb.DeleteCode(disk, crypto.Keccak256Hash(code))
+	rawdb.DeleteLegacyTrieNode(disk, state.GetStorageRoot(contract))
+	deleteAccountLeaf(t, localDB, root, addr)
+
+	state, _ = New(root, NewDatabase(triedb.NewDatabase(disk, nil), nil))
+	state.SetFallbackReader(fallback)
+
+	check := func(state *StateDB) {
+		t.Helper()
+		if have := state.GetBalance(addr); have.Uint64() != 42 {
+			t.Fatalf("remote balance mismatch: have %v, want 42", have)
+		}
+		if have := state.GetState(addr, slot); have != value {
+			t.Fatalf("remote slot mismatch: have %x, want %x", have, value)
+		}
+		if have := state.GetCode(addr); !bytes.Equal(have, code) {
+			t.Fatalf("remote code mismatch: have %x, want %x", have, code)
+		}
+		if have := state.GetState(contract, slot); have != value {
+			t.Fatalf("remote storage mismatch: have %x, want %x", have, value)
+		}
+		if state.Exist(deleted) {
+			t.Fatal("locally deleted account resurrected")
+		}
+		if have := state.GetState(local, cleared); have != (common.Hash{}) {
+			t.Fatalf("locally cleared slot resurrected: %x", have)
+		}
+		if have := state.GetState(local, slot); have != (common.Hash{}) {
+			t.Fatalf("empty local slot resolved remotely: %x", have)
+		}
+	}
+	check(state)
+	check(state.Copy())
+	if fallback.accounts != 1 || fallback.slots != 2 || fallback.codes != 1 {
+		t.Fatalf("fallback retrievals mismatch: accounts %d, slots %d, codes %d", fallback.accounts, fallback.slots, fallback.codes)
+	}
+	// Changes not touching the missing trie nodes can be committed, and the
+	// fallback remains in use afterwards
+	state.SetBalance(local, uint256.NewInt(8), tracing.BalanceChangeUnspecified)
+	state.SetBalance(contract, uint256.NewInt(9), tracing.BalanceChangeUnspecified)
+	if _, err := state.Commit(2, true, false); err != nil {
+		t.Fatalf("failed to commit after fallback reads: %v", err)
+	}
+	if have := state.GetBalance(addr); have.Uint64() != 42 {
+		t.Fatalf("remote balance mismatch after commit: have %v, want 42", have)
+	}
+	if have := state.GetState(contract, slot); have != value {
+		t.Fatalf("remote storage mismatch after commit: have %x, want %x", have, value)
+	}
+	// Removing the fallback surfaces the missing data
+	state, _ = New(root, NewDatabase(triedb.NewDatabase(disk, nil), nil))
+	state.SetFallbackReader(fallback)
+	state.SetFallbackReader(nil)
+	state.GetBalance(addr)
+	if state.Error() == nil {
+		t.Fatal("missing account resolved without fallback")
+	}
+}
+
+// deleteAccountLeaf removes the trie node holding the account of the address
+// from the hash based database.
+func deleteAccountLeaf(t *testing.T, db *CachingDB, root common.Hash, addr common.Address) {
+	t.Helper()
+
+	tr, err := db.OpenTrie(root)
+	if err != nil {
+		t.Fatalf("failed to open trie: %v", err)
+	}
+	it, err := tr.NodeIterator(nil)
+	if err != nil {
+		t.Fatalf("failed to iterate trie: %v", err)
+	}
+	var parent common.Hash // Last hashed node seen, holding the leaf
+	for it.Next(true) {
+		if it.Hash() != (common.Hash{}) {
+			parent = it.Hash()
+		}
+		if it.Leaf() && bytes.Equal(it.LeafKey(), crypto.Keccak256(addr.Bytes())) {
+			rawdb.DeleteLegacyTrieNode(db.TrieDB().Disk(), parent)
+			return
+		}
+	}
+	t.Fatalf("account %x not found", addr)
+}
+
+// Tests that rolling back to a block checkpoint discards the changes of all the
+// transactions applied since, finalised or not, and keeps the earlier ones.
+func TestBlockCheckpoint(t *testing.T) {
+	var (
+		db       = NewDatabaseForTesting()
+		addrA    = common.HexToAddress("0xaa")
+		addrB    = common.HexToAddress("0xbb")
+		addrC    = common.HexToAddress("0xcc")
+		slot     = common.HexToHash("0x01")
+		state, _ = New(types.EmptyRootHash, db)
+	)
+	state.SetBalance(addrA, uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+	state.SetBalance(addrB, uint256.NewInt(200), tracing.BalanceChangeUnspecified)
+	state.SetState(addrB, slot, common.HexToHash("0x0b"))
+	root, err := state.Commit(0, true, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(root, db)
+
+	// Apply a transaction and checkpoint the state in the middle of the block
+	state.SetTxContext(common.Hash{0x01}, 0)
+	state.AddBalance(addrA, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.AddLog(&types.Log{Address: addrA})
+	state.Finalise(true)
+
+	want := state.Copy().IntermediateRoot(true)
+	state.BlockCheckpoint()
+
+	// Apply a few more finalised transactions and a pending one
+	for i := 1; i <= 3; i++ {
+		state.SetTxContext(common.Hash{byte(i + 1)}, i)
+		state.SubBalance(addrA, uint256.NewInt(uint64(i)), tracing.BalanceChangeUnspecified)
+		state.SetState(addrB, slot, common.BigToHash(big.NewInt(int64(i))))
+		state.AddLog(&types.Log{Address: addrB})
+		if i == 2 {
+			state.SetBalance(addrC, uint256.NewInt(300), tracing.BalanceChangeUnspecified)
+			state.IntermediateRoot(true)
+		}
+		state.Finalise(true)
+	}
+	state.SetTxContext(common.Hash{0x05}, 4)
+	state.SelfDestruct(addrB)
+
+	state.RollbackToBlockCheckpoint()
+
+	if have := state.GetBalance(addrA); !have.Eq(uint256.NewInt(101)) {
+		t.Errorf("balance mismatch: have %v, want %v", have, 101)
+	}
+	if have := state.GetState(addrB, slot); have != common.HexToHash("0x0b") {
+		t.Errorf("storage mismatch: have %x, want %x", have, common.HexToHash("0x0b"))
+	}
+	if state.HasSelfDestructed(addrB) || state.Exist(addrC) {
+		t.Errorf("discarded changes survived the rollback")
+	}
+	if have := len(state.Logs()); have != 1 {
+		t.Errorf("log count mismatch: have %d, want %d", have, 1)
+	}
+	if have := state.TxIndex(); have != 0 {
+		t.Errorf("tx index mismatch: have %d, want %d", have, 0)
+	}
+	// The state must remain usable, continuing the block past the checkpoint
+	state.SetTxContext(common.Hash{0x06}, 1)
+	snap := state.Snapshot()
+	state.AddBalance(addrC, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.RevertToSnapshot(snap)
+
+	have, err := state.Commit(1, true, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	if have != want {
+		t.Fatalf("root mismatch: have %x, want %x", have, want)
+	}
+	// The checkpoint is consumed by the rollback
+	defer func() {
+		if recover() == nil {
+			t.Fatal("rollback without checkpoint didn't panic")
+		}
+	}()
+	state.RollbackToBlockCheckpoint()
+}
+
+func TestBlockCheckpointSlotChanges(t *testing.T) {
+	var (
+		db       = NewDatabaseForTesting()
+		addr     = common.HexToAddress("0xaa")
+		state, _ = New(types.EmptyRootHash, db)
+		replay   = make(map[common.Hash]common.Hash)
+	)
+	state.SetState(addr, common.Hash{0x01}, common.Hash{0x01})
+	state.Finalise(true)
+
+	state.SetSlotChangeSink(func(change SlotChange) {
+		replay[change.Slot] = change.New
+	})
+	state.BlockCheckpoint()
+	for i := 1; i <= 3; i++ {
+		state.SetTxContext(common.Hash{byte(i)}, i)
+		state.SetState(addr, common.Hash{0x01}, common.Hash{byte(i + 1)})
+		state.SetState(addr, common.Hash{byte(i + 1)}, common.Hash{byte(i + 1)})
+		state.Finalise(true)
+	}
+	state.RollbackToBlockCheckpoint()
+
+	for slot, value := range replay {
+		if want := state.GetState(addr, slot); value != want {
+			t.Errorf("slot %x: replayed value mismatch: have %x, want %x", slot, value, want)
+		}
+	}
+	// Committing discards the checkpoint
+	state.BlockCheckpoint()
+	if _, err := state.Commit(0, true, false); err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("rollback after commit didn't panic")
+		}
+	}()
+	state.RollbackToBlockCheckpoint()
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/triedb/database"
+)
+
+// storageCaches is the set of dedicated clean caches for the storage trie nodes
+// of selected accounts, keyed by the account address hash. Accounts with huge
+// and frequently accessed storage would otherwise evict each other's nodes from
+// the shared trie node cache.
+type storageCaches struct {
+	lock   sync.RWMutex
+	caches map[common.Hash]*lru.SizeConstrainedCache[common.Hash, []byte]
+}
+
+// setSize configures the dedicated cache of the account with the given size
+// in bytes, discarding the existing one. A zero size removes the cache.
+func (c *storageCaches) setSize(owner common.Hash, size int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if size <= 0 {
+		delete(c.caches, owner)
+		return
+	}
+	if c.caches == nil {
+		c.caches = make(map[common.Hash]*lru.SizeConstrainedCache[common.Hash, []byte])
+	}
+	c.caches[owner] = lru.NewSizeConstrainedCache[common.Hash, []byte](uint64(size))
+}
+
+// nodeDatabase returns the node database to open the storage trie of the given
+// account with. It's the given database itself, unless a dedicated cache is
+// configured for the account.
+func (c *storageCaches) nodeDatabase(db database.NodeDatabase, owner common.Hash) database.NodeDatabase {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	cache := c.caches[owner]
+	if cache == nil {
+		return db
+	}
+	return &cachedNodeDatabase{db: db, owner: owner, cache: cache}
+}
+
+// cachedNodeDatabase is a node database serving the storage trie nodes of a
+// single account from a dedicated cache.
+type cachedNodeDatabase struct {
+	db    database.NodeDatabase
+	owner common.Hash
+	cache *lru.SizeConstrainedCache[common.Hash, []byte]
+}
+
+// NodeReader implements database.NodeDatabase, returning a node reader associated
+// with the specific state.
+func (db *cachedNodeDatabase) NodeReader(stateRoot common.Hash) (database.NodeReader, error) {
+	reader, err := db.db.NodeReader(stateRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &cachedNodeReader{reader: reader, owner: db.owner, cache: db.cache}, nil
+}
+
+// cachedNodeReader is a node reader looking up the nodes of the storage trie of
+// a single account in a dedicated cache, before falling back to the backing one.
+type cachedNodeReader struct {
+	reader database.NodeReader
+	owner  common.Hash
+	cache  *lru.SizeConstrainedCache[common.Hash, []byte]
+}
+
+// Node implements database.NodeReader, retrieving the trie node blob with the
+// provided trie identifier, node path and the corresponding node hash.
+func (r *cachedNodeReader) Node(owner common.Hash, path []byte, hash common.Hash) ([]byte, error) {
+	if owner != r.owner {
+		return r.reader.Node(owner, path, hash)
+	}
+	// Trie nodes are addressed by their hash, so a cached blob is valid
+	// regardless of the state the reader is associated with.
+	if blob, ok := r.cache.Get(hash); ok {
+		return blob, nil
+	}
+	blob, err := r.reader.Node(owner, path, hash)
+	if err != nil || len(blob) == 0 {
+		return blob, err
+	}
+	r.cache.Add(hash, blob)
+	return blob, nil
+}
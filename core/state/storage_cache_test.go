@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/database"
+	"github.com/ethereum/go-ethereum/triedb/hashdb"
+	"github.com/holiman/uint256"
+)
+
+// countingNodeDatabase is a node database counting the node reads.
+type countingNodeDatabase struct {
+	reads int
+}
+
+func (db *countingNodeDatabase) NodeReader(stateRoot common.Hash) (database.NodeReader, error) {
+	return db, nil
+}
+
+func (db *countingNodeDatabase) Node(owner common.Hash, path []byte, hash common.Hash) ([]byte, error) {
+	db.reads++
+	return hash.Bytes(), nil
+}
+
+func TestStorageCacheReads(t *testing.T) {
+	var (
+		backing = new(countingNodeDatabase)
+		caches  = new(storageCaches)
+		hot     = common.HexToHash("0x01")
+		cold    = common.HexToHash("0x02")
+		node    = common.HexToHash("0xff")
+	)
+	if db := caches.nodeDatabase(backing, hot); db != backing {
+		t.Fatal("node database wrapped without configured cache")
+	}
+	caches.setSize(hot, 1024)
+
+	reader, _ := caches.nodeDatabase(backing, hot).NodeReader(types.EmptyRootHash)
+	for i := 0; i < 3; i++ {
+		if blob, _ := reader.Node(hot, nil, node); common.BytesToHash(blob) != node {
+			t.Fatalf("read %d: wrong node blob %x", i, blob)
+		}
+		reader.Node(cold, nil, node)
+	}
+	if backing.reads != 4 {
+		t.Fatalf("wrong number of backing reads: have %d, want 4", backing.reads)
+	}
+	caches.setSize(hot, 0)
+	if db := caches.nodeDatabase(backing, hot); db != backing {
+		t.Fatal("node database wrapped after cache removal")
+	}
+}
+
+func TestStorageCacheState(t *testing.T) {
+	var (
+		db   = NewDatabaseForTesting()
+		addr = common.HexToAddress("0xa")
+	)
+	db.SetStorageCacheSize(addr, 1024*1024)
+
+	root := makeLargeStorage(db, addr, 256)
+	for round := 0; round < 2; round++ {
+		state, _ := New(root, db)
+		for i := 0; i < 256; i++ {
+			key, want := largeStorageSlot(i)
+			if have := state.GetState(addr, key); have != want {
+				t.Fatalf("round %d, slot %d: have %x, want %x", round, i, have, want)
+			}
+			state.SetState(addr, key, common.Hash{})
+		}
+		state.Commit(1, false, false)
+		if have := state.GetStorageRoot(addr); have != types.EmptyRootHash {
+			t.Fatalf("round %d: storage not cleared, root %x", round, have)
+		}
+	}
+	owner := crypto.Keccak256Hash(addr.Bytes())
+	if _, ok := db.storageCaches.caches[owner]; !ok {
+		t.Fatal("storage cache not configured")
+	}
+}
+
+// makeLargeStorage creates and commits an account with the given number of
+// storage slots, returning the state root.
+func makeLargeStorage(db *CachingDB, addr common.Address, slots int) common.Hash {
+	state, _ := New(types.EmptyRootHash, db)
+	state.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	for i := 0; i < slots; i++ {
+		key, value := largeStorageSlot(i)
+		state.SetState(addr, key, value)
+	}
+	root, _ := state.Commit(0, false, false)
+	db.TrieDB().Commit(root, false)
+	return root
+}
+
+// largeStorageSlot returns the key and value of a slot of a large mapping.
+func largeStorageSlot(i int) (common.Hash, common.Hash) {
+	key := crypto.Keccak256Hash(common.BigToHash(uint256.NewInt(uint64(i)).ToBig()).Bytes(), common.Hash{}.Bytes())
+	return key, common.BigToHash(uint256.NewInt(uint64(i + 1)).ToBig())
+}
+
+// BenchmarkStorageCache measures repeated access to a large mapping, with the
+// shared trie node cache being too small to hold the storage trie.
+func BenchmarkStorageCache(b *testing.B) {
+	const slots = 100_000
+
+	for _, cached := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cached=%v", cached), func(b *testing.B) {
+			pdb, err := pebble.New(b.TempDir(), 16, 16, "", false)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var (
+				disk = rawdb.NewDatabase(pdb)
+				tdb  = triedb.NewDatabase(disk, &triedb.Config{HashDB: &hashdb.Config{CleanCacheSize: 1024 * 1024}})
+				db   = NewDatabase(tdb, nil)
+				addr = common.HexToAddress("0xa")
+			)
+			defer disk.Close()
+
+			root := makeLargeStorage(db, addr, slots)
+			if cached {
+				db.SetStorageCacheSize(addr, 256*1024*1024)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				state, _ := New(root, db)
+				for j := 0; j < 1000; j++ {
+					key, _ := largeStorageSlot((i*1000 + j) % slots)
+					state.GetState(addr, key)
+				}
+			}
+		})
+	}
+}
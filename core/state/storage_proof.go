@@ -0,0 +1,65 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+)
+
+// StorageProof is the Merkle proof of a single storage slot against the storage
+// root of the owning account.
+type StorageProof struct {
+	Key   common.Hash
+	Value common.Hash        // Zero if the slot does not exist
+	Proof trienode.ProofList // Exclusion proof if the slot does not exist
+}
+
+// GetStorageProofs returns the Merkle proofs of the given storage slots against
+// the current storage root of the account, as reported by GetStorageRoot. Slots
+// which are not present in the storage trie are proven with exclusion proofs.
+//
+// The proofs are built from the storage trie of the account, which only reflects
+// the modifications flushed by IntermediateRoot or Commit; slots modified in
+// the meantime are proven with their previous value.
+func (s *StateDB) GetStorageProofs(addr common.Address, slots []common.Hash) ([]StorageProof, error) {
+	proofs := make([]StorageProof, len(slots))
+	for i, slot := range slots {
+		proofs[i].Key = slot
+	}
+	// Non-existent accounts have an empty storage trie, nothing to prove against
+	obj := s.getStateObject(addr)
+	if obj == nil {
+		return proofs, nil
+	}
+	tr, err := obj.getTrie()
+	if err != nil {
+		return nil, err
+	}
+	for i, slot := range slots {
+		if err := tr.Prove(crypto.Keccak256(slot.Bytes()), &proofs[i].Proof); err != nil {
+			return nil, err
+		}
+		value, err := tr.GetStorage(addr, slot.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		proofs[i].Value = common.BytesToHash(value)
+	}
+	return proofs, nil
+}
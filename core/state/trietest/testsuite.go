@@ -0,0 +1,283 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package trietest contains the conformance suite of the trie backends pluggable
+// into the state database.
+package trietest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+)
+
+// TestTrieBackendSuite runs a suite of tests against a trie backend implementation.
+// New is invoked with a fresh trie database for every test.
+func TestTrieBackendSuite(t *testing.T, New func(db *triedb.Database) state.TrieBackend) {
+	t.Run("AccountRoundTrip", func(t *testing.T) {
+		backend := New(newTrieDatabase())
+		tr, err := backend.OpenTrie(types.EmptyRootHash)
+		if err != nil {
+			t.Fatalf("failed to open empty trie: %v", err)
+		}
+		addr := common.HexToAddress("0x01")
+		if acc, err := tr.GetAccount(addr); err != nil || acc != nil {
+			t.Fatalf("account in empty trie: %v, %v", acc, err)
+		}
+		want := testAccount(1)
+		if err := tr.UpdateAccount(addr, want, 0); err != nil {
+			t.Fatalf("failed to update account: %v", err)
+		}
+		have, err := tr.GetAccount(addr)
+		if err != nil {
+			t.Fatalf("failed to get account: %v", err)
+		}
+		if have == nil || have.Nonce != want.Nonce || have.Balance.Cmp(want.Balance) != 0 || have.Root != want.Root || !bytes.Equal(have.CodeHash, want.CodeHash) {
+			t.Fatalf("account mismatch: have %v, want %v", have, want)
+		}
+		if err := tr.DeleteAccount(addr); err != nil {
+			t.Fatalf("failed to delete account: %v", err)
+		}
+		if acc, err := tr.GetAccount(addr); err != nil || acc != nil {
+			t.Fatalf("deleted account retrieved: %v, %v", acc, err)
+		}
+		if tr.Hash() != types.EmptyRootHash {
+			t.Fatalf("non-empty root after deletion: %x", tr.Hash())
+		}
+	})
+
+	t.Run("StorageRoundTrip", func(t *testing.T) {
+		backend := New(newTrieDatabase())
+		addr := common.HexToAddress("0x01")
+		main, err := backend.OpenTrie(types.EmptyRootHash)
+		if err != nil {
+			t.Fatalf("failed to open empty trie: %v", err)
+		}
+		tr, err := backend.OpenStorageTrie(types.EmptyRootHash, addr, types.EmptyRootHash, main)
+		if err != nil {
+			t.Fatalf("failed to open empty storage trie: %v", err)
+		}
+		key, value := common.HexToHash("0x02").Bytes(), common.TrimLeftZeroes(common.HexToHash("0x03").Bytes())
+		if err := tr.UpdateStorage(addr, key, value); err != nil {
+			t.Fatalf("failed to update slot: %v", err)
+		}
+		if have, err := tr.GetStorage(addr, key); err != nil || !bytes.Equal(have, value) {
+			t.Fatalf("slot mismatch: have %x, want %x, err %v", have, value, err)
+		}
+		if err := tr.DeleteStorage(addr, key); err != nil {
+			t.Fatalf("failed to delete slot: %v", err)
+		}
+		if have, err := tr.GetStorage(addr, key); err != nil || len(have) != 0 {
+			t.Fatalf("deleted slot retrieved: %x, %v", have, err)
+		}
+	})
+
+	t.Run("HashDeterminism", func(t *testing.T) {
+		backend := New(newTrieDatabase())
+		a, _ := backend.OpenTrie(types.EmptyRootHash)
+		b, _ := backend.OpenTrie(types.EmptyRootHash)
+		for i := 0; i < 16; i++ {
+			a.UpdateAccount(testAddress(i), testAccount(i), 0)
+			b.UpdateAccount(testAddress(15-i), testAccount(15-i), 0)
+		}
+		if a.Hash() != b.Hash() {
+			t.Fatalf("insertion order changed the root: %x != %x", a.Hash(), b.Hash())
+		}
+		if a.Hash() == types.EmptyRootHash {
+			t.Fatal("empty root for non-empty trie")
+		}
+	})
+
+	t.Run("CommitReopen", func(t *testing.T) {
+		tdb := newTrieDatabase()
+		db := state.NewDatabase(tdb, nil).WithTrieBackend(New(tdb))
+		root := populateState(t, db)
+
+		statedb, err := state.New(root, db)
+		if err != nil {
+			t.Fatalf("failed to reopen state: %v", err)
+		}
+		checkState(t, statedb)
+
+		// Reopen through a fresh database, without any cached tries or nodes
+		db = state.NewDatabase(tdb, nil).WithTrieBackend(New(tdb))
+		statedb, err = state.New(root, db)
+		if err != nil {
+			t.Fatalf("failed to reopen state: %v", err)
+		}
+		checkState(t, statedb)
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		tdb := newTrieDatabase()
+		db := state.NewDatabase(tdb, nil).WithTrieBackend(New(tdb))
+		root := populateState(t, db)
+
+		orig, _ := state.New(root, db)
+		orig.GetBalance(testAddress(0)) // resolve the tries before copying
+		cpy := orig.Copy()
+		for i := 0; i < 4; i++ {
+			cpy.SetBalance(testAddress(i), uint256.NewInt(1000), tracing.BalanceChangeUnspecified)
+			cpy.SetState(testAddress(i), testSlot(0), common.Hash{})
+		}
+		if cpy.IntermediateRoot(false) == root {
+			t.Fatal("copy modifications not reflected in its root")
+		}
+		if have := orig.IntermediateRoot(false); have != root {
+			t.Fatalf("copy modifications leaked into the original: root %x, want %x", have, root)
+		}
+		checkState(t, orig)
+	})
+
+	t.Run("Prove", func(t *testing.T) {
+		tdb := newTrieDatabase()
+		db := state.NewDatabase(tdb, nil).WithTrieBackend(New(tdb))
+		root := populateState(t, db)
+
+		tr, err := db.OpenTrie(root)
+		if err != nil {
+			t.Fatalf("failed to open trie: %v", err)
+		}
+		for _, key := range [][]byte{crypto.Keccak256(testAddress(0).Bytes()), crypto.Keccak256([]byte("missing"))} {
+			proof := memorydb.New()
+			if err := tr.Prove(key, proof); err != nil {
+				t.Fatalf("failed to prove %x: %v", key, err)
+			}
+			if proof.Len() == 0 {
+				t.Fatalf("empty proof for %x", key)
+			}
+		}
+	})
+
+	t.Run("NodeIterator", func(t *testing.T) {
+		tdb := newTrieDatabase()
+		db := state.NewDatabase(tdb, nil).WithTrieBackend(New(tdb))
+		root := populateState(t, db)
+
+		tr, err := db.OpenTrie(root)
+		if err != nil {
+			t.Fatalf("failed to open trie: %v", err)
+		}
+		it, err := tr.NodeIterator(nil)
+		if err != nil {
+			t.Fatalf("failed to create iterator: %v", err)
+		}
+		var leaves int
+		for it.Next(true) {
+			if it.Leaf() {
+				leaves++
+			}
+		}
+		if it.Error() != nil {
+			t.Fatalf("iteration failed: %v", it.Error())
+		}
+		if leaves != testAccounts {
+			t.Fatalf("wrong number of leaves: have %d, want %d", leaves, testAccounts)
+		}
+	})
+}
+
+// testAccounts is the number of accounts populated by populateState.
+const testAccounts = 16
+
+func newTrieDatabase() *triedb.Database {
+	return triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil)
+}
+
+func testAddress(i int) common.Address {
+	return common.BigToAddress(uint256.NewInt(uint64(i + 1)).ToBig())
+}
+
+func testSlot(i int) common.Hash {
+	return common.BigToHash(uint256.NewInt(uint64(i + 1)).ToBig())
+}
+
+func testAccount(i int) *types.StateAccount {
+	return &types.StateAccount{
+		Nonce:    uint64(i),
+		Balance:  uint256.NewInt(uint64(i + 1)),
+		Root:     types.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	}
+}
+
+// populateState creates accounts with balances, storage and code, commits them
+// and returns the resulting state root.
+func populateState(t *testing.T, db *state.CachingDB) common.Hash {
+	statedb, err := state.New(types.EmptyRootHash, db)
+	if err != nil {
+		t.Fatalf("failed to open empty state: %v", err)
+	}
+	for i := 0; i < testAccounts; i++ {
+		addr := testAddress(i)
+		statedb.SetBalance(addr, uint256.NewInt(uint64(i+1)), tracing.BalanceChangeUnspecified)
+		statedb.SetNonce(addr, uint64(i), tracing.NonceChangeUnspecified)
+		for j := 0; j <= i%4; j++ {
+			statedb.SetState(addr, testSlot(j), testSlot(i+j))
+		}
+		if i%2 == 0 {
+			statedb.SetCode(addr, []byte{byte(i), 0x60, 0x00})
+		}
+	}
+	want := statedb.IntermediateRoot(false)
+	root, err := statedb.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	if root != want {
+		t.Fatalf("commit root mismatch: have %x, want %x", root, want)
+	}
+	if err := db.TrieDB().Commit(root, false); err != nil {
+		t.Fatalf("failed to flush state: %v", err)
+	}
+	return root
+}
+
+// checkState verifies the content of a state created by populateState.
+func checkState(t *testing.T, statedb *state.StateDB) {
+	t.Helper()
+
+	for i := 0; i < testAccounts; i++ {
+		addr := testAddress(i)
+		if have := statedb.GetBalance(addr); have.Uint64() != uint64(i+1) {
+			t.Fatalf("account %d: balance %v, want %d", i, have, i+1)
+		}
+		if have := statedb.GetNonce(addr); have != uint64(i) {
+			t.Fatalf("account %d: nonce %d, want %d", i, have, i)
+		}
+		for j := 0; j <= i%4; j++ {
+			if have := statedb.GetState(addr, testSlot(j)); have != testSlot(i+j) {
+				t.Fatalf("account %d, slot %d: have %x, want %x", i, j, have, testSlot(i+j))
+			}
+		}
+		var code []byte
+		if i%2 == 0 {
+			code = []byte{byte(i), 0x60, 0x00}
+		}
+		if have := statedb.GetCode(addr); !bytes.Equal(have, code) {
+			t.Fatalf("account %d: code %x, want %x", i, have, code)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trietest
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// mptBackend is a trie backend opening merkle patricia tries, wrapped so that
+// the state database can't recognize them as its builtin ones.
+type mptBackend struct {
+	db *triedb.Database
+}
+
+type mptTrie struct {
+	*trie.StateTrie
+}
+
+func (t *mptTrie) Copy() state.Trie {
+	return &mptTrie{t.StateTrie.Copy()}
+}
+
+func (b *mptBackend) OpenTrie(root common.Hash) (state.Trie, error) {
+	tr, err := trie.NewStateTrie(trie.StateTrieID(root), b.db)
+	if err != nil {
+		return nil, err
+	}
+	return &mptTrie{tr}, nil
+}
+
+func (b *mptBackend) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash, self state.Trie) (state.Trie, error) {
+	id := trie.StorageTrieID(stateRoot, crypto.Keccak256Hash(address.Bytes()), root)
+	tr, err := trie.NewStateTrie(id, b.db)
+	if err != nil {
+		return nil, err
+	}
+	return &mptTrie{tr}, nil
+}
+
+func TestMerkleBackend(t *testing.T) {
+	TestTrieBackendSuite(t, func(db *triedb.Database) state.TrieBackend {
+		return &mptBackend{db: db}
+	})
+}
@@ -18,6 +18,7 @@ package core
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -38,6 +39,18 @@ type ExecutionResult struct {
 	RefundedGas uint64 // Total gas refunded after execution
 	Err         error  // Any error encountered during the execution(listed in core/vm/errors.go)
 	ReturnData  []byte // Returned data from evm(function result or data supplied with revert opcode)
+
+	BaseFeeBurned   *uint256.Int // Base fee paid for the used gas and burned (EIP-1559)
+	BaseFeeCredited *uint256.Int // Base fee paid for the used gas and credited to the configured recipient instead of burned
+	PriorityFeePaid *uint256.Int // Priority fee paid for the used gas to the coinbase
+	BlobFeeBurned   *uint256.Int // Blob fee paid for the used blob gas and burned (EIP-4844)
+
+	PeakStackSize  int // Largest number of stack items held by any call frame
+	PeakMemorySize int // Largest memory size in bytes reached by any call frame
+
+	SelfDestructs []vm.SelfDestruct // Self-destructs executed in order, excluding the reverted ones
+	OutOfGas      *vm.OutOfGasFault // Operation running out of gas if the execution failed so, nil otherwise
+	CodeReads     []vm.CodeRead     // External code reads in execution order, if recording is enabled
 }
 
 // Unwrap returns the internal evm error which allows us for further
@@ -67,53 +80,12 @@ func (result *ExecutionResult) Revert() []byte {
 	return common.CopyBytes(result.ReturnData)
 }
 
-// IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
+// IntrinsicGas computes the 'intrinsic gas' for a message with the given data,
+// like types.IntrinsicGas with the flagged forks enabled. The access list is
+// always charged, as it can only be set since Berlin.
 func IntrinsicGas(data []byte, accessList types.AccessList, authList []types.SetCodeAuthorization, isContractCreation, isHomestead, isEIP2028, isEIP3860 bool) (uint64, error) {
-	// Set the starting gas for the raw transaction
-	var gas uint64
-	if isContractCreation && isHomestead {
-		gas = params.TxGasContractCreation
-	} else {
-		gas = params.TxGas
-	}
-	dataLen := uint64(len(data))
-	// Bump the required gas by the amount of transactional data
-	if dataLen > 0 {
-		// Zero and non-zero bytes are priced differently
-		z := uint64(bytes.Count(data, []byte{0}))
-		nz := dataLen - z
-
-		// Make sure we don't exceed uint64 for all data combinations
-		nonZeroGas := params.TxDataNonZeroGasFrontier
-		if isEIP2028 {
-			nonZeroGas = params.TxDataNonZeroGasEIP2028
-		}
-		if (math.MaxUint64-gas)/nonZeroGas < nz {
-			return 0, ErrGasUintOverflow
-		}
-		gas += nz * nonZeroGas
-
-		if (math.MaxUint64-gas)/params.TxDataZeroGas < z {
-			return 0, ErrGasUintOverflow
-		}
-		gas += z * params.TxDataZeroGas
-
-		if isContractCreation && isEIP3860 {
-			lenWords := toWordSize(dataLen)
-			if (math.MaxUint64-gas)/params.InitCodeWordGas < lenWords {
-				return 0, ErrGasUintOverflow
-			}
-			gas += lenWords * params.InitCodeWordGas
-		}
-	}
-	if accessList != nil {
-		gas += uint64(len(accessList)) * params.TxAccessListAddressGas
-		gas += uint64(accessList.StorageKeys()) * params.TxAccessListStorageKeyGas
-	}
-	if authList != nil {
-		gas += uint64(len(authList)) * params.CallNewAccountGas
-	}
-	return gas, nil
+	rules := params.Rules{IsHomestead: isHomestead, IsIstanbul: isEIP2028, IsBerlin: true, IsShanghai: isEIP3860}
+	return types.IntrinsicGas(data, accessList, authList, isContractCreation, rules)
 }
 
 // FloorDataGas computes the minimum gas required for a transaction based on its data tokens (EIP-7623).
@@ -131,15 +103,6 @@ func FloorDataGas(data []byte) (uint64, error) {
 	return params.TxGas + tokens*params.TxCostFloorPerToken, nil
 }
 
-// toWordSize returns the ceiled word size required for init code payment calculation.
-func toWordSize(size uint64) uint64 {
-	if size > math.MaxUint64-31 {
-		return math.MaxUint64/32 + 1
-	}
-
-	return (size + 31) / 32
-}
-
 // A Message contains the data derived from a single transaction that is relevant to state
 // processing.
 type Message struct {
@@ -429,7 +392,7 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 	)
 
 	// Check clauses 4-5, subtract intrinsic gas if everything is correct
-	gas, err := IntrinsicGas(msg.Data, msg.AccessList, msg.SetCodeAuthorizations, contractCreation, rules.IsHomestead, rules.IsIstanbul, rules.IsShanghai)
+	gas, err := types.IntrinsicGas(msg.Data, msg.AccessList, msg.SetCodeAuthorizations, contractCreation, rules)
 	if err != nil {
 		return nil, err
 	}
@@ -469,8 +432,8 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 	}
 
 	// Check whether the init code size has been exceeded.
-	if rules.IsShanghai && contractCreation && len(msg.Data) > params.MaxInitCodeSize {
-		return nil, fmt.Errorf("%w: code size %v limit %v", ErrMaxInitCodeSizeExceeded, len(msg.Data), params.MaxInitCodeSize)
+	if limit := st.evm.MaxInitCodeSize(); rules.IsShanghai && contractCreation && len(msg.Data) > limit {
+		return nil, fmt.Errorf("%w: code size %v limit %v", ErrMaxInitCodeSizeExceeded, len(msg.Data), limit)
 	}
 
 	// Execute the preparatory steps for state transition which includes:
@@ -533,6 +496,22 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 	}
 	effectiveTipU256, _ := uint256.FromBig(effectiveTip)
 
+	result := &ExecutionResult{
+		UsedGas:         st.gasUsed(),
+		RefundedGas:     gasRefund,
+		Err:             vmerr,
+		ReturnData:      ret,
+		BaseFeeBurned:   new(uint256.Int),
+		BaseFeeCredited: new(uint256.Int),
+		PriorityFeePaid: new(uint256.Int),
+		BlobFeeBurned:   st.blobFee(),
+	}
+	result.PeakStackSize, result.PeakMemorySize = st.evm.ResourcePeaks()
+	result.SelfDestructs = st.evm.SelfDestructs()
+	result.CodeReads = st.evm.CodeReads()
+	if errors.Is(vmerr, vm.ErrOutOfGas) {
+		result.OutOfGas = st.evm.OutOfGasFault()
+	}
 	if st.evm.Config.NoBaseFee && msg.GasFeeCap.Sign() == 0 && msg.GasTipCap.Sign() == 0 {
 		// Skip fee payment when NoBaseFee is set and the fee fields
 		// are 0. This avoids a negative effectiveTip being applied to
@@ -541,25 +520,35 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 		fee := new(uint256.Int).SetUint64(st.gasUsed())
 		fee.Mul(fee, effectiveTipU256)
 		st.state.AddBalance(st.evm.Context.Coinbase, fee, tracing.BalanceIncreaseRewardTransactionFee)
+		result.PriorityFeePaid = fee
+
+		if rules.IsLondon {
+			baseFee, _ := uint256.FromBig(st.evm.Context.BaseFee)
+			baseFee.Mul(new(uint256.Int).SetUint64(st.gasUsed()), baseFee)
+
+			if recipient := st.evm.Config.BaseFeeRecipient; recipient != nil {
+				st.state.AddBalance(*recipient, baseFee, tracing.BalanceIncreaseRewardTransactionFee)
+				result.BaseFeeCredited = baseFee
 
+				if rules.IsEIP4762 && baseFee.Sign() != 0 {
+					st.evm.AccessEvents.AddAccount(*recipient, true)
+				}
+			} else {
+				result.BaseFeeBurned = baseFee
+			}
+		}
 		// add the coinbase to the witness iff the fee is greater than 0
 		if rules.IsEIP4762 && fee.Sign() != 0 {
 			st.evm.AccessEvents.AddAccount(st.evm.Context.Coinbase, true)
 		}
 	}
-
-	return &ExecutionResult{
-		UsedGas:     st.gasUsed(),
-		RefundedGas: gasRefund,
-		Err:         vmerr,
-		ReturnData:  ret,
-	}, nil
+	return result, nil
 }
 
 // validateAuthorization validates an EIP-7702 authorization against the state.
 func (st *stateTransition) validateAuthorization(auth *types.SetCodeAuthorization) (authority common.Address, err error) {
 	// Verify chain ID is null or equal to current chain ID.
-	if !auth.ChainID.IsZero() && auth.ChainID.CmpBig(st.evm.ChainConfig().ChainID) != 0 {
+	if !auth.ValidChainID(st.evm.ChainConfig().ChainID) {
 		return authority, ErrAuthorizationWrongChainID
 	}
 	// Limit nonce to 2^64-1 per EIP-2681.
@@ -654,6 +643,16 @@ func (st *stateTransition) gasUsed() uint64 {
 	return st.initialGas - st.gasRemaining
 }
 
+// blobFee returns the blob fee charged for the message, zero before Cancun.
+func (st *stateTransition) blobFee() *uint256.Int {
+	fee := new(uint256.Int)
+	if blobGas := st.blobGasUsed(); blobGas > 0 && st.evm.ChainConfig().IsCancun(st.evm.Context.BlockNumber, st.evm.Context.Time) {
+		blobBaseFee, _ := uint256.FromBig(st.evm.Context.BlobBaseFee)
+		fee.Mul(new(uint256.Int).SetUint64(blobGas), blobBaseFee)
+	}
+	return fee
+}
+
 // blobGasUsed returns the amount of blob gas used by the message.
 func (st *stateTransition) blobGasUsed() uint64 {
 	return uint64(len(st.msg.BlobHashes) * params.BlobTxBlobGasPerBlob)
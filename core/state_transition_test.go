@@ -0,0 +1,388 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestExecutionResultFees(t *testing.T) {
+	var (
+		sender   = common.HexToAddress("0x1000")
+		to       = common.HexToAddress("0x2000")
+		coinbase = common.HexToAddress("0x3000")
+		treasury = common.HexToAddress("0x4000")
+	)
+	tests := []struct {
+		name      string
+		feeCap    int64
+		tipCap    int64
+		blobs     int
+		noBaseFee bool
+		redirect  bool
+		baseFee   uint64
+		tip       uint64
+		blobFee   uint64
+	}{
+		{name: "tip-capped", feeCap: 20, tipCap: 3, baseFee: 10, tip: 3},
+		{name: "fee-capped", feeCap: 12, tipCap: 3, baseFee: 10, tip: 2},
+		{name: "blob", feeCap: 20, tipCap: 3, blobs: 2, baseFee: 10, tip: 3, blobFee: 2 * params.BlobTxBlobGasPerBlob * 7},
+		{name: "simulated", noBaseFee: true},
+		{name: "redirected", feeCap: 20, tipCap: 3, redirect: true, baseFee: 10, tip: 3},
+	}
+	for _, tt := range tests {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.SetBalance(sender, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+
+		header := &types.Header{
+			Number:     big.NewInt(1),
+			GasLimit:   30_000_000,
+			BaseFee:    big.NewInt(10),
+			Difficulty: new(big.Int),
+			Coinbase:   coinbase,
+		}
+		blockCtx := NewEVMBlockContext(header, nil, &coinbase)
+		blockCtx.BlobBaseFee = big.NewInt(7)
+		config := vm.Config{NoBaseFee: tt.noBaseFee}
+		if tt.redirect {
+			config.BaseFeeRecipient = &treasury
+		}
+		evm := vm.NewEVM(blockCtx, statedb, params.MergedTestChainConfig, config)
+
+		msg := &Message{
+			From:             sender,
+			To:               &to,
+			Value:            new(big.Int),
+			GasLimit:         params.TxGas,
+			GasFeeCap:        big.NewInt(tt.feeCap),
+			GasTipCap:        big.NewInt(tt.tipCap),
+			GasPrice:         new(big.Int),
+			SkipNonceChecks:  true,
+			SkipFromEOACheck: true,
+		}
+		if !tt.noBaseFee {
+			msg.GasPrice = big.NewInt(min(tt.feeCap, 10+tt.tipCap))
+		}
+		for i := 0; i < tt.blobs; i++ {
+			msg.BlobHashes = append(msg.BlobHashes, common.Hash{0x01, byte(i)})
+			msg.BlobGasFeeCap = big.NewInt(7)
+		}
+		result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit))
+		if err != nil {
+			t.Fatalf("%s: failed to apply message: %v", tt.name, err)
+		}
+		burned, credited := result.BaseFeeBurned, result.BaseFeeCredited
+		if tt.redirect {
+			burned, credited = credited, burned
+		}
+		if want := tt.baseFee * params.TxGas; burned.Uint64() != want {
+			t.Errorf("%s: base fee paid mismatch: have %v, want %d", tt.name, burned, want)
+		}
+		if !credited.IsZero() {
+			t.Errorf("%s: base fee both burned and credited: %v", tt.name, credited)
+		}
+		if have := statedb.GetBalance(treasury); !have.Eq(result.BaseFeeCredited) {
+			t.Errorf("%s: base fee recipient balance mismatch: have %v, want %v", tt.name, have, result.BaseFeeCredited)
+		}
+		if want := tt.tip * params.TxGas; result.PriorityFeePaid.Uint64() != want {
+			t.Errorf("%s: priority fee paid mismatch: have %v, want %d", tt.name, result.PriorityFeePaid, want)
+		}
+		if result.BlobFeeBurned.Uint64() != tt.blobFee {
+			t.Errorf("%s: blob fee burned mismatch: have %v, want %d", tt.name, result.BlobFeeBurned, tt.blobFee)
+		}
+		// The fees add up to the balance changes of the sender and the coinbase
+		if have := statedb.GetBalance(coinbase); !have.Eq(result.PriorityFeePaid) {
+			t.Errorf("%s: coinbase balance mismatch: have %v, want %v", tt.name, have, result.PriorityFeePaid)
+		}
+		spent := new(uint256.Int).Sub(uint256.NewInt(params.Ether), statedb.GetBalance(sender))
+		total := new(uint256.Int).Add(result.BaseFeeBurned, result.BaseFeeCredited)
+		total.Add(total, result.PriorityFeePaid)
+		total.Add(total, result.BlobFeeBurned)
+		if !spent.Eq(total) {
+			t.Errorf("%s: sender spent %v, fees total %v", tt.name, spent, total)
+		}
+	}
+}
+
+func TestExecutionResultResourcePeaks(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x1000")
+		caller = common.HexToAddress("0x2000")
+		callee = common.HexToAddress("0x3000")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetBalance(sender, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+
+	// caller: mstore(0x80, 1); call(gas, callee, 0, 0, 0, 0, 0)
+	code := []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0x80, byte(vm.MSTORE)}
+	code = append(code, byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH20))
+	code = append(code, callee.Bytes()...)
+	code = append(code, byte(vm.GAS), byte(vm.CALL), byte(vm.STOP))
+	statedb.SetCode(caller, code)
+
+	// callee: push ten items, then mstore(0x3e0, 1)
+	code = nil
+	for i := 0; i < 10; i++ {
+		code = append(code, byte(vm.PUSH1), 0)
+	}
+	code = append(code, byte(vm.PUSH1), 1, byte(vm.PUSH2), 0x03, 0xe0, byte(vm.MSTORE), byte(vm.STOP))
+	statedb.SetCode(callee, code)
+
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   30_000_000,
+		BaseFee:    new(big.Int),
+		Difficulty: new(big.Int),
+	}
+	evm := vm.NewEVM(NewEVMBlockContext(header, nil, new(common.Address)), statedb, params.MergedTestChainConfig, vm.Config{NoBaseFee: true})
+	msg := &Message{
+		From:             sender,
+		To:               &caller,
+		Value:            new(big.Int),
+		GasLimit:         1_000_000,
+		GasPrice:         new(big.Int),
+		GasFeeCap:        new(big.Int),
+		GasTipCap:        new(big.Int),
+		SkipNonceChecks:  true,
+		SkipFromEOACheck: true,
+	}
+	result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit))
+	if err != nil || result.Err != nil {
+		t.Fatalf("failed to apply message: %v, %v", err, result.Err)
+	}
+	// The peaks are both reached in the nested frame
+	if result.PeakStackSize != 12 {
+		t.Errorf("peak stack size mismatch: have %d, want %d", result.PeakStackSize, 12)
+	}
+	if result.PeakMemorySize != 1024 {
+		t.Errorf("peak memory size mismatch: have %d, want %d", result.PeakMemorySize, 1024)
+	}
+}
+
+func TestExecutionResultSelfDestructs(t *testing.T) {
+	var (
+		sender      = common.HexToAddress("0x1000")
+		beneficiary = common.HexToAddress("0x2000")
+		first       = common.HexToAddress("0x3000")
+		second      = common.HexToAddress("0x4000")
+		reverter    = common.HexToAddress("0x5000")
+		reverted    = common.HexToAddress("0x6000")
+	)
+	selfdestruct := append(append([]byte{byte(vm.PUSH20)}, beneficiary.Bytes()...), byte(vm.SELFDESTRUCT))
+	call := func(addr common.Address) []byte {
+		code := []byte{byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH20)}
+		code = append(code, addr.Bytes()...)
+		return append(code, byte(vm.GAS), byte(vm.CALL), byte(vm.POP))
+	}
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetBalance(sender, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	for i, addr := range []common.Address{first, second, reverted} {
+		statedb.SetCode(addr, selfdestruct)
+		statedb.SetBalance(addr, uint256.NewInt(uint64(i+1)), tracing.BalanceChangeUnspecified)
+	}
+	// reverter: call(reverted); revert(0, 0)
+	statedb.SetCode(reverter, append(call(reverted), byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.REVERT)))
+
+	// The deployed contract self-destructs after calling the others
+	var initcode []byte
+	for _, addr := range []common.Address{first, second, reverter} {
+		initcode = append(initcode, call(addr)...)
+	}
+	initcode = append(initcode, selfdestruct...)
+
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   30_000_000,
+		BaseFee:    new(big.Int),
+		Difficulty: new(big.Int),
+	}
+	evm := vm.NewEVM(NewEVMBlockContext(header, nil, new(common.Address)), statedb, params.MergedTestChainConfig, vm.Config{NoBaseFee: true})
+	msg := &Message{
+		From:             sender,
+		Value:            big.NewInt(8),
+		GasLimit:         1_000_000,
+		GasPrice:         new(big.Int),
+		GasFeeCap:        new(big.Int),
+		GasTipCap:        new(big.Int),
+		Data:             initcode,
+		SkipNonceChecks:  true,
+		SkipFromEOACheck: true,
+	}
+	result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit))
+	if err != nil || result.Err != nil {
+		t.Fatalf("failed to apply message: %v, %v", err, result.Err)
+	}
+	// Only the contract created in the transaction is deleted (EIP-6780), the
+	// self-destruct of the reverted frame is dropped.
+	want := []vm.SelfDestruct{
+		{Contract: first, Beneficiary: beneficiary, Amount: uint256.NewInt(1)},
+		{Contract: second, Beneficiary: beneficiary, Amount: uint256.NewInt(2)},
+		{Contract: crypto.CreateAddress(sender, 0), Beneficiary: beneficiary, Amount: uint256.NewInt(8), Destructed: true},
+	}
+	if !reflect.DeepEqual(result.SelfDestructs, want) {
+		t.Fatalf("self-destructs mismatch:\nhave %+v\nwant %+v", result.SelfDestructs, want)
+	}
+	if have := statedb.GetBalance(beneficiary); !have.Eq(uint256.NewInt(11)) {
+		t.Errorf("beneficiary balance mismatch: have %v, want %d", have, 11)
+	}
+}
+
+func TestExecutionResultCodeReads(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x1000")
+		entry  = common.HexToAddress("0x2000")
+		lib    = common.HexToAddress("0x3000")
+		hashed = common.HexToAddress("0x4000") // Never called, only hashed
+		copied = common.HexToAddress("0x5000") // Never called, only copied
+	)
+	call := func(op vm.OpCode, addr common.Address) []byte {
+		code := []byte{byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH20)}
+		code = append(code, addr.Bytes()...)
+		return append(code, byte(vm.GAS), byte(op), byte(vm.POP))
+	}
+	// entry: extcodehash(hashed); staticcall(lib); delegatecall(lib)
+	code := append(append([]byte{byte(vm.PUSH20)}, hashed.Bytes()...), byte(vm.EXTCODEHASH), byte(vm.POP))
+	code = append(code, call(vm.STATICCALL, lib)...)
+	code = append(code, call(vm.DELEGATECALL, lib)...)
+
+	// lib: extcodecopy(copied, 0, 0, 0)
+	libcode := append([]byte{byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH20)}, copied.Bytes()...)
+	libcode = append(libcode, byte(vm.EXTCODECOPY))
+
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   30_000_000,
+		BaseFee:    new(big.Int),
+		Difficulty: new(big.Int),
+	}
+	for _, record := range []bool{false, true} {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.SetBalance(sender, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+		statedb.SetCode(entry, code)
+		statedb.SetCode(lib, libcode)
+		statedb.SetCode(hashed, []byte{byte(vm.STOP)})
+		statedb.SetCode(copied, []byte{byte(vm.STOP)})
+
+		evm := vm.NewEVM(NewEVMBlockContext(header, nil, new(common.Address)), statedb, params.MergedTestChainConfig, vm.Config{NoBaseFee: true, RecordCodeReads: record})
+		msg := &Message{
+			From:             sender,
+			To:               &entry,
+			Value:            new(big.Int),
+			GasLimit:         1_000_000,
+			GasPrice:         new(big.Int),
+			GasFeeCap:        new(big.Int),
+			GasTipCap:        new(big.Int),
+			SkipNonceChecks:  true,
+			SkipFromEOACheck: true,
+		}
+		result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit))
+		if err != nil || result.Err != nil {
+			t.Fatalf("failed to apply message: %v, %v", err, result.Err)
+		}
+		if !record {
+			if result.CodeReads != nil {
+				t.Fatalf("code reads recorded without being enabled: %+v", result.CodeReads)
+			}
+			continue
+		}
+		// The delegated frame reads on behalf of the entry contract
+		want := []vm.CodeRead{
+			{Op: vm.EXTCODEHASH, Reader: entry, Depth: 1, Target: hashed},
+			{Op: vm.STATICCALL, Reader: entry, Depth: 1, Target: lib},
+			{Op: vm.EXTCODECOPY, Reader: lib, Depth: 2, Target: copied},
+			{Op: vm.DELEGATECALL, Reader: entry, Depth: 1, Target: lib},
+			{Op: vm.EXTCODECOPY, Reader: entry, Depth: 2, Target: copied},
+		}
+		if !reflect.DeepEqual(result.CodeReads, want) {
+			t.Fatalf("code reads mismatch:\nhave %+v\nwant %+v", result.CodeReads, want)
+		}
+	}
+}
+
+func TestExecutionResultOutOfGas(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x1000")
+		store  = common.HexToAddress("0x2000")
+		caller = common.HexToAddress("0x3000")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetBalance(sender, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+
+	// store: sstore(0, 1)
+	statedb.SetCode(store, []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)})
+
+	// caller: call(0x1000, store, 0, 0, 0, 0, 0)
+	code := []byte{byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH20)}
+	code = append(code, store.Bytes()...)
+	code = append(code, byte(vm.PUSH2), 0x10, 0x00, byte(vm.CALL), byte(vm.STOP))
+	statedb.SetCode(caller, code)
+
+	tests := []struct {
+		name string
+		to   common.Address
+		gas  uint64
+		want *vm.OutOfGasFault
+	}{
+		// The cold zero to non-zero store costs 22100 gas (EIP-2929)
+		{"dynamic", store, params.TxGas + 6 + 10000, &vm.OutOfGasFault{Op: vm.SSTORE, PC: 4, Gas: 10000, Shortfall: 12100}},
+		{"constant", store, params.TxGas + 4, &vm.OutOfGasFault{Op: vm.PUSH1, PC: 2, Gas: 1, Shortfall: 2}},
+		// The nested frame running out of gas doesn't fail the transaction
+		{"nested", caller, 100000, nil},
+	}
+	for _, tt := range tests {
+		header := &types.Header{
+			Number:     big.NewInt(1),
+			GasLimit:   30_000_000,
+			BaseFee:    new(big.Int),
+			Difficulty: new(big.Int),
+		}
+		evm := vm.NewEVM(NewEVMBlockContext(header, nil, new(common.Address)), statedb.Copy(), params.MergedTestChainConfig, vm.Config{NoBaseFee: true})
+		msg := &Message{
+			From:             sender,
+			To:               &tt.to,
+			Value:            new(big.Int),
+			GasLimit:         tt.gas,
+			GasPrice:         new(big.Int),
+			GasFeeCap:        new(big.Int),
+			GasTipCap:        new(big.Int),
+			SkipNonceChecks:  true,
+			SkipFromEOACheck: true,
+		}
+		result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit))
+		if err != nil {
+			t.Fatalf("%s: failed to apply message: %v", tt.name, err)
+		}
+		if tt.want != nil && !errors.Is(result.Err, vm.ErrOutOfGas) {
+			t.Errorf("%s: execution error mismatch: have %v, want %v", tt.name, result.Err, vm.ErrOutOfGas)
+		}
+		if !reflect.DeepEqual(result.OutOfGas, tt.want) {
+			t.Errorf("%s: out of gas fault mismatch: have %+v, want %+v", tt.name, result.OutOfGas, tt.want)
+		}
+	}
+}
@@ -53,6 +53,7 @@ type StateDB interface {
 	GetCodeHash(common.Address) common.Hash
 	GetState(common.Address, common.Hash) common.Hash
 	GetTransientState(common.Address, common.Hash) common.Hash
+	GetTransientStorage(common.Address) map[common.Hash]common.Hash
 	Exist(common.Address) bool
 	GetRefund() uint64
 }
@@ -65,6 +66,9 @@ type VMContext struct {
 	Random      *common.Hash
 	BaseFee     *big.Int
 	StateDB     StateDB
+
+	BlobBaseFee   *big.Int // Blob base fee charged in the block, nil before Cancun
+	ExcessBlobGas *uint64  // Excess blob gas of the block, nil before Cancun
 }
 
 // BlockEvent is emitted upon tracing an incoming block.
@@ -123,10 +123,11 @@ type BlockChain interface {
 
 // Config are the configuration parameters of the transaction pool.
 type Config struct {
-	Locals    []common.Address // Addresses that should be treated by default as local
-	NoLocals  bool             // Whether local transaction handling should be disabled
-	Journal   string           // Journal of local transactions to survive node restarts
-	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	Locals       []common.Address // Addresses that should be treated by default as local
+	NoLocals     bool             // Whether local transaction handling should be disabled
+	Journal      string           // Journal of local transactions to survive node restarts
+	Rejournal    time.Duration    // Time interval to regenerate the local transaction journal
+	JournalLimit uint64           // Size in bytes to regenerate the journal at before the interval (0 = unlimited)
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -141,8 +142,9 @@ type Config struct {
 
 // DefaultConfig contains the default configurations for the transaction pool.
 var DefaultConfig = Config{
-	Journal:   "transactions.rlp",
-	Rejournal: time.Hour,
+	Journal:      "transactions.rlp",
+	Rejournal:    time.Hour,
+	JournalLimit: 64 * 1024 * 1024,
 
 	PriceLimit: 1,
 	PriceBump:  10,
@@ -21,6 +21,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -32,6 +33,10 @@ import (
 // into the journal, but no such file is currently open.
 var errNoActiveJournal = errors.New("no active journal")
 
+// errJournalFull is returned if a transaction is attempted to be inserted into
+// the journal, but doing so would exceed its size limit.
+var errJournalFull = errors.New("journal size limit reached")
+
 // devNull is a WriteCloser that just discards anything written into it. Its
 // goal is to allow the transaction journal to write into a fake journal when
 // loading transactions on startup without printing warnings due to no file
@@ -46,12 +51,16 @@ func (*devNull) Close() error                      { return nil }
 type journal struct {
 	path   string         // Filesystem path to store the transactions at
 	writer io.WriteCloser // Output stream to write new transactions into
+	size   uint64         // Number of bytes of the live journal
+	limit  uint64         // Maximum number of bytes of the journal (0 = unlimited)
+	full   bool           // Whether the last rotation had to leave transactions out
 }
 
 // newTxJournal creates a new transaction journal to
-func newTxJournal(path string) *journal {
+func newTxJournal(path string, limit uint64) *journal {
 	return &journal{
-		path: path,
+		path:  path,
+		limit: limit,
 	}
 }
 
@@ -71,7 +80,7 @@ func (journal *journal) load(add func([]*types.Transaction) []error) error {
 
 	// Temporarily discard any journal additions (don't double add on load)
 	journal.writer = new(devNull)
-	defer func() { journal.writer = nil }()
+	defer func() { journal.writer, journal.size = nil, 0 }()
 
 	// Inject all transactions from the journal into the pool
 	stream := rlp.NewStream(input, 0)
@@ -122,14 +131,23 @@ func (journal *journal) insert(tx *types.Transaction) error {
 	if journal.writer == nil {
 		return errNoActiveJournal
 	}
-	if err := rlp.Encode(journal.writer, tx); err != nil {
+	blob, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	if journal.limit > 0 && journal.size+uint64(len(blob)) > journal.limit {
+		return errJournalFull
+	}
+	if _, err := journal.writer.Write(blob); err != nil {
 		return err
 	}
+	journal.size += uint64(len(blob))
 	return nil
 }
 
 // rotate regenerates the transaction journal based on the current contents of
-// the transaction pool.
+// the transaction pool. If the transactions exceed the size limit of the journal,
+// the highest nonce ones of the accounts are left out.
 func (journal *journal) rotate(all map[common.Address]types.Transactions) error {
 	// Close the current journal (if any is open)
 	if journal.writer != nil {
@@ -143,15 +161,39 @@ func (journal *journal) rotate(all map[common.Address]types.Transactions) error
 	if err != nil {
 		return err
 	}
-	journaled := 0
-	for _, txs := range all {
-		for _, tx := range txs {
-			if err = rlp.Encode(replacement, tx); err != nil {
+	var (
+		journaled int
+		skipped   int
+		size      uint64
+	)
+	// Journal the accounts in a fixed order, so the same ones are dropped on
+	// each rotation if they don't all fit
+	addrs := make([]common.Address, 0, len(all))
+	for addr := range all {
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, common.Address.Cmp)
+
+	for _, addr := range addrs {
+		txs := all[addr]
+		for i, tx := range txs {
+			blob, err := rlp.EncodeToBytes(tx)
+			if err != nil {
 				replacement.Close()
 				return err
 			}
+			// Subsequent transactions of the account are useless without this one
+			if journal.limit > 0 && size+uint64(len(blob)) > journal.limit {
+				skipped += len(txs) - i
+				break
+			}
+			if _, err = replacement.Write(blob); err != nil {
+				replacement.Close()
+				return err
+			}
+			size += uint64(len(blob))
+			journaled++
 		}
-		journaled += len(txs)
 	}
 	replacement.Close()
 
@@ -164,12 +206,17 @@ func (journal *journal) rotate(all map[common.Address]types.Transactions) error
 		return err
 	}
 	journal.writer = sink
+	journal.size = size
+	journal.full = skipped > 0
 
 	logger := log.Info
 	if len(all) == 0 {
 		logger = log.Debug
 	}
-	logger("Regenerated local transaction journal", "transactions", journaled, "accounts", len(all))
+	logger("Regenerated local transaction journal", "transactions", journaled, "accounts", len(all), "size", common.StorageSize(size))
+	if skipped > 0 {
+		log.Warn("Transaction journal size limit reached", "limit", common.StorageSize(journal.limit), "skipped", skipped)
+	}
 
 	return nil
 }
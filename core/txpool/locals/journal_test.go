@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package locals
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the journal stops accepting transactions at its size limit, and
+// that rotating it compacts it to the given transactions within the limit.
+func TestJournalRotation(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "transactions.rlp")
+		txs  types.Transactions
+	)
+	for i := 0; i < 10; i++ {
+		txs = append(txs, types.NewTransaction(uint64(i), common.Address{0x01}, big.NewInt(1), 21000, big.NewInt(1), nil))
+	}
+	size, _ := rlp.EncodeToBytes(txs[0])
+	limit := uint64(4 * len(size))
+
+	journal := newTxJournal(path, limit)
+	if err := journal.rotate(nil); err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	defer journal.close()
+
+	// Fill the journal up to the limit
+	for i, tx := range txs {
+		err := journal.insert(tx)
+		if i < 4 && err != nil {
+			t.Fatalf("tx %d: failed to insert: %v", i, err)
+		}
+		if i >= 4 && !errors.Is(err, errJournalFull) {
+			t.Fatalf("tx %d: insert error mismatch: have %v, want %v", i, err, errJournalFull)
+		}
+	}
+	if stat, err := os.Stat(path); err != nil || uint64(stat.Size()) != limit {
+		t.Fatalf("journal size mismatch: have %v (err %v), want %d", stat.Size(), err, limit)
+	}
+	// Rotate the journal, dropping the first two (mined) transactions. Only the
+	// lowest nonces of the rest fit into the journal.
+	if err := journal.rotate(map[common.Address]types.Transactions{{0x01}: txs[2:]}); err != nil {
+		t.Fatalf("failed to rotate journal: %v", err)
+	}
+	if stat, err := os.Stat(path); err != nil || uint64(stat.Size()) != limit {
+		t.Fatalf("rotated journal size mismatch: have %v (err %v), want %d", stat.Size(), err, limit)
+	}
+	if err := journal.insert(txs[9]); !errors.Is(err, errJournalFull) {
+		t.Fatalf("insert error mismatch: have %v, want %v", err, errJournalFull)
+	}
+	// Rotate again with room to spare, new transactions are accepted again
+	if err := journal.rotate(map[common.Address]types.Transactions{{0x01}: txs[8:9]}); err != nil {
+		t.Fatalf("failed to rotate journal: %v", err)
+	}
+	if err := journal.insert(txs[9]); err != nil {
+		t.Fatalf("failed to insert after rotation: %v", err)
+	}
+	journal.close()
+
+	var loaded []uint64
+	if err := newTxJournal(path, limit).load(func(txs []*types.Transaction) []error {
+		for _, tx := range txs {
+			loaded = append(loaded, tx.Nonce())
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to load journal: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0] != 8 || loaded[1] != 9 {
+		t.Fatalf("loaded transactions mismatch: have %v, want [8 9]", loaded)
+	}
+}
+
+// Tests that rotating an overflowing journal always keeps the same accounts.
+func TestJournalRotationOrder(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "transactions.rlp")
+		all  = make(map[common.Address]types.Transactions)
+	)
+	for i := byte(0); i < 10; i++ {
+		all[common.Address{i}] = types.Transactions{types.NewTransaction(uint64(i), common.Address{0x01}, big.NewInt(1), 21000, big.NewInt(1), nil)}
+	}
+	size, _ := rlp.EncodeToBytes(all[common.Address{}][0])
+	limit := uint64(4 * len(size))
+
+	journal := newTxJournal(path, limit)
+	defer journal.close()
+
+	for i := 0; i < 5; i++ {
+		if err := journal.rotate(all); err != nil {
+			t.Fatalf("failed to rotate journal: %v", err)
+		}
+		var loaded []uint64
+		if err := newTxJournal(path, limit).load(func(txs []*types.Transaction) []error {
+			for _, tx := range txs {
+				loaded = append(loaded, tx.Nonce())
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("failed to load journal: %v", err)
+		}
+		if !slices.Equal(loaded, []uint64{0, 1, 2, 3}) {
+			t.Fatalf("rotation %d: loaded transactions mismatch: have %v, want [0 1 2 3]", i, loaded)
+		}
+	}
+}
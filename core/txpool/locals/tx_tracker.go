@@ -18,6 +18,7 @@
 package locals
 
 import (
+	"errors"
 	"sync"
 	"time"
 
@@ -51,22 +52,25 @@ type TxTracker struct {
 	pool      *txpool.TxPool // The tx pool to interact with
 	signer    types.Signer
 
+	rotateCh   chan struct{} // Notification channel to rotate a full journal early
 	shutdownCh chan struct{}
 	mu         sync.Mutex
 	wg         sync.WaitGroup
 }
 
-// New creates a new TxTracker
-func New(journalPath string, journalTime time.Duration, chainConfig *params.ChainConfig, next *txpool.TxPool) *TxTracker {
+// New creates a new TxTracker. The journal is rotated every journalTime, or as
+// soon as it grows beyond journalLimit bytes if non-zero.
+func New(journalPath string, journalTime time.Duration, journalLimit uint64, chainConfig *params.ChainConfig, next *txpool.TxPool) *TxTracker {
 	pool := &TxTracker{
 		all:        make(map[common.Hash]*types.Transaction),
 		byAddr:     make(map[common.Address]*legacypool.SortedMap),
 		signer:     types.LatestSigner(chainConfig),
+		rotateCh:   make(chan struct{}, 1),
 		shutdownCh: make(chan struct{}),
 		pool:       next,
 	}
 	if journalPath != "" {
-		pool.journal = newTxJournal(journalPath)
+		pool.journal = newTxJournal(journalPath, journalLimit)
 		pool.rejournal = journalTime
 	}
 	return pool
@@ -100,10 +104,21 @@ func (tracker *TxTracker) TrackAll(txs []*types.Transaction) {
 		if tracker.byAddr[addr] == nil {
 			tracker.byAddr[addr] = legacypool.NewSortedMap()
 		}
+		// Forget about any replaced transaction, not to journal it anymore
+		if old := tracker.byAddr[addr].Get(tx.Nonce()); old != nil {
+			delete(tracker.all, old.Hash())
+		}
 		tracker.byAddr[addr].Put(tx)
 
 		if tracker.journal != nil {
-			_ = tracker.journal.insert(tx)
+			// Rotating early is pointless if the tracked transactions didn't fit
+			// into the journal the last time, leave it to the periodic rotation.
+			if err := tracker.journal.insert(tx); errors.Is(err, errJournalFull) && !tracker.journal.full {
+				select {
+				case tracker.rotateCh <- struct{}{}:
+				default:
+				}
+			}
 		}
 	}
 	localGauge.Update(int64(len(tracker.all)))
@@ -191,6 +206,15 @@ func (tracker *TxTracker) loop() {
 		select {
 		case <-tracker.shutdownCh:
 			return
+		case <-tracker.rotateCh:
+			// The journal is full, compact it without waiting for the next rotation
+			resubmits, rejournal := tracker.recheck(true)
+			if len(resubmits) > 0 {
+				tracker.pool.Add(resubmits, false)
+			}
+			tracker.rotate(rejournal)
+			lastJournal = time.Now()
+
 		case <-timer.C:
 			checkJournal := tracker.journal != nil && time.Since(lastJournal) > tracker.rejournal
 			resubmits, rejournal := tracker.recheck(checkJournal)
@@ -198,15 +222,21 @@ func (tracker *TxTracker) loop() {
 				tracker.pool.Add(resubmits, false)
 			}
 			if checkJournal {
-				// Lock to prevent journal.rotate <-> journal.insert (via TrackAll) conflicts
-				tracker.mu.Lock()
+				tracker.rotate(rejournal)
 				lastJournal = time.Now()
-				if err := tracker.journal.rotate(rejournal); err != nil {
-					log.Warn("Transaction journal rotation failed", "err", err)
-				}
-				tracker.mu.Unlock()
 			}
 			timer.Reset(recheckInterval)
 		}
 	}
 }
+
+// rotate regenerates the journal with the given transactions.
+func (tracker *TxTracker) rotate(txs map[common.Address]types.Transactions) {
+	// Lock to prevent journal.rotate <-> journal.insert (via TrackAll) conflicts
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if err := tracker.journal.rotate(txs); err != nil {
+		log.Warn("Transaction journal rotation failed", "err", err)
+	}
+}
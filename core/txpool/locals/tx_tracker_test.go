@@ -0,0 +1,88 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package locals
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that a full journal requests an early rotation, unless the tracked
+// transactions didn't fit into it on the last rotation anyway.
+func TestTrackerEarlyRotation(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		signer  = types.LatestSigner(params.TestChainConfig)
+		txs     types.Transactions
+		tracked = make(map[common.Address]types.Transactions)
+	)
+	for i := 0; i < 10; i++ {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: uint64(i), To: &common.Address{0x01}, Gas: 21000, GasPrice: big.NewInt(1)})
+		txs = append(txs, tx)
+	}
+	size, _ := rlp.EncodeToBytes(txs[0])
+
+	tracker := New(filepath.Join(t.TempDir(), "transactions.rlp"), time.Hour, uint64(4*len(size)), params.TestChainConfig, nil)
+	if err := tracker.journal.rotate(nil); err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	defer tracker.journal.close()
+
+	rotation := func() bool {
+		select {
+		case <-tracker.rotateCh:
+			return true
+		default:
+			return false
+		}
+	}
+	// Overflowing the journal requests a rotation
+	tracker.TrackAll(txs[:5])
+	if !rotation() {
+		t.Fatal("full journal didn't request a rotation")
+	}
+	// Once the rotation leaves transactions out, overflowing it again doesn't
+	tracked[crypto.PubkeyToAddress(key.PublicKey)] = txs[:5]
+	tracker.rotate(tracked)
+
+	for i := 5; i < len(txs); i++ {
+		tracker.Track(txs[i])
+		if rotation() {
+			t.Fatalf("tx %d: rotation requested despite the tracked transactions not fitting", i)
+		}
+	}
+	// After a rotation with room to spare, overflowing requests a rotation again
+	tracked[crypto.PubkeyToAddress(key.PublicKey)] = txs[8:]
+	tracker.rotate(tracked)
+
+	tracker.TrackAll(types.Transactions{
+		types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 10, To: &common.Address{0x01}, Gas: 21000, GasPrice: big.NewInt(1)}),
+		types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 11, To: &common.Address{0x01}, Gas: 21000, GasPrice: big.NewInt(1)}),
+		types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 12, To: &common.Address{0x01}, Gas: 21000, GasPrice: big.NewInt(1)}),
+	})
+	if !rotation() {
+		t.Fatal("full journal didn't request a rotation after making room")
+	}
+}
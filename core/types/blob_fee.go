@@ -0,0 +1,64 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var minBlobGasPrice = big.NewInt(params.BlobTxMinBlobGasprice)
+
+// CalcBlobFee calculates the blob base fee from the excess blob gas, using the
+// update fraction of EIP-4844. Use the fork aware eip4844.CalcBlobFee for a given
+// chain config.
+//
+// The consensus rules live in eip4844, which depends on this package, so the
+// helpers here mirror them for the tools not having a chain config at hand.
+func CalcBlobFee(excessBlobGas uint64) *big.Int {
+	fraction := new(big.Int).SetUint64(params.DefaultCancunBlobConfig.UpdateFraction)
+	return fakeExponential(minBlobGasPrice, new(big.Int).SetUint64(excessBlobGas), fraction)
+}
+
+// CalcExcessBlobGas calculates the excess blob gas of a block from the excess
+// blob gas and blob gas used of its parent, given the target blob gas per block
+// at the block's fork.
+func CalcExcessBlobGas(parentExcessBlobGas uint64, parentBlobGasUsed uint64, targetBlobGas uint64) uint64 {
+	excessBlobGas := parentExcessBlobGas + parentBlobGasUsed
+	if excessBlobGas < targetBlobGas {
+		return 0
+	}
+	return excessBlobGas - targetBlobGas
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) using
+// Taylor expansion, as eip4844 does.
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	var (
+		output = new(big.Int)
+		accum  = new(big.Int).Mul(factor, denominator)
+	)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+	return output.Div(output, denominator)
+}
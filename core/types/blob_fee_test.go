@@ -0,0 +1,72 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestCalcBlobFee(t *testing.T) {
+	tests := []struct {
+		excessBlobGas uint64
+		blobfee       int64
+	}{
+		{0, 1},
+		{2314057, 1},
+		{2314058, 2},
+		{10 * 1024 * 1024, 23},
+	}
+	for i, tt := range tests {
+		have := CalcBlobFee(tt.excessBlobGas)
+		if have.Int64() != tt.blobfee {
+			t.Errorf("test %d: blobfee mismatch: have %v want %v", i, have, tt.blobfee)
+		}
+	}
+}
+
+func TestCalcExcessBlobGas(t *testing.T) {
+	target := uint64(3 * params.BlobTxBlobGasPerBlob)
+
+	var tests = []struct {
+		excess uint64
+		used   uint64
+		want   uint64
+	}{
+		// The excess blob gas should not increase from zero if the used blob
+		// slots are below - or equal - to the target.
+		{0, 0, 0},
+		{0, params.BlobTxBlobGasPerBlob, 0},
+		{0, target, 0},
+
+		// If the target blob gas is exceeded, the excessBlobGas should increase
+		// by however much it was overshot
+		{0, target + params.BlobTxBlobGasPerBlob, params.BlobTxBlobGasPerBlob},
+		{1, target + params.BlobTxBlobGasPerBlob, params.BlobTxBlobGasPerBlob + 1},
+
+		// The excess blob gas should decrease by however much the target was
+		// under-shot, capped at zero.
+		{target, target - params.BlobTxBlobGasPerBlob, target - params.BlobTxBlobGasPerBlob},
+		{params.BlobTxBlobGasPerBlob - 1, target - params.BlobTxBlobGasPerBlob, 0},
+	}
+	for i, tt := range tests {
+		if have := CalcExcessBlobGas(tt.excess, tt.used, target); have != tt.want {
+			t.Errorf("test %d: excess blob gas mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
@@ -376,6 +376,15 @@ func (b *Block) Transaction(hash common.Hash) *Transaction {
 	return nil
 }
 
+// DeriveTxHash recomputes the transactions root of the block with the given trie
+// implementation instead of returning the one in the header, along with the time
+// spent on it. This is useful to verify and benchmark alternative implementations.
+func (b *Block) DeriveTxHash(hasher TrieHasher) (common.Hash, time.Duration) {
+	start := time.Now()
+	root := DeriveSha(Transactions(b.transactions), hasher)
+	return root, time.Since(start)
+}
+
 // Header returns the block header (as a copy).
 func (b *Block) Header() *Header {
 	return CopyHeader(b.header)
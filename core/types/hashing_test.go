@@ -235,3 +235,23 @@ func (d *hashToHumanReadable) Update(i []byte, i2 []byte) error {
 func (d *hashToHumanReadable) Hash() common.Hash {
 	return common.Hash{}
 }
+
+func TestBlockDeriveTxHash(t *testing.T) {
+	txs, err := genTxs(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := types.NewBlock(&types.Header{}, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+
+	if root, _ := trie.DeriveTxHash(block); root != block.TxHash() {
+		t.Fatalf("stack trie root mismatch: have %x, want %x", root, block.TxHash())
+	}
+	root, _ := block.DeriveTxHash(trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil)))
+	if root != block.TxHash() {
+		t.Fatalf("trie root mismatch: have %x, want %x", root, block.TxHash())
+	}
+	// A broken implementation is not masked by the header
+	if root, _ := block.DeriveTxHash(&hashToHumanReadable{}); root == block.TxHash() {
+		t.Fatal("broken implementation matched the header root")
+	}
+}
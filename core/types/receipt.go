@@ -38,7 +38,14 @@ var (
 	receiptStatusSuccessfulRLP = []byte{0x01}
 )
 
-var errShortTypedReceipt = errors.New("typed receipt too short")
+var (
+	errShortTypedReceipt = errors.New("typed receipt too short")
+	errDecreasingGasUsed = errors.New("cumulative gas used decreasing")
+
+	// ErrBlockGasMismatch is returned if the gas used by a block is inconsistent
+	// with its receipts or transactions.
+	ErrBlockGasMismatch = errors.New("block gas used mismatch")
+)
 
 const (
 	// ReceiptStatusFailed is the status code of a transaction if execution failed.
@@ -375,3 +382,52 @@ func (rs Receipts) DeriveFields(config *params.ChainConfig, hash common.Hash, nu
 	}
 	return nil
 }
+
+// PerTxGasUsed returns the gas used by each transaction of the block, derived
+// by differencing the cumulative gas used of consecutive receipts. It returns
+// an error if the cumulative gas used decreases along the receipts.
+func (rs Receipts) PerTxGasUsed() ([]uint64, error) {
+	var (
+		used = make([]uint64, len(rs))
+		prev uint64
+	)
+	for i, r := range rs {
+		if r.CumulativeGasUsed < prev {
+			return nil, fmt.Errorf("%w: receipt %d: %d < %d", errDecreasingGasUsed, i, r.CumulativeGasUsed, prev)
+		}
+		used[i] = r.CumulativeGasUsed - prev
+		prev = r.CumulativeGasUsed
+	}
+	return used, nil
+}
+
+// VerifyBlockGas checks the gas used by a block for consistency, without executing
+// it: the header's gas used must equal the cumulative gas used of the last receipt,
+// zero for empty blocks, and fit the header's gas limit, while the gas used by each
+// transaction must fit its gas limit. The transactions and receipts are expected
+// in block order.
+func VerifyBlockGas(header *Header, txs Transactions, receipts Receipts) error {
+	if len(txs) != len(receipts) {
+		return fmt.Errorf("%w: %d transactions, %d receipts", ErrBlockGasMismatch, len(txs), len(receipts))
+	}
+	used, err := receipts.PerTxGasUsed()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBlockGasMismatch, err)
+	}
+	var cumulative uint64
+	if len(receipts) > 0 {
+		cumulative = receipts[len(receipts)-1].CumulativeGasUsed
+	}
+	if header.GasUsed != cumulative {
+		return fmt.Errorf("%w: header %d, receipts %d", ErrBlockGasMismatch, header.GasUsed, cumulative)
+	}
+	if header.GasUsed > header.GasLimit {
+		return fmt.Errorf("%w: gas used %d above gas limit %d", ErrBlockGasMismatch, header.GasUsed, header.GasLimit)
+	}
+	for i, tx := range txs {
+		if used[i] > tx.Gas() {
+			return fmt.Errorf("%w: transaction %d used %d above gas limit %d", ErrBlockGasMismatch, i, used[i], tx.Gas())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// compactReceiptsRLP is the experimental compact storage encoding of the receipts
+// of a block. Log addresses and topics are interned into per-block tables and
+// referenced by their index.
+type compactReceiptsRLP struct {
+	Addresses []common.Address
+	Topics    []common.Hash
+	Receipts  []compactReceiptRLP
+}
+
+// compactReceiptRLP is the compact storage encoding of a single receipt.
+type compactReceiptRLP struct {
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []compactLogRLP
+}
+
+// compactLogRLP is the compact storage encoding of a single log, with the address
+// and topics replaced by indices into the interning tables.
+type compactLogRLP struct {
+	Address uint64
+	Topics  []uint64
+	Data    []byte
+}
+
+// ReceiptsEncodeCompact encodes the receipts of a block into the experimental
+// compact storage format. Like ReceiptForStorage, the encoding only contains the
+// content fields of the receipts; additionally the log addresses and topics are
+// stored once per block, which significantly reduces the size for blocks with
+// many logs from the same contracts or events.
+//
+// The encoding is an optional storage optimization and not part of consensus.
+func ReceiptsEncodeCompact(rs Receipts) ([]byte, error) {
+	var (
+		enc       = compactReceiptsRLP{Receipts: make([]compactReceiptRLP, len(rs))}
+		addresses = make(map[common.Address]uint64)
+		topics    = make(map[common.Hash]uint64)
+	)
+	for i, r := range rs {
+		receipt := compactReceiptRLP{
+			PostStateOrStatus: r.statusEncoding(),
+			CumulativeGasUsed: r.CumulativeGasUsed,
+			Logs:              make([]compactLogRLP, len(r.Logs)),
+		}
+		for j, log := range r.Logs {
+			index, ok := addresses[log.Address]
+			if !ok {
+				index = uint64(len(enc.Addresses))
+				addresses[log.Address] = index
+				enc.Addresses = append(enc.Addresses, log.Address)
+			}
+			clog := compactLogRLP{
+				Address: index,
+				Topics:  make([]uint64, len(log.Topics)),
+				Data:    log.Data,
+			}
+			for k, topic := range log.Topics {
+				index, ok := topics[topic]
+				if !ok {
+					index = uint64(len(enc.Topics))
+					topics[topic] = index
+					enc.Topics = append(enc.Topics, topic)
+				}
+				clog.Topics[k] = index
+			}
+			receipt.Logs[j] = clog
+		}
+		enc.Receipts[i] = receipt
+	}
+	return rlp.EncodeToBytes(&enc)
+}
+
+// ReceiptsDecodeCompact decodes receipts encoded by ReceiptsEncodeCompact. As with
+// ReceiptForStorage, only the content fields are restored and the bloom filters
+// are recomputed; the remaining fields need to be derived separately.
+func ReceiptsDecodeCompact(b []byte) (Receipts, error) {
+	var dec compactReceiptsRLP
+	if err := rlp.DecodeBytes(b, &dec); err != nil {
+		return nil, err
+	}
+	rs := make(Receipts, len(dec.Receipts))
+	for i, stored := range dec.Receipts {
+		r := &Receipt{
+			CumulativeGasUsed: stored.CumulativeGasUsed,
+			Logs:              make([]*Log, len(stored.Logs)),
+		}
+		if err := r.setStatus(stored.PostStateOrStatus); err != nil {
+			return nil, err
+		}
+		for j, stored := range stored.Logs {
+			if stored.Address >= uint64(len(dec.Addresses)) {
+				return nil, fmt.Errorf("receipt %d, log %d: address index %d out of range", i, j, stored.Address)
+			}
+			log := &Log{
+				Address: dec.Addresses[stored.Address],
+				Topics:  make([]common.Hash, len(stored.Topics)),
+				Data:    stored.Data,
+			}
+			for k, index := range stored.Topics {
+				if index >= uint64(len(dec.Topics)) {
+					return nil, fmt.Errorf("receipt %d, log %d: topic index %d out of range", i, j, index)
+				}
+				log.Topics[k] = dec.Topics[index]
+			}
+			r.Logs[j] = log
+		}
+		r.Bloom = CreateBloom(r)
+		rs[i] = r
+	}
+	return rs, nil
+}
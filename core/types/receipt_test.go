@@ -19,6 +19,7 @@ package types
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"math"
 	"math/big"
 	"reflect"
@@ -334,6 +335,96 @@ func TestDeriveFields(t *testing.T) {
 
 // Test that we can marshal/unmarshal receipts to/from json without errors.
 // This also confirms that our test receipts contain all the required fields.
+func TestReceiptsPerTxGasUsed(t *testing.T) {
+	receipts := func(cumulative ...uint64) Receipts {
+		rs := make(Receipts, len(cumulative))
+		for i, gas := range cumulative {
+			rs[i] = &Receipt{CumulativeGasUsed: gas}
+		}
+		return rs
+	}
+	tests := []struct {
+		receipts Receipts
+		used     []uint64
+		fail     bool
+	}{
+		{receipts: receipts(), used: []uint64{}},
+		{receipts: receipts(21000), used: []uint64{21000}},
+		{receipts: receipts(21000, 42000, 100000), used: []uint64{21000, 21000, 58000}},
+		{receipts: receipts(21000, 21000), used: []uint64{21000, 0}},
+		{receipts: receipts(21000, 42000, 30000), fail: true},
+	}
+	for i, tt := range tests {
+		used, err := tt.receipts.PerTxGasUsed()
+		if tt.fail {
+			if !errors.Is(err, errDecreasingGasUsed) {
+				t.Errorf("test %d: unexpected error: have %v, want %v", i, err, errDecreasingGasUsed)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(used, tt.used) {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, tt.used)
+		}
+	}
+	// The derived gas used matches the one filled by DeriveFields
+	used, err := receipts(21000, 42000, 100000).PerTxGasUsed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := receipts(21000, 42000, 100000)
+	txs := make([]*Transaction, len(rs))
+	for i := range txs {
+		txs[i] = NewTx(&LegacyTx{To: &common.Address{}, GasPrice: new(big.Int)})
+	}
+	if err := rs.DeriveFields(params.TestChainConfig, common.Hash{}, 0, 0, nil, nil, txs); err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range rs {
+		if r.GasUsed != used[i] {
+			t.Errorf("receipt %d: derived gas used mismatch: have %d, want %d", i, used[i], r.GasUsed)
+		}
+	}
+}
+
+func TestVerifyBlockGas(t *testing.T) {
+	var (
+		txs = Transactions{
+			NewTx(&LegacyTx{To: &common.Address{}, Gas: 21000}),
+			NewTx(&LegacyTx{To: &common.Address{}, Gas: 50000}),
+		}
+		receipts = Receipts{{CumulativeGasUsed: 21000}, {CumulativeGasUsed: 61000}}
+	)
+	tests := []struct {
+		name     string
+		header   *Header
+		txs      Transactions
+		receipts Receipts
+		fail     bool
+	}{
+		{"valid", &Header{GasUsed: 61000, GasLimit: 100000}, txs, receipts, false},
+		{"empty", &Header{GasLimit: 100000}, nil, nil, false},
+		{"empty-gas-used", &Header{GasUsed: 1, GasLimit: 100000}, nil, nil, true},
+		{"header-mismatch", &Header{GasUsed: 60000, GasLimit: 100000}, txs, receipts, true},
+		{"above-block-limit", &Header{GasUsed: 61000, GasLimit: 60000}, txs, receipts, true},
+		{"above-tx-limit", &Header{GasUsed: 72000, GasLimit: 100000}, txs, Receipts{{CumulativeGasUsed: 22000}, {CumulativeGasUsed: 72000}}, true},
+		{"decreasing", &Header{GasUsed: 20000, GasLimit: 100000}, txs, Receipts{{CumulativeGasUsed: 21000}, {CumulativeGasUsed: 20000}}, true},
+		{"missing-receipt", &Header{GasUsed: 21000, GasLimit: 100000}, txs, receipts[:1], true},
+	}
+	for _, tt := range tests {
+		err := VerifyBlockGas(tt.header, tt.txs, tt.receipts)
+		if tt.fail && !errors.Is(err, ErrBlockGasMismatch) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, ErrBlockGasMismatch)
+		}
+		if !tt.fail && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+	}
+}
+
 func TestReceiptJSON(t *testing.T) {
 	for i := range receipts {
 		b, err := receipts[i].MarshalJSON()
@@ -527,3 +618,117 @@ func clearComputedFieldsOnLogs(logs []*Log) []*Log {
 	}
 	return l
 }
+
+func TestReceiptsCompactEncoding(t *testing.T) {
+	for i, rs := range []Receipts{
+		{},
+		{legacyReceipt, accessListReceipt, eip1559Receipt},
+		makeCompactTestReceipts(150),
+	} {
+		enc, err := ReceiptsEncodeCompact(rs)
+		if err != nil {
+			t.Fatalf("test %d: encoding failed: %v", i, err)
+		}
+		have, err := ReceiptsDecodeCompact(enc)
+		if err != nil {
+			t.Fatalf("test %d: decoding failed: %v", i, err)
+		}
+		// The result must be identical to the one of the plain storage encoding
+		want := make(Receipts, len(rs))
+		for j, r := range rs {
+			blob, err := rlp.EncodeToBytes((*ReceiptForStorage)(r))
+			if err != nil {
+				t.Fatalf("test %d: storage encoding failed: %v", i, err)
+			}
+			var stored ReceiptForStorage
+			if err := rlp.DecodeBytes(blob, &stored); err != nil {
+				t.Fatalf("test %d: storage decoding failed: %v", i, err)
+			}
+			want[j] = (*Receipt)(&stored)
+		}
+		if len(have) != len(want) {
+			t.Fatalf("test %d: receipt count mismatch: have %d, want %d", i, len(have), len(want))
+		}
+		for j := range want {
+			haveJSON, _ := json.Marshal(have[j])
+			wantJSON, _ := json.Marshal(want[j])
+			if !bytes.Equal(haveJSON, wantJSON) {
+				t.Fatalf("test %d, receipt %d: mismatch\nhave %s\nwant %s", i, j, haveJSON, wantJSON)
+			}
+		}
+	}
+	// Corrupted indices must be rejected
+	enc, _ := rlp.EncodeToBytes(&compactReceiptsRLP{
+		Receipts: []compactReceiptRLP{{Logs: []compactLogRLP{{Address: 1}}}},
+	})
+	if _, err := ReceiptsDecodeCompact(enc); err == nil {
+		t.Fatal("expected error for out of range address index")
+	}
+}
+
+// makeCompactTestReceipts creates a block worth of receipts modelled after a
+// typical mainnet block: most logs are token transfers and approvals emitted by
+// a small set of popular contracts, between a larger set of accounts.
+func makeCompactTestReceipts(n int) Receipts {
+	var (
+		transfer = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+		approval = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+		swap     = common.HexToHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822")
+		rs       = make(Receipts, n)
+		gas      uint64
+	)
+	account := func(i int) common.Hash {
+		return common.BytesToHash(common.BigToAddress(big.NewInt(int64(0x1000 + i%97))).Bytes())
+	}
+	for i := 0; i < n; i++ {
+		gas += 21000 + uint64(i%7)*15000
+		r := &Receipt{Type: DynamicFeeTxType, Status: ReceiptStatusSuccessful, CumulativeGasUsed: gas}
+		for j := 0; j < i%5; j++ {
+			var (
+				contract = common.BigToAddress(big.NewInt(int64(0xc0de + (i+j)%13)))
+				data     = common.LeftPadBytes(big.NewInt(int64(i*1000+j)).Bytes(), 32)
+				topics   []common.Hash
+			)
+			switch j % 3 {
+			case 0:
+				topics = []common.Hash{transfer, account(i), account(i + j + 1)}
+			case 1:
+				topics = []common.Hash{approval, account(i), account(j)}
+			case 2:
+				topics = []common.Hash{swap, account(j), account(i)}
+				data = append(data, make([]byte, 96)...)
+			}
+			r.Logs = append(r.Logs, &Log{Address: contract, Topics: topics, Data: data})
+		}
+		r.Bloom = CreateBloom(r)
+		rs[i] = r
+	}
+	return rs
+}
+
+func BenchmarkReceiptsCompactEncoding(b *testing.B) {
+	rs := makeCompactTestReceipts(200)
+
+	var plain int
+	for _, r := range rs {
+		blob, _ := rlp.EncodeToBytes((*ReceiptForStorage)(r))
+		plain += len(blob)
+	}
+	b.Run("encode", func(b *testing.B) {
+		var size int
+		for i := 0; i < b.N; i++ {
+			enc, _ := ReceiptsEncodeCompact(rs)
+			size = len(enc)
+		}
+		b.ReportMetric(float64(plain), "plain-bytes")
+		b.ReportMetric(float64(size), "compact-bytes")
+		b.ReportMetric(100*float64(size)/float64(plain), "%-of-plain")
+	})
+	b.Run("decode", func(b *testing.B) {
+		enc, _ := ReceiptsEncodeCompact(rs)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ReceiptsDecodeCompact(enc)
+		}
+	})
+}
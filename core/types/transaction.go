@@ -22,11 +22,14 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"sort"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -35,7 +38,18 @@ var (
 	ErrUnexpectedProtection = errors.New("transaction type does not supported EIP-155 protected signatures")
 	ErrInvalidTxType        = errors.New("transaction type not valid in this context")
 	ErrTxTypeNotSupported   = errors.New("transaction type not supported")
+	ErrTxTruncated          = errors.New("transaction encoding truncated")
+	ErrTxInvalidField       = errors.New("invalid transaction field")
+	ErrTxTrailingBytes      = errors.New("trailing bytes after transaction")
+	ErrTxNonCanonical       = errors.New("non-canonical transaction encoding")
 	ErrGasFeeCapTooLow      = errors.New("fee cap less than base fee")
+	ErrTipAboveFeeCap       = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrNegativeFee          = errors.New("negative max fee or max priority fee per gas")
+	ErrOversizedTx          = errors.New("transaction too large")
+	ErrAccessListTooLarge   = errors.New("access list too large for gas limit")
+	ErrAuthListTooLarge     = errors.New("authorization list too large for gas limit")
+	ErrTooManyBlobs         = errors.New("too many blobs in transaction")
+	ErrNotContractCreation  = errors.New("transaction does not create a contract")
 	errShortTypedTx         = errors.New("typed transaction too short")
 	errInvalidYParity       = errors.New("'yParity' field must be 0 or 1")
 	errVYParityMismatch     = errors.New("'v' and 'yParity' fields do not match")
@@ -100,6 +114,7 @@ type TxData interface {
 
 	encode(*bytes.Buffer) error
 	decode([]byte) error
+	encodedSize() uint64 // size of the encoding, excluding the type byte
 }
 
 // EncodeRLP implements rlp.Encoder
@@ -179,7 +194,7 @@ func (tx *Transaction) UnmarshalBinary(b []byte) error {
 		var data LegacyTx
 		err := rlp.DecodeBytes(b, &data)
 		if err != nil {
-			return err
+			return classifyDecodeError(err)
 		}
 		tx.setDecoded(&data, uint64(len(b)))
 		return nil
@@ -187,12 +202,26 @@ func (tx *Transaction) UnmarshalBinary(b []byte) error {
 	// It's an EIP-2718 typed transaction envelope.
 	inner, err := tx.decodeTyped(b)
 	if err != nil {
-		return err
+		return classifyDecodeError(err)
 	}
 	tx.setDecoded(inner, uint64(len(b)))
 	return nil
 }
 
+// UnmarshalBinaryLenient decodes transactions like UnmarshalBinary, but it also
+// accepts legacy transactions wrapped into an EIP-2718 envelope with the type byte
+// of LegacyTxType. Such encodings are produced by some non-conforming tooling and
+// are invalid in consensus, so this method must not be used for consensus data.
+//
+// The decoded transaction is identical to the one decoded from the canonical
+// encoding, i.e. the envelope type byte is not included in its hash and size.
+func (tx *Transaction) UnmarshalBinaryLenient(b []byte) error {
+	if len(b) > 1 && b[0] == LegacyTxType && b[1] > 0x7f {
+		b = b[1:]
+	}
+	return tx.UnmarshalBinary(b)
+}
+
 // decodeTyped decodes a typed transaction from the canonical format.
 func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 	if len(b) <= 1 {
@@ -215,6 +244,27 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 	return inner, err
 }
 
+// classifyDecodeError wraps a transaction decoding error into the most specific
+// of ErrTxTruncated, ErrTxTrailingBytes, ErrTxNonCanonical and ErrTxInvalidField.
+// The original error is retained in the chain, unsupported types are returned as is.
+//
+// Integers with leading zero bytes and non-minimal size prefixes are rejected by
+// the decoder rather than normalized, as they would change the transaction hash.
+func classifyDecodeError(err error) error {
+	switch {
+	case errors.Is(err, ErrTxTypeNotSupported):
+		return err
+	case errors.Is(err, rlp.ErrCanonInt), errors.Is(err, rlp.ErrCanonSize):
+		return fmt.Errorf("%w: %w", ErrTxNonCanonical, err)
+	case errors.Is(err, errShortTypedTx), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, rlp.ErrValueTooLarge):
+		return fmt.Errorf("%w: %w", ErrTxTruncated, err)
+	case errors.Is(err, rlp.ErrMoreThanOneValue):
+		return fmt.Errorf("%w: %w", ErrTxTrailingBytes, err)
+	default:
+		return fmt.Errorf("%w: %w", ErrTxInvalidField, err)
+	}
+}
+
 // setDecoded sets the inner transaction and size after decoding.
 func (tx *Transaction) setDecoded(inner TxData, size uint64) {
 	tx.inner = inner
@@ -311,6 +361,26 @@ func (tx *Transaction) To() *common.Address {
 	return copyAddressPtr(tx.inner.to())
 }
 
+// IsContractCreation reports whether the transaction creates a contract. Blob and
+// set code transactions can't create contracts.
+func (tx *Transaction) IsContractCreation() bool {
+	switch tx.inner.(type) {
+	case *BlobTx, *SetCodeTx:
+		return false
+	default:
+		return tx.inner.to() == nil
+	}
+}
+
+// ContractAddress returns the address of the contract deployed by a contract
+// creation transaction sent by the given account, without executing it.
+func (tx *Transaction) ContractAddress(sender common.Address) (common.Address, error) {
+	if !tx.IsContractCreation() {
+		return common.Address{}, ErrNotContractCreation
+	}
+	return crypto.CreateAddress(sender, tx.Nonce()), nil
+}
+
 // Cost returns (gas * gasPrice) + (blobGas * blobGasPrice) + value.
 func (tx *Transaction) Cost() *big.Int {
 	total := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.Gas()))
@@ -348,6 +418,53 @@ func (tx *Transaction) GasTipCapIntCmp(other *big.Int) int {
 	return tx.inner.gasTipCap().Cmp(other)
 }
 
+// ValidateFees checks the sanity of the EIP-1559 fee fields of dynamic fee, blob
+// and set code transactions: neither of the caps can be negative and the tip cap
+// can't exceed the fee cap. Other transactions have no such fields to check.
+func (tx *Transaction) ValidateFees() error {
+	if tx.Type() < DynamicFeeTxType {
+		return nil
+	}
+	feeCap, tipCap := tx.inner.gasFeeCap(), tx.inner.gasTipCap()
+	if feeCap.Sign() < 0 || tipCap.Sign() < 0 {
+		return fmt.Errorf("%w: maxPriorityFeePerGas: %s, maxFeePerGas: %s", ErrNegativeFee, tipCap, feeCap)
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		return fmt.Errorf("%w: maxPriorityFeePerGas: %s, maxFeePerGas: %s", ErrTipAboveFeeCap, tipCap, feeCap)
+	}
+	return nil
+}
+
+// maxTxBlobs is the number of blobs a transaction can't exceed in any fork. The
+// fork-specific blob limit is checked against the chain config by the callers.
+const maxTxBlobs = 128
+
+// SanityCheck performs the cheap, stateless checks guarding against oversized
+// transactions before any expensive processing. The encoded size, including the
+// blob sidecar, can't exceed maxSize, which depends on the caller. The access
+// and authorization lists can't be longer than what the gas limit of the
+// transaction could ever pay for, and the number of blobs is capped.
+func (tx *Transaction) SanityCheck(maxSize int) error {
+	if size := tx.Size(); size > uint64(maxSize) {
+		return fmt.Errorf("%w: size %d, limit %d", ErrOversizedTx, size, maxSize)
+	}
+	// The lists are bounded by the size check, so the gas can't overflow
+	var gas uint64
+	for _, tuple := range tx.AccessList() {
+		gas += params.TxAccessListAddressGas + uint64(len(tuple.StorageKeys))*params.TxAccessListStorageKeyGas
+	}
+	if gas > tx.Gas() {
+		return fmt.Errorf("%w: intrinsic gas %d, gas limit %d", ErrAccessListTooLarge, gas, tx.Gas())
+	}
+	if auths := tx.SetCodeAuthorizations(); uint64(len(auths))*params.CallNewAccountGas > tx.Gas() {
+		return fmt.Errorf("%w: %d authorizations, gas limit %d", ErrAuthListTooLarge, len(auths), tx.Gas())
+	}
+	if blobs := len(tx.BlobHashes()); blobs > maxTxBlobs {
+		return fmt.Errorf("%w: %d blobs, limit %d", ErrTooManyBlobs, blobs, maxTxBlobs)
+	}
+	return nil
+}
+
 // EffectiveGasTip returns the effective miner gasTipCap for the given base fee.
 // Note: if the effective gasTipCap is negative, this method returns both error
 // the actual negative value, _and_ ErrGasFeeCapTooLow
@@ -392,6 +509,22 @@ func (tx *Transaction) EffectiveGasTipIntCmp(other *big.Int, baseFee *big.Int) i
 	return tx.EffectiveGasTipValue(baseFee).Cmp(other)
 }
 
+// MeetsFeeFloor reports whether the fees of the transaction cover the given base
+// fee plus the minimum tip, i.e. whether its fee cap covers the base fee and its
+// effective tip reaches the minimum. Legacy transactions pay their gas price as
+// both fee cap and tip. A nil base fee (pre-London) only checks the tip, and a
+// nil minimum tip is treated as zero.
+func (tx *Transaction) MeetsFeeFloor(baseFee, minTip *big.Int) bool {
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return false
+	}
+	if minTip == nil {
+		return tip.Sign() >= 0
+	}
+	return tip.Cmp(minTip) >= 0
+}
+
 // BlobGas returns the blob gas limit of the transaction for blob transactions, 0 otherwise.
 func (tx *Transaction) BlobGas() uint64 {
 	if blobtx, ok := tx.inner.(*BlobTx); ok {
@@ -498,6 +631,39 @@ func (tx *Transaction) SetCodeAuthorities() []common.Address {
 	return auths
 }
 
+// ReferencedAddresses returns the deduplicated set of addresses statically
+// referenced by the transaction: the sender, the recipient, the addresses of the
+// access list and the authorities of the authorization list, in that order.
+//
+// If the sender cannot be recovered, the error is returned along with the rest
+// of the addresses.
+func (tx *Transaction) ReferencedAddresses(signer Signer) ([]common.Address, error) {
+	var (
+		addrs []common.Address
+		seen  = make(map[common.Address]struct{})
+	)
+	add := func(addr common.Address) {
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+	from, err := Sender(signer, tx)
+	if err == nil {
+		add(from)
+	}
+	if to := tx.To(); to != nil {
+		add(*to)
+	}
+	for _, tuple := range tx.AccessList() {
+		add(tuple.Address)
+	}
+	for _, addr := range tx.SetCodeAuthorities() {
+		add(addr)
+	}
+	return addrs, err
+}
+
 // SetTime sets the decoding time of a transaction. This is used by tests to set
 // arbitrary times and by persistent transaction pools when loading old txs from
 // disk.
@@ -555,6 +721,60 @@ func (tx *Transaction) Size() uint64 {
 	return size
 }
 
+// String returns a human-readable summary of the transaction for logging and
+// debugging. The sender is only included if it was already derived and cached,
+// and blob transactions report the number of blobs rather than their content.
+func (tx *Transaction) String() string {
+	if tx.inner == nil {
+		return "Transaction{}"
+	}
+	var b strings.Builder
+	switch tx.Type() {
+	case LegacyTxType:
+		b.WriteString("LegacyTx")
+	case AccessListTxType:
+		b.WriteString("AccessListTx")
+	case DynamicFeeTxType:
+		b.WriteString("DynamicFeeTx")
+	case BlobTxType:
+		b.WriteString("BlobTx")
+	case SetCodeTxType:
+		b.WriteString("SetCodeTx")
+	default:
+		fmt.Fprintf(&b, "Tx%d", tx.Type())
+	}
+	fmt.Fprintf(&b, "{hash: %v, nonce: %d", tx.Hash(), tx.Nonce())
+	if sc := tx.from.Load(); sc != nil {
+		fmt.Fprintf(&b, ", from: %v", sc.from)
+	}
+	if to := tx.inner.to(); to != nil {
+		fmt.Fprintf(&b, ", to: %v", *to)
+	} else {
+		b.WriteString(", to: <create>")
+	}
+	fmt.Fprintf(&b, ", value: %v, gas: %d", tx.inner.value(), tx.Gas())
+
+	if tx.Type() < DynamicFeeTxType {
+		fmt.Fprintf(&b, ", gasPrice: %v", tx.inner.gasPrice())
+	} else {
+		fmt.Fprintf(&b, ", gasTipCap: %v, gasFeeCap: %v", tx.inner.gasTipCap(), tx.inner.gasFeeCap())
+	}
+	if tx.Type() != LegacyTxType {
+		fmt.Fprintf(&b, ", accessList: %d", len(tx.inner.accessList()))
+	}
+	if blobtx, ok := tx.inner.(*BlobTx); ok {
+		fmt.Fprintf(&b, ", blobFeeCap: %v, blobHashes: %d", blobtx.BlobFeeCap, len(blobtx.BlobHashes))
+		if blobtx.Sidecar != nil {
+			fmt.Fprintf(&b, ", blobs: %d", len(blobtx.Sidecar.Blobs))
+		}
+	}
+	if setcodetx, ok := tx.inner.(*SetCodeTx); ok {
+		fmt.Fprintf(&b, ", authList: %d", len(setcodetx.AuthList))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
 // WithSignature returns a new transaction with the given signature.
 // This signature needs to be in the [R || S || V] format where V is 0 or 1.
 func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, error) {
@@ -570,6 +790,14 @@ func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, e
 	return &Transaction{inner: cpy, time: tx.time}, nil
 }
 
+// Unsigned returns a copy of the transaction with the signature values zeroed.
+// All other fields, including the chain ID of typed transactions, are retained.
+func (tx *Transaction) Unsigned() *Transaction {
+	cpy := tx.inner.copy()
+	cpy.setSignatureValues(tx.inner.chainID(), new(big.Int), new(big.Int), new(big.Int))
+	return &Transaction{inner: cpy, time: tx.time}
+}
+
 // Transactions implements DerivableList for transactions.
 type Transactions []*Transaction
 
@@ -633,6 +861,42 @@ func (s TxByNonce) Len() int           { return len(s) }
 func (s TxByNonce) Less(i, j int) bool { return s[i].Nonce() < s[j].Nonce() }
 func (s TxByNonce) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
+// TxByEffectiveTip returns a sort.Interface ordering the given transactions the
+// way the miner selects them for the given base fee: by descending effective tip,
+// then by the time they were first seen, falling back to the hash to keep the
+// order deterministic. Transactions with a fee cap below the base fee, which
+// the miner discards, are ordered last.
+func TxByEffectiveTip(txs Transactions, baseFee *big.Int) sort.Interface {
+	tips := make([]*big.Int, len(txs))
+	for i, tx := range txs {
+		tips[i] = tx.EffectiveGasTipValue(baseFee)
+	}
+	return &txByEffectiveTip{txs: txs, tips: tips}
+}
+
+// txByEffectiveTip implements the sort interface for TxByEffectiveTip, caching
+// the effective tips of the transactions.
+type txByEffectiveTip struct {
+	txs  Transactions
+	tips []*big.Int
+}
+
+func (s *txByEffectiveTip) Len() int { return len(s.txs) }
+func (s *txByEffectiveTip) Less(i, j int) bool {
+	if cmp := s.tips[i].Cmp(s.tips[j]); cmp != 0 {
+		return cmp > 0
+	}
+	if ti, tj := s.txs[i].Time(), s.txs[j].Time(); !ti.Equal(tj) {
+		return ti.Before(tj)
+	}
+	hi, hj := s.txs[i].Hash(), s.txs[j].Hash()
+	return bytes.Compare(hi[:], hj[:]) < 0
+}
+func (s *txByEffectiveTip) Swap(i, j int) {
+	s.txs[i], s.txs[j] = s.txs[j], s.txs[i]
+	s.tips[i], s.tips[j] = s.tips[j], s.tips[i]
+}
+
 // copyAddressPtr copies an address.
 func copyAddressPtr(a *common.Address) *common.Address {
 	if a == nil {
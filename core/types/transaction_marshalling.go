@@ -19,6 +19,7 @@ package types
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -517,3 +518,23 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	// TODO: check hash here?
 	return nil
 }
+
+// UnmarshalTransactionsJSON parses a JSON array of transactions in the RPC object
+// format, reconstructing the signatures from their v, r and s values. Fields of
+// the RPC objects not part of the transactions, such as the block hash or the
+// sender, are ignored.
+func UnmarshalTransactionsJSON(input []byte) (Transactions, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(input, &raws); err != nil {
+		return nil, err
+	}
+	txs := make(Transactions, len(raws))
+	for i, raw := range raws {
+		tx := new(Transaction)
+		if err := tx.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
@@ -27,7 +27,10 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-var ErrInvalidChainId = errors.New("invalid chain id for signer")
+var (
+	ErrInvalidChainId = errors.New("invalid chain id for signer")
+	ErrSignerRequired = errors.New("legacy transaction sender requires a signer")
+)
 
 // sigCache is used to cache the derived sender and contains
 // the signer used to derive it.
@@ -155,6 +158,20 @@ func Sender(signer Signer, tx *Transaction) (common.Address, error) {
 	return addr, nil
 }
 
+// RecoverSender returns the address derived from the signature of a typed
+// transaction, using the signer of the chain ID embedded in the transaction.
+// Legacy transactions don't commit to their chain ID in a way that determines
+// the signer, so ErrSignerRequired is returned for them and Sender must be used
+// instead.
+//
+// The derived address is cached like with Sender.
+func (tx *Transaction) RecoverSender() (common.Address, error) {
+	if tx.Type() == LegacyTxType {
+		return common.Address{}, ErrSignerRequired
+	}
+	return Sender(NewPragueSigner(tx.ChainId()), tx)
+}
+
 // Signer encapsulates transaction signature handling. The name of this type is slightly
 // misleading because Signers don't actually sign, they're just for validating and
 // processing of signatures.
@@ -644,6 +661,16 @@ func recoverPlain(sighash common.Hash, R, S, Vb *big.Int, homestead bool) (commo
 	return addr, nil
 }
 
+// LegacyChainID extracts the chain ID embedded in the V signature value of an
+// EIP-155 replay protected legacy transaction. It returns false if the value is
+// not a protected one, either unprotected (27 or 28) or invalid.
+func LegacyChainID(v *big.Int) (*big.Int, bool) {
+	if v == nil || v.Cmp(big.NewInt(35)) < 0 {
+		return nil, false
+	}
+	return deriveChainId(v), true
+}
+
 // deriveChainId derives the chain id from the given v parameter
 func deriveChainId(v *big.Int) *big.Int {
 	if v.BitLen() <= 64 {
@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 )
 
 func TestEIP155Signing(t *testing.T) {
@@ -79,6 +80,91 @@ func TestEIP155ChainId(t *testing.T) {
 	}
 }
 
+func TestLegacyChainID(t *testing.T) {
+	huge, _ := new(big.Int).SetString("0x1000000000000000000000000000000023", 0)
+	hugeID, _ := new(big.Int).SetString("0x800000000000000000000000000000000", 0)
+
+	for i, test := range []struct {
+		v         *big.Int
+		protected bool
+		chainID   *big.Int
+	}{
+		{v: big.NewInt(27)},
+		{v: big.NewInt(28)},
+		{v: big.NewInt(0)},
+		{v: big.NewInt(1)},
+		{v: big.NewInt(34)},
+		{v: big.NewInt(35), protected: true, chainID: big.NewInt(0)},
+		{v: big.NewInt(37), protected: true, chainID: big.NewInt(1)},
+		{v: big.NewInt(38), protected: true, chainID: big.NewInt(1)},
+		{v: big.NewInt(2*1337 + 36), protected: true, chainID: big.NewInt(1337)},
+		{v: huge, protected: true, chainID: hugeID},
+	} {
+		chainID, ok := LegacyChainID(test.v)
+		if ok != test.protected {
+			t.Errorf("test %d: protection mismatch for v=%v: have %t, want %t", i, test.v, ok, test.protected)
+			continue
+		}
+		if ok && chainID.Cmp(test.chainID) != 0 {
+			t.Errorf("test %d: chain id mismatch for v=%v: have %v, want %v", i, test.v, chainID, test.chainID)
+		}
+	}
+	// The chain ID of signed transactions is the one of the signer
+	key, _ := defaultTestKey()
+	tx, err := SignTx(NewTransaction(0, common.Address{}, new(big.Int), 0, new(big.Int), nil), NewEIP155Signer(big.NewInt(18)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _, _ := tx.RawSignatureValues()
+	if chainID, ok := LegacyChainID(v); !ok || chainID.Cmp(big.NewInt(18)) != 0 {
+		t.Errorf("signed tx chain id mismatch: have %v (protected %t), want 18", chainID, ok)
+	}
+	tx, err = SignTx(NewTransaction(0, common.Address{}, new(big.Int), 0, new(big.Int), nil), HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _, _ = tx.RawSignatureValues()
+	if _, ok := LegacyChainID(v); ok || tx.Protected() {
+		t.Error("unprotected signed tx reported as protected")
+	}
+}
+
+func TestRecoverSender(t *testing.T) {
+	var (
+		key, addr = defaultTestKey()
+		chainID   = big.NewInt(1337)
+		signer    = NewPragueSigner(chainID)
+		to        = common.Address{0x01}
+	)
+	for _, inner := range []TxData{
+		&AccessListTx{ChainID: chainID, To: &to, Gas: 21000, GasPrice: big.NewInt(1)},
+		&DynamicFeeTx{ChainID: chainID, To: &to, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1)},
+		&BlobTx{ChainID: uint256.MustFromBig(chainID), Gas: 21000, BlobHashes: []common.Hash{{0x01}}},
+		&SetCodeTx{ChainID: uint256.MustFromBig(chainID), Gas: 21000, AuthList: []SetCodeAuthorization{{Address: to}}},
+	} {
+		tx, err := SignNewTx(key, signer, inner)
+		if err != nil {
+			t.Fatalf("type %d: failed to sign: %v", inner.txType(), err)
+		}
+		// Recover from a decoded copy to bypass the sender cache
+		enc, _ := tx.MarshalBinary()
+		var dec Transaction
+		if err := dec.UnmarshalBinary(enc); err != nil {
+			t.Fatalf("type %d: failed to decode: %v", inner.txType(), err)
+		}
+		if from, err := dec.RecoverSender(); err != nil || from != addr {
+			t.Errorf("type %d: sender mismatch: have %x (%v), want %x", inner.txType(), from, err, addr)
+		}
+	}
+	tx, err := SignTx(NewTransaction(0, to, new(big.Int), 21000, big.NewInt(1), nil), NewEIP155Signer(chainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.RecoverSender(); !errors.Is(err, ErrSignerRequired) {
+		t.Errorf("legacy tx error mismatch: have %v, want %v", err, ErrSignerRequired)
+	}
+}
+
 func TestEIP155SigningVitalik(t *testing.T) {
 	// Test vectors come from http://vitalik.ca/files/eip155_testvec.txt
 	for i, test := range []struct {
@@ -0,0 +1,107 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxStreamTxSize is the maximum size of a single transaction accepted in a
+// transaction stream, protecting against corrupt length prefixes.
+const maxStreamTxSize = 16 * 1024 * 1024
+
+var errStreamTxTooLarge = errors.New("transaction too large")
+
+// TransactionStream decodes a stream of concatenated transactions, each of them
+// in the EIP-2718 binary encoding prefixed by its length as an uvarint.
+type TransactionStream struct {
+	r      *bufio.Reader
+	offset uint64 // Offset of the next transaction in the stream
+	index  int    // Index of the next transaction in the stream
+	buf    []byte // Scratch buffer reused between transactions
+	err    error  // Sticky error, the stream can't be resumed after a failure
+}
+
+// DecodeTransactionsStream creates a decoder of the stream of length-prefixed
+// transactions read from r. The transactions are decoded one at a time by Next,
+// without buffering the whole stream.
+func DecodeTransactionsStream(r io.Reader) *TransactionStream {
+	return &TransactionStream{r: bufio.NewReader(r)}
+}
+
+// Next decodes the next transaction of the stream. It returns io.EOF if the
+// stream ended cleanly after the last transaction. Errors of malformed entries
+// report the index and offset of the entry in the stream.
+func (s *TransactionStream) Next() (*Transaction, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	tx, size, err := s.next()
+	if err != nil {
+		if err != io.EOF {
+			err = fmt.Errorf("transaction %d at offset %d: %w", s.index, s.offset, err)
+		}
+		s.err = err
+		return nil, err
+	}
+	s.offset += size
+	s.index++
+	return tx, nil
+}
+
+// next reads and decodes the next transaction, returning it along with the number
+// of bytes consumed from the stream.
+func (s *TransactionStream) next() (*Transaction, uint64, error) {
+	// Read the length prefix, an empty stream at this point is a clean end
+	if _, err := s.r.Peek(1); err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	size, err := binary.ReadUvarint(s.r)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	if size == 0 {
+		return nil, 0, errShortTypedTx
+	}
+	if size > maxStreamTxSize {
+		return nil, 0, errStreamTxTooLarge
+	}
+	// Read and decode the transaction itself
+	if uint64(cap(s.buf)) < size {
+		s.buf = make([]byte, size)
+	}
+	s.buf = s.buf[:size]
+	if _, err := io.ReadFull(s.r, s.buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	tx := new(Transaction)
+	if err := tx.UnmarshalBinary(s.buf); err != nil {
+		return nil, 0, err
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	return tx, uint64(binary.PutUvarint(prefix[:], size)) + size, nil
+}
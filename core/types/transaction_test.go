@@ -19,17 +19,25 @@ package types
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"math/big"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 )
 
 // The values in those tests are from the Transaction Tests
@@ -380,6 +388,64 @@ func TestLegacyTransaction_ConsistentV_LargeChainIds(t *testing.T) {
 	}
 }
 
+func TestUnmarshalTransactionsJSON(t *testing.T) {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	var (
+		to     = common.HexToAddress("0x01")
+		list   = AccessList{{Address: to, StorageKeys: []common.Hash{{0x01}}}}
+		signer = NewPragueSigner(big.NewInt(1))
+		auth   = SetCodeAuthorization{ChainID: *uint256.NewInt(1), Address: to, Nonce: 1, V: 1, R: *uint256.NewInt(1), S: *uint256.NewInt(1)}
+		txs    = Transactions{
+			MustSignNewTx(key, HomesteadSigner{}, &LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to}),
+			MustSignNewTx(key, signer, &LegacyTx{Nonce: 2, GasPrice: big.NewInt(1), Gas: 21000}),
+			MustSignNewTx(key, signer, &AccessListTx{ChainID: big.NewInt(1), Gas: 21000, AccessList: list}),
+			MustSignNewTx(key, signer, &DynamicFeeTx{ChainID: big.NewInt(1), GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), To: &to, Data: []byte{0x01}}),
+			MustSignNewTx(key, signer, &BlobTx{ChainID: uint256.NewInt(1), BlobFeeCap: uint256.NewInt(1), BlobHashes: []common.Hash{{0x01}}}),
+			MustSignNewTx(key, signer, &SetCodeTx{ChainID: uint256.NewInt(1), To: to, AuthList: []SetCodeAuthorization{auth}}),
+		}
+	)
+	// Extend the transactions with the extra fields of the RPC objects
+	raws := make([]map[string]interface{}, len(txs))
+	for i, tx := range txs {
+		blob, err := json.Marshal(tx)
+		if err != nil {
+			t.Fatalf("tx %d: failed to marshal: %v", i, err)
+		}
+		if err := json.Unmarshal(blob, &raws[i]); err != nil {
+			t.Fatalf("tx %d: failed to unmarshal: %v", i, err)
+		}
+		raws[i]["blockHash"] = common.Hash{0xff}
+		raws[i]["from"] = crypto.PubkeyToAddress(key.PublicKey)
+		raws[i]["transactionIndex"] = fmt.Sprintf("%#x", i)
+	}
+	input, _ := json.Marshal(raws)
+
+	have, err := UnmarshalTransactionsJSON(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal transactions: %v", err)
+	}
+	if len(have) != len(txs) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(have), len(txs))
+	}
+	for i := range txs {
+		if err := assertEqual(txs[i], have[i]); err != nil {
+			t.Errorf("tx %d: %v", i, err)
+		}
+		if from, err := Sender(signer, have[i]); err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Errorf("tx %d: sender mismatch: have %v, err %v", i, from, err)
+		}
+	}
+	// Failures report the index of the invalid transaction
+	delete(raws[3], "gas")
+	input, _ = json.Marshal(raws)
+	if _, err := UnmarshalTransactionsJSON(input); err == nil || !strings.HasPrefix(err.Error(), "transaction 3:") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := UnmarshalTransactionsJSON([]byte(`{}`)); err == nil {
+		t.Fatal("non-array input accepted")
+	}
+}
+
 func encodeDecodeJSON(tx *Transaction) (*Transaction, error) {
 	data, err := json.Marshal(tx)
 	if err != nil {
@@ -420,6 +486,328 @@ func assertEqual(orig *Transaction, cpy *Transaction) error {
 	return nil
 }
 
+// This test checks that legacy transactions prefixed with an explicit envelope
+// type byte are rejected by the canonical decoder, but accepted by the lenient one.
+func TestTransactionLenientLegacyEnvelope(t *testing.T) {
+	canonical := common.FromHex("f86103018207d094b94f5374fce5edbc8e2a8697c15331677e6ebf0b0a8255441ca098ff921201554726367d2be8c804a7ff89ccf285ebc57dff8ae4c44b9c19ac4aa08887321be575c8095f789dd4c743dfe42c1820f9231f98a962b210e3ac2452a3")
+	prefixed := append([]byte{LegacyTxType}, canonical...)
+
+	// Strict decoding must reject the prefixed encoding
+	var strict Transaction
+	if err := strict.UnmarshalBinary(prefixed); err != ErrTxTypeNotSupported {
+		t.Fatalf("wrong error from strict decoding: have %v, want %v", err, ErrTxTypeNotSupported)
+	}
+	// Lenient decoding must produce the canonical transaction
+	for _, input := range [][]byte{canonical, prefixed} {
+		var tx Transaction
+		if err := tx.UnmarshalBinaryLenient(input); err != nil {
+			t.Fatalf("lenient decoding of %x failed: %v", input, err)
+		}
+		if tx.Type() != LegacyTxType {
+			t.Errorf("wrong transaction type: have %d, want %d", tx.Type(), LegacyTxType)
+		}
+		if tx.Hash() != rightvrsTx.Hash() {
+			t.Errorf("hash mismatch: have %x, want %x", tx.Hash(), rightvrsTx.Hash())
+		}
+		if tx.Size() != uint64(len(canonical)) {
+			t.Errorf("size mismatch: have %d, want %d", tx.Size(), len(canonical))
+		}
+		enc, _ := tx.MarshalBinary()
+		if !bytes.Equal(enc, canonical) {
+			t.Errorf("re-encoding mismatch: have %x, want %x", enc, canonical)
+		}
+	}
+	// Typed transactions are not affected by the lenient mode
+	enc, _ := signedEip2718Tx.MarshalBinary()
+	var typed Transaction
+	if err := typed.UnmarshalBinaryLenient(enc); err != nil {
+		t.Fatalf("lenient decoding of typed transaction failed: %v", err)
+	}
+	if typed.Hash() != signedEip2718Tx.Hash() {
+		t.Errorf("typed hash mismatch: have %x, want %x", typed.Hash(), signedEip2718Tx.Hash())
+	}
+}
+
+// Tests that stripping the signature of a transaction retains all other fields,
+// and that signing the unsigned copy again results in the original transaction.
+func TestTransactionUnsigned(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		signer    = LatestSignerForChainID(big.NewInt(1))
+		recipient = common.HexToAddress("095e7baea6a6c7c4c2dfeb977efac326af552d87")
+		accesses  = AccessList{{Address: recipient, StorageKeys: []common.Hash{{1}}}}
+	)
+	for i, txdata := range []TxData{
+		&LegacyTx{Nonce: 1, To: &recipient, Gas: 21000, GasPrice: big.NewInt(2), Data: []byte("abcdef")},
+		&AccessListTx{ChainID: big.NewInt(1), Nonce: 2, To: &recipient, Gas: 21000, GasPrice: big.NewInt(2), AccessList: accesses},
+		&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 3, To: &recipient, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), AccessList: accesses},
+		&BlobTx{ChainID: uint256.NewInt(1), Nonce: 4, To: recipient, Gas: 21000, GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(2), BlobFeeCap: uint256.NewInt(3), BlobHashes: []common.Hash{{0x01}}},
+		&SetCodeTx{ChainID: uint256.NewInt(1), Nonce: 5, To: recipient, Gas: 21000, GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(2), AuthList: []SetCodeAuthorization{{ChainID: *uint256.NewInt(1), Address: recipient, Nonce: 7}}},
+	} {
+		signed := MustSignNewTx(key, signer, txdata)
+		unsigned := signed.Unsigned()
+
+		if v, r, s := unsigned.RawSignatureValues(); v.Sign() != 0 || r.Sign() != 0 || s.Sign() != 0 {
+			t.Fatalf("test %d: signature not stripped: v %v, r %v, s %v", i, v, r, s)
+		}
+		if _, r, _ := signed.RawSignatureValues(); r.Sign() == 0 {
+			t.Fatalf("test %d: original transaction modified", i)
+		}
+		if unsigned.Type() != signed.Type() || (signed.Type() != LegacyTxType && unsigned.ChainId().Cmp(signed.ChainId()) != 0) {
+			t.Fatalf("test %d: type or chain ID mismatch", i)
+		}
+		if unsigned.Hash() == signed.Hash() {
+			t.Fatalf("test %d: hash not updated", i)
+		}
+		if signer.Hash(unsigned) != signer.Hash(signed) {
+			t.Fatalf("test %d: signing hash mismatch", i)
+		}
+		resigned, err := SignTx(unsigned, signer, key)
+		if err != nil {
+			t.Fatalf("test %d: failed to sign: %v", i, err)
+		}
+		if resigned.Hash() != signed.Hash() {
+			t.Fatalf("test %d: re-signed transaction mismatch: have %x, want %x", i, resigned.Hash(), signed.Hash())
+		}
+	}
+}
+
+func TestTransactionValidateFees(t *testing.T) {
+	tests := []struct {
+		tx   TxData
+		want error
+	}{
+		{&LegacyTx{GasPrice: big.NewInt(1)}, nil},
+		{&AccessListTx{GasPrice: big.NewInt(1)}, nil},
+		{&DynamicFeeTx{GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2)}, nil},
+		{&DynamicFeeTx{GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(2)}, nil},
+		{&DynamicFeeTx{GasTipCap: big.NewInt(3), GasFeeCap: big.NewInt(2)}, ErrTipAboveFeeCap},
+		{&DynamicFeeTx{GasTipCap: big.NewInt(-1), GasFeeCap: big.NewInt(2)}, ErrNegativeFee},
+		{&DynamicFeeTx{GasTipCap: big.NewInt(0), GasFeeCap: big.NewInt(-1)}, ErrNegativeFee},
+		{&BlobTx{GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(2)}, nil},
+		{&BlobTx{GasTipCap: uint256.NewInt(3), GasFeeCap: uint256.NewInt(2)}, ErrTipAboveFeeCap},
+		{&SetCodeTx{GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(2)}, nil},
+		{&SetCodeTx{GasTipCap: uint256.NewInt(3), GasFeeCap: uint256.NewInt(2)}, ErrTipAboveFeeCap},
+	}
+	for i, tt := range tests {
+		if err := NewTx(tt.tx).ValidateFees(); !errors.Is(err, tt.want) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.want)
+		}
+	}
+}
+
+func TestIsContractCreation(t *testing.T) {
+	to := common.HexToAddress("0x01")
+	tests := []struct {
+		tx   TxData
+		want bool
+	}{
+		{&LegacyTx{}, true},
+		{&LegacyTx{To: &to}, false},
+		{&AccessListTx{}, true},
+		{&AccessListTx{To: &to}, false},
+		{&DynamicFeeTx{}, true},
+		{&DynamicFeeTx{To: &to}, false},
+		{&BlobTx{}, false},
+		{&BlobTx{To: to}, false},
+		{&SetCodeTx{}, false},
+		{&SetCodeTx{To: to}, false},
+	}
+	for i, tt := range tests {
+		tx := NewTx(tt.tx)
+		if have := tx.IsContractCreation(); have != tt.want {
+			t.Errorf("test %d (type %d): have %v, want %v", i, tx.Type(), have, tt.want)
+		}
+	}
+}
+
+func TestDiffTransactions(t *testing.T) {
+	to := common.HexToAddress("0x01")
+	base := &DynamicFeeTx{
+		ChainID:    big.NewInt(1),
+		Nonce:      1,
+		GasTipCap:  big.NewInt(2),
+		GasFeeCap:  big.NewInt(3),
+		Gas:        21000,
+		To:         &to,
+		Value:      big.NewInt(4),
+		AccessList: AccessList{{Address: to, StorageKeys: []common.Hash{{0x01}}}},
+		V:          big.NewInt(0),
+		R:          big.NewInt(5),
+		S:          big.NewInt(6),
+	}
+	if diffs := DiffTransactions(NewTx(base), NewTx(base)); diffs != nil {
+		t.Fatalf("equal transactions differ: %v", diffs)
+	}
+	modified := *base
+	modified.Nonce = 2
+	modified.To = nil
+	modified.AccessList = AccessList{{Address: to}}
+	modified.S = big.NewInt(7)
+
+	want := []string{
+		"nonce: 1 != 2",
+		"to: 0x0000000000000000000000000000000000000001 != nil",
+		"accessList[0]: {0x0000000000000000000000000000000000000001 [0x0100000000000000000000000000000000000000000000000000000000000000]} != {0x0000000000000000000000000000000000000001 []}",
+		"s: 6 != 7",
+	}
+	if have := DiffTransactions(NewTx(base), NewTx(&modified)); !reflect.DeepEqual(have, want) {
+		t.Fatalf("diff mismatch:\nhave %q\nwant %q", have, want)
+	}
+	// Type mismatches are reported first
+	legacy := NewTx(&LegacyTx{Nonce: 1, GasPrice: big.NewInt(3), Gas: 21000, To: &to, Value: big.NewInt(4)})
+	if have := DiffTransactions(NewTx(base), legacy); len(have) == 0 || have[0] != "type: 2 != 0" {
+		t.Fatalf("type mismatch not reported first: %q", have)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	legacy, _ := rightvrsTx.MarshalBinary()
+	typed, _ := signedEip2718Tx.MarshalBinary()
+
+	// Legacy transactions whose nonce has a leading zero byte or a non-minimal
+	// single byte encoding, both decoding to 1 if the decoder were lenient.
+	v, r, s := rightvrsTx.RawSignatureValues()
+	noncanonical := func(nonce []byte) []byte {
+		fields := []rlp.RawValue{nonce}
+		for _, field := range []interface{}{rightvrsTx.GasPrice(), rightvrsTx.Gas(), rightvrsTx.To(), rightvrsTx.Value(), rightvrsTx.Data(), v, r, s} {
+			enc, _ := rlp.EncodeToBytes(field)
+			fields = append(fields, enc)
+		}
+		enc, _ := rlp.EncodeToBytes(fields)
+		return enc
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  error
+	}{
+		{"empty", nil, ErrTxTruncated},
+		{"type-only", []byte{DynamicFeeTxType}, ErrTxTruncated},
+		{"legacy-truncated", legacy[:len(legacy)-1], ErrTxTruncated},
+		{"typed-truncated", typed[:len(typed)-1], ErrTxTruncated},
+		{"legacy-trailing", append(common.CopyBytes(legacy), 0x00), ErrTxTrailingBytes},
+		{"typed-trailing", append(common.CopyBytes(typed), 0x00), ErrTxTrailingBytes},
+		{"typed-too-few-fields", []byte{DynamicFeeTxType, 0xc1, 0x01}, ErrTxInvalidField},
+		{"typed-not-list", []byte{DynamicFeeTxType, 0x01}, ErrTxInvalidField},
+		{"legacy-leading-zero-int", noncanonical([]byte{0x82, 0x00, 0x01}), ErrTxNonCanonical},
+		{"legacy-noncanonical-size", noncanonical([]byte{0x81, 0x01}), ErrTxNonCanonical},
+		{"typed-leading-zero-int", []byte{DynamicFeeTxType, 0xc3, 0x82, 0x00, 0x01}, ErrTxNonCanonical},
+		{"unknown-type", []byte{0x7f, 0xc0}, ErrTxTypeNotSupported},
+	}
+	for _, tt := range tests {
+		var tx Transaction
+		if err := tx.UnmarshalBinary(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestTransactionSanityCheck(t *testing.T) {
+	const txMaxSize = 128 * 1024
+
+	var (
+		key, _   = crypto.GenerateKey()
+		blobTx   = createEmptyBlobTx(key, true)
+		blobSize = int(blobTx.Size())
+
+		accessList = AccessList{
+			{Address: common.Address{0x01}, StorageKeys: []common.Hash{{0x01}, {0x02}}},
+			{Address: common.Address{0x02}},
+		}
+		accessGas = 2*params.TxAccessListAddressGas + 2*params.TxAccessListStorageKeyGas
+		authList  = []SetCodeAuthorization{{Address: common.Address{0x01}}, {Address: common.Address{0x02}}}
+		authGas   = 2 * params.CallNewAccountGas
+	)
+	tests := []struct {
+		tx      *Transaction
+		maxSize int
+		want    error
+	}{
+		// Size limit, including the blob sidecar
+		{rightvrsTx, int(rightvrsTx.Size()), nil},
+		{rightvrsTx, int(rightvrsTx.Size()) - 1, ErrOversizedTx},
+		{blobTx, blobSize, nil},
+		{blobTx, blobSize - 1, ErrOversizedTx},
+
+		// Access list bounded by the gas limit
+		{NewTx(&AccessListTx{Gas: accessGas, AccessList: accessList}), txMaxSize, nil},
+		{NewTx(&AccessListTx{Gas: accessGas - 1, AccessList: accessList}), txMaxSize, ErrAccessListTooLarge},
+
+		// Authorization list bounded by the gas limit
+		{NewTx(&SetCodeTx{Gas: authGas, AuthList: authList}), txMaxSize, nil},
+		{NewTx(&SetCodeTx{Gas: authGas - 1, AuthList: authList}), txMaxSize, ErrAuthListTooLarge},
+
+		// Blob count limit
+		{NewTx(&BlobTx{BlobHashes: make([]common.Hash, maxTxBlobs)}), txMaxSize, nil},
+		{NewTx(&BlobTx{BlobHashes: make([]common.Hash, maxTxBlobs+1)}), txMaxSize, ErrTooManyBlobs},
+	}
+	for i, tt := range tests {
+		if err := tt.tx.SanityCheck(tt.maxSize); !errors.Is(err, tt.want) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.want)
+		}
+	}
+}
+
+func TestDecodeTransactionsStream(t *testing.T) {
+	txs := []*Transaction{rightvrsTx, signedEip2718Tx, rightvrsTx}
+
+	var stream []byte
+	for _, tx := range txs {
+		enc, _ := tx.MarshalBinary()
+		stream = binary.AppendUvarint(stream, uint64(len(enc)))
+		stream = append(stream, enc...)
+	}
+	s := DecodeTransactionsStream(bytes.NewReader(stream))
+	for i, want := range txs {
+		tx, err := s.Next()
+		if err != nil {
+			t.Fatalf("transaction %d: decoding failed: %v", i, err)
+		}
+		if tx.Hash() != want.Hash() {
+			t.Fatalf("transaction %d: hash mismatch: have %x, want %x", i, tx.Hash(), want.Hash())
+		}
+	}
+	if _, err := s.Next(); err != io.EOF {
+		t.Fatalf("expected EOF at end of stream, got %v", err)
+	}
+	// Malformed entries are reported with their offset
+	enc, _ := rightvrsTx.MarshalBinary()
+	offset := len(enc) + 1
+
+	tests := []struct {
+		name  string
+		entry []byte
+		want  error
+	}{
+		{"truncated-prefix", []byte{0x80}, io.ErrUnexpectedEOF},
+		{"truncated-payload", append(binary.AppendUvarint(nil, uint64(len(enc))), enc[:len(enc)-1]...), io.ErrUnexpectedEOF},
+		{"empty", []byte{0x00}, errShortTypedTx},
+		{"oversized", binary.AppendUvarint(nil, maxStreamTxSize+1), errStreamTxTooLarge},
+		{"invalid", []byte{0x02, 0x7f, 0x00}, ErrTxTypeNotSupported},
+	}
+	for _, tt := range tests {
+		stream := append(binary.AppendUvarint(nil, uint64(len(enc))), enc...)
+		stream = append(stream, tt.entry...)
+
+		s := DecodeTransactionsStream(bytes.NewReader(stream))
+		if _, err := s.Next(); err != nil {
+			t.Fatalf("%s: first transaction failed: %v", tt.name, err)
+		}
+		_, err := s.Next()
+		if !errors.Is(err, tt.want) {
+			t.Fatalf("%s: error mismatch: have %v, want %v", tt.name, err, tt.want)
+		}
+		if prefix := fmt.Sprintf("transaction 1 at offset %d: ", offset); !strings.HasPrefix(err.Error(), prefix) {
+			t.Fatalf("%s: error %q doesn't report the offset", tt.name, err)
+		}
+		if _, again := s.Next(); again != err {
+			t.Fatalf("%s: error not sticky: %v", tt.name, again)
+		}
+	}
+}
+
 func TestTransactionSizes(t *testing.T) {
 	signer := NewLondonSigner(big.NewInt(123))
 	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
@@ -488,6 +876,91 @@ func TestTransactionSizes(t *testing.T) {
 	}
 }
 
+func TestTransactionEncodedSize(t *testing.T) {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	var (
+		to       = common.HexToAddress("0x01")
+		large    = new(big.Int).Lsh(big.NewInt(1), 255)
+		short    = []byte{0x7f}
+		long     = bytes.Repeat([]byte{0xff}, 70000)
+		list     = AccessList{{Address: to, StorageKeys: []common.Hash{{0x01}, {0x02}}}, {Address: to}}
+		authList = []SetCodeAuthorization{{ChainID: *uint256.NewInt(1), Address: to, Nonce: 1 << 40, V: 1, R: *uint256.NewInt(0x80), S: *uint256.MustFromBig(large)}}
+	)
+	txs := []TxData{
+		&LegacyTx{},
+		&LegacyTx{Nonce: 0x7f, GasPrice: big.NewInt(0x80), Gas: 1 << 63, To: &to, Value: large, Data: short},
+		&LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Data: long},
+		&AccessListTx{ChainID: big.NewInt(1), AccessList: list},
+		&AccessListTx{ChainID: big.NewInt(1), To: &to, Data: long, AccessList: list},
+		&DynamicFeeTx{ChainID: big.NewInt(1)},
+		&DynamicFeeTx{ChainID: big.NewInt(1), GasTipCap: big.NewInt(255), GasFeeCap: large, To: &to, AccessList: list},
+		&SetCodeTx{ChainID: uint256.NewInt(1), AuthList: authList},
+		&SetCodeTx{ChainID: uint256.NewInt(1), To: to, Data: long, AccessList: list, AuthList: authList},
+		createEmptyBlobTxInner(false),
+		createEmptyBlobTxInner(true),
+	}
+	signer := NewPragueSigner(big.NewInt(1))
+	for i, txdata := range txs {
+		for _, signed := range []bool{false, true} {
+			tx := NewTx(txdata)
+			if signed {
+				tx = MustSignNewTx(key, signer, txdata)
+			}
+			bin, err := tx.MarshalBinary()
+			if err != nil {
+				t.Fatalf("test %d: failed to encode: %v", i, err)
+			}
+			if have, want := tx.EncodedSize(), uint64(len(bin)); have != want {
+				t.Errorf("test %d (signed %t): encoded size mismatch: have %d, want %d", i, signed, have, want)
+			}
+			if allocs := testing.AllocsPerRun(10, func() { tx.EncodedSize() }); allocs != 0 {
+				t.Errorf("test %d (signed %t): encoded size allocates %v times", i, signed, allocs)
+			}
+		}
+	}
+}
+
+func TestEncodeTransactionsTo(t *testing.T) {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	var (
+		to     = common.HexToAddress("0x01")
+		signer = NewPragueSigner(big.NewInt(1))
+		legacy = NewTx(&LegacyTx{})
+		txs    = Transactions{
+			MustSignNewTx(key, signer, &LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), To: &to}),
+			MustSignNewTx(key, signer, &AccessListTx{ChainID: big.NewInt(1), Data: bytes.Repeat([]byte{0xff}, 70000)}),
+			MustSignNewTx(key, signer, &DynamicFeeTx{ChainID: big.NewInt(1), To: &to}),
+			MustSignNewTx(key, signer, createEmptyBlobTxInner(true)),
+			MustSignNewTx(key, signer, &SetCodeTx{ChainID: uint256.NewInt(1), To: to}),
+		}
+	)
+	for i, list := range []Transactions{nil, {legacy}, txs[2:3], txs} {
+		want, err := rlp.EncodeToBytes(list)
+		if err != nil {
+			t.Fatalf("test %d: failed to encode list: %v", i, err)
+		}
+		var buf bytes.Buffer
+		if err := EncodeTransactionsTo(&buf, list); err != nil {
+			t.Fatalf("test %d: failed to stream list: %v", i, err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Fatalf("test %d: encoding mismatch: have %x, want %x", i, buf.Bytes(), want)
+		}
+		var decoded Transactions
+		if err := rlp.DecodeBytes(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("test %d: failed to decode list: %v", i, err)
+		}
+		if len(decoded) != len(list) {
+			t.Fatalf("test %d: decoded length mismatch: have %d, want %d", i, len(decoded), len(list))
+		}
+		for j := range list {
+			if decoded[j].Hash() != list[j].Hash() {
+				t.Errorf("test %d: tx %d hash mismatch", i, j)
+			}
+		}
+	}
+}
+
 func TestYParityJSONUnmarshalling(t *testing.T) {
 	baseJson := map[string]interface{}{
 		// type is filled in by the test
@@ -576,3 +1049,225 @@ func TestYParityJSONUnmarshalling(t *testing.T) {
 		}
 	}
 }
+
+func TestTransactionString(t *testing.T) {
+	blobtx := NewTx(&BlobTx{
+		Nonce:      1,
+		To:         testAddr,
+		Value:      uint256.NewInt(0),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(2),
+		BlobFeeCap: uint256.NewInt(3),
+		BlobHashes: []common.Hash{{0x01}, {0x02}},
+		Sidecar:    &BlobTxSidecar{Blobs: make([]kzg4844.Blob, 2)},
+	})
+	setcodetx := NewTx(&SetCodeTx{
+		Nonce:      2,
+		Value:      uint256.NewInt(0),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(2),
+		AccessList: AccessList{{Address: testAddr}},
+		AuthList:   make([]SetCodeAuthorization, 3),
+	})
+	tests := []struct {
+		tx   *Transaction
+		want []string
+	}{
+		{new(Transaction), []string{"Transaction{}"}},
+		{emptyTx, []string{"LegacyTx{", "nonce: 0", "gasPrice: 0", "to: " + emptyTx.To().Hex()}},
+		{emptyEip2718Tx, []string{"AccessListTx{", "nonce: 3", "value: 10", "gas: 25000", "accessList: 0"}},
+		{blobtx, []string{"BlobTx{", "gasTipCap: 1, gasFeeCap: 2", "blobFeeCap: 3, blobHashes: 2, blobs: 2"}},
+		{setcodetx, []string{"SetCodeTx{", "accessList: 1", "authList: 3"}},
+	}
+	for i, tt := range tests {
+		have := tt.tx.String()
+		for _, want := range tt.want {
+			if !strings.Contains(have, want) {
+				t.Errorf("test %d: %q missing from %q", i, want, have)
+			}
+		}
+	}
+	// The sender is only rendered once derived
+	key, _ := crypto.GenerateKey()
+	tx := MustSignNewTx(key, HomesteadSigner{}, &LegacyTx{To: &testAddr, GasPrice: big.NewInt(1)})
+	if have := tx.String(); strings.Contains(have, "from:") || !strings.Contains(have, "to: "+testAddr.Hex()) {
+		t.Errorf("wrong rendering before sender derivation: %q", have)
+	}
+	from, err := Sender(HomesteadSigner{}, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := tx.String(); !strings.Contains(have, "from: "+from.Hex()) {
+		t.Errorf("sender missing from %q", have)
+	}
+}
+
+func TestTxByEffectiveTip(t *testing.T) {
+	var (
+		baseFee = big.NewInt(10)
+		now     = time.Now()
+	)
+	newTx := func(nonce uint64, feeCap, tipCap int64, seen time.Duration) *Transaction {
+		tx := NewTx(&DynamicFeeTx{Nonce: nonce, GasFeeCap: big.NewInt(feeCap), GasTipCap: big.NewInt(tipCap)})
+		tx.time = now.Add(seen)
+		return tx
+	}
+	var (
+		capped    = newTx(0, 15, 10, 0)           // tip capped to 5 by the fee cap
+		high      = newTx(1, 30, 8, 0)            // tip of 8
+		early     = newTx(2, 20, 5, -time.Second) // tip of 5, seen first
+		legacy    = NewTx(&LegacyTx{Nonce: 3, GasPrice: big.NewInt(15)})
+		underpaid = newTx(4, 9, 5, -time.Hour) // fee cap below base fee
+	)
+	legacy.time = now
+
+	txs := Transactions{underpaid, capped, legacy, early, high}
+	sort.Sort(TxByEffectiveTip(txs, baseFee))
+
+	// The legacy and capped transactions tie on both tip and time
+	want := []*Transaction{high, early, capped, legacy}
+	if h1, h2 := capped.Hash(), legacy.Hash(); bytes.Compare(h1[:], h2[:]) > 0 {
+		want = []*Transaction{high, early, legacy, capped}
+	}
+	want = append(want, underpaid)
+	for i := range want {
+		if txs[i] != want[i] {
+			t.Fatalf("position %d: have nonce %d, want nonce %d", i, txs[i].Nonce(), want[i].Nonce())
+		}
+	}
+	// Without a base fee, the tip caps are compared
+	sort.Sort(TxByEffectiveTip(txs, nil))
+	if txs[0] != legacy || txs[1] != capped {
+		t.Fatalf("order without base fee mismatch: have nonces %d, %d, want 3, 0", txs[0].Nonce(), txs[1].Nonce())
+	}
+}
+
+func TestReferencedAddresses(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		from      = crypto.PubkeyToAddress(key.PublicKey)
+		other, _  = crypto.GenerateKey()
+		authority = crypto.PubkeyToAddress(other.PublicKey)
+		to        = common.Address{0x01}
+		listed    = common.Address{0x02}
+		signer    = NewPragueSigner(big.NewInt(1))
+	)
+	auths := make([]SetCodeAuthorization, 0, 2)
+	for _, k := range []*ecdsa.PrivateKey{key, other} {
+		auth, err := SignSetCode(k, SetCodeAuthorization{Address: common.Address{0x42}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		auths = append(auths, auth)
+	}
+	tx := MustSignNewTx(key, signer, &SetCodeTx{
+		ChainID: uint256.NewInt(1),
+		To:      to,
+		AccessList: AccessList{
+			{Address: listed, StorageKeys: []common.Hash{{0x01}}},
+			{Address: to},
+		},
+		AuthList: auths,
+	})
+	want := []common.Address{from, to, listed, authority}
+
+	addrs, err := tx.ReferencedAddresses(signer)
+	if err != nil {
+		t.Fatalf("failed to gather addresses: %v", err)
+	}
+	if !reflect.DeepEqual(addrs, want) {
+		t.Fatalf("addresses mismatch: have %v, want %v", addrs, want)
+	}
+	// A failed sender recovery still reports the static addresses, the sender
+	// being among them as an authority
+	addrs, err = tx.ReferencedAddresses(NewPragueSigner(big.NewInt(2)))
+	if !errors.Is(err, ErrInvalidChainId) {
+		t.Fatalf("unexpected error: have %v, want %v", err, ErrInvalidChainId)
+	}
+	if want := []common.Address{to, listed, from, authority}; !reflect.DeepEqual(addrs, want) {
+		t.Fatalf("addresses mismatch: have %v, want %v", addrs, want)
+	}
+	// Contract creations don't reference a recipient
+	tx = MustSignNewTx(key, signer, &LegacyTx{GasPrice: big.NewInt(1)})
+	if addrs, err = tx.ReferencedAddresses(signer); err != nil || !reflect.DeepEqual(addrs, []common.Address{from}) {
+		t.Fatalf("creation addresses mismatch: have %v, want %v, err %v", addrs, []common.Address{from}, err)
+	}
+}
+
+func TestContractAddress(t *testing.T) {
+	sender := common.HexToAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	for _, tt := range []struct {
+		nonce uint64
+		want  common.Address
+	}{
+		{0, common.HexToAddress("0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d")},
+		{1, common.HexToAddress("0x343c43a37d37dff08ae8c4a11544c718abb4fcf8")},
+		{2, common.HexToAddress("0xf778b86fa74e846c4f0a1fbd1335fe81c00a0c91")},
+		{3, common.HexToAddress("0xfffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c")},
+	} {
+		for _, tx := range []*Transaction{
+			NewTx(&LegacyTx{Nonce: tt.nonce}),
+			NewTx(&DynamicFeeTx{Nonce: tt.nonce}),
+		} {
+			have, err := tx.ContractAddress(sender)
+			if err != nil {
+				t.Fatalf("type %d, nonce %d: failed to derive contract address: %v", tx.Type(), tt.nonce, err)
+			}
+			if have != tt.want {
+				t.Errorf("type %d, nonce %d: contract address mismatch: have %v, want %v", tx.Type(), tt.nonce, have, tt.want)
+			}
+		}
+	}
+	// Transactions with a recipient don't create contracts
+	for _, tx := range []*Transaction{
+		NewTx(&LegacyTx{To: &sender}),
+		NewTx(&BlobTx{}),
+		NewTx(&SetCodeTx{}),
+	} {
+		if _, err := tx.ContractAddress(sender); !errors.Is(err, ErrNotContractCreation) {
+			t.Errorf("type %d: error mismatch: have %v, want %v", tx.Type(), err, ErrNotContractCreation)
+		}
+	}
+}
+
+func TestMeetsFeeFloor(t *testing.T) {
+	var (
+		baseFee = big.NewInt(100)
+		minTip  = big.NewInt(10)
+
+		legacy = func(price int64) *Transaction {
+			return NewTx(&LegacyTx{GasPrice: big.NewInt(price)})
+		}
+		dynamic = func(tip, feeCap int64) *Transaction {
+			return NewTx(&DynamicFeeTx{GasTipCap: big.NewInt(tip), GasFeeCap: big.NewInt(feeCap)})
+		}
+		blob = func(tip, feeCap uint64) *Transaction {
+			return NewTx(&BlobTx{GasTipCap: uint256.NewInt(tip), GasFeeCap: uint256.NewInt(feeCap)})
+		}
+	)
+	tests := []struct {
+		name    string
+		tx      *Transaction
+		baseFee *big.Int
+		minTip  *big.Int
+		want    bool
+	}{
+		{"legacy-below-basefee", legacy(99), baseFee, minTip, false},
+		{"legacy-below-tip", legacy(109), baseFee, minTip, false},
+		{"legacy-exact", legacy(110), baseFee, minTip, true},
+		{"legacy-pre-london", legacy(10), nil, minTip, true},
+		{"legacy-pre-london-below-tip", legacy(9), nil, minTip, false},
+		{"dynamic-cap-below-basefee", dynamic(50, 99), baseFee, minTip, false},
+		{"dynamic-cap-below-floor", dynamic(50, 105), baseFee, minTip, false},
+		{"dynamic-tip-below-min", dynamic(9, 1000), baseFee, minTip, false},
+		{"dynamic-exact", dynamic(10, 110), baseFee, minTip, true},
+		{"dynamic-no-min-tip", dynamic(0, 100), baseFee, nil, true},
+		{"blob-cap-below-floor", blob(50, 105), baseFee, minTip, false},
+		{"blob-above-floor", blob(50, 200), baseFee, minTip, true},
+	}
+	for _, tt := range tests {
+		if have := tt.tx.MeetsFeeFloor(tt.baseFee, tt.minTip); have != tt.want {
+			t.Errorf("%s: fee floor mismatch: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
@@ -44,6 +44,37 @@ func (al AccessList) StorageKeys() int {
 	return sum
 }
 
+// Merge returns the union of the two access lists, without duplicate addresses
+// nor duplicate storage keys. Addresses and storage keys are ordered by their
+// first appearance, in the receiver first and in the other list second. Neither
+// of the input lists is modified.
+func (al AccessList) Merge(other AccessList) AccessList {
+	var (
+		merged AccessList
+		index  = make(map[common.Address]int)
+		slots  = make(map[common.Address]map[common.Hash]struct{})
+	)
+	for _, list := range []AccessList{al, other} {
+		for _, tuple := range list {
+			i, ok := index[tuple.Address]
+			if !ok {
+				i = len(merged)
+				index[tuple.Address] = i
+				slots[tuple.Address] = make(map[common.Hash]struct{})
+				merged = append(merged, AccessTuple{Address: tuple.Address, StorageKeys: []common.Hash{}})
+			}
+			for _, key := range tuple.StorageKeys {
+				if _, ok := slots[tuple.Address][key]; ok {
+					continue
+				}
+				slots[tuple.Address][key] = struct{}{}
+				merged[i].StorageKeys = append(merged[i].StorageKeys, key)
+			}
+		}
+	}
+	return merged
+}
+
 // AccessListTx is the data of EIP-2930 access list transactions.
 type AccessListTx struct {
 	ChainID    *big.Int        // destination chain ID
@@ -0,0 +1,91 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestAccessListMerge(t *testing.T) {
+	var (
+		addr1 = common.HexToAddress("0x01")
+		addr2 = common.HexToAddress("0x02")
+		addr3 = common.HexToAddress("0x03")
+		slot1 = common.HexToHash("0x01")
+		slot2 = common.HexToHash("0x02")
+		slot3 = common.HexToHash("0x03")
+	)
+	tests := []struct {
+		a, b AccessList
+		want AccessList
+	}{
+		{nil, nil, nil},
+		{
+			AccessList{{Address: addr1, StorageKeys: []common.Hash{slot1}}},
+			nil,
+			AccessList{{Address: addr1, StorageKeys: []common.Hash{slot1}}},
+		},
+		{
+			nil,
+			AccessList{{Address: addr1}},
+			AccessList{{Address: addr1, StorageKeys: []common.Hash{}}},
+		},
+		// Shared addresses with different slots
+		{
+			AccessList{
+				{Address: addr1, StorageKeys: []common.Hash{slot1, slot2}},
+				{Address: addr2, StorageKeys: []common.Hash{slot1}},
+			},
+			AccessList{
+				{Address: addr3, StorageKeys: []common.Hash{slot3}},
+				{Address: addr2, StorageKeys: []common.Hash{slot2, slot1}},
+				{Address: addr1, StorageKeys: []common.Hash{slot3, slot1}},
+			},
+			AccessList{
+				{Address: addr1, StorageKeys: []common.Hash{slot1, slot2, slot3}},
+				{Address: addr2, StorageKeys: []common.Hash{slot1, slot2}},
+				{Address: addr3, StorageKeys: []common.Hash{slot3}},
+			},
+		},
+		// Duplicates within a single list
+		{
+			AccessList{
+				{Address: addr1, StorageKeys: []common.Hash{slot1, slot1}},
+				{Address: addr1, StorageKeys: []common.Hash{slot2}},
+			},
+			AccessList{{Address: addr1, StorageKeys: []common.Hash{slot2}}},
+			AccessList{{Address: addr1, StorageKeys: []common.Hash{slot1, slot2}}},
+		},
+	}
+	for i, tt := range tests {
+		if have := tt.a.Merge(tt.b); !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: merged list mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+	// Ensure the merged list doesn't alias the inputs
+	var (
+		a      = AccessList{{Address: addr1, StorageKeys: []common.Hash{slot1}}}
+		merged = a.Merge(AccessList{{Address: addr1, StorageKeys: []common.Hash{slot2}}})
+	)
+	merged[0].StorageKeys[0] = slot3
+	if a[0].StorageKeys[0] != slot1 {
+		t.Errorf("input list modified through merged list")
+	}
+}
@@ -19,6 +19,8 @@ package types
 import (
 	"bytes"
 	"crypto/sha256"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +30,11 @@ import (
 	"github.com/holiman/uint256"
 )
 
+var (
+	ErrBlobSidecarLength = errors.New("blob sidecar length mismatch")
+	ErrBlobHashMismatch  = errors.New("blob versioned hash mismatch")
+)
+
 // BlobTx represents an EIP-4844 transaction.
 type BlobTx struct {
 	ChainID    *uint256.Int
@@ -69,6 +76,44 @@ func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
 	return h
 }
 
+// ValidateAgainst checks that the sidecar matches the versioned hashes of a
+// transaction: it must carry a blob, commitment and proof for each of them, with
+// the commitments hashing to them. The blobs are not verified against the proofs.
+func (sc *BlobTxSidecar) ValidateAgainst(hashes []common.Hash) error {
+	if len(sc.Blobs) != len(hashes) || len(sc.Commitments) != len(hashes) || len(sc.Proofs) != len(hashes) {
+		return fmt.Errorf("%w: %d blobs, %d commitments and %d proofs for %d hashes", ErrBlobSidecarLength, len(sc.Blobs), len(sc.Commitments), len(sc.Proofs), len(hashes))
+	}
+	hasher := sha256.New()
+	for i, vhash := range hashes {
+		if computed := kzg4844.CalcBlobHashV1(hasher, &sc.Commitments[i]); vhash != computed {
+			return fmt.Errorf("%w: blob %d: computed hash %#x, transaction hash %#x", ErrBlobHashMismatch, i, computed, vhash)
+		}
+	}
+	return nil
+}
+
+// Split divides the sidecar into single-blob sidecars, each one carrying a blob
+// with its commitment and proof, e.g. to verify or sample the blobs separately.
+// The returned sidecars share the data of the original one.
+//
+// Sidecars only have the per-blob proof layout of EIP-4844. The cell proofs of
+// EIP-7594 are not supported by the KZG backends, so there are no accessors for
+// cells and proofs are always per blob.
+func (sc *BlobTxSidecar) Split() ([]*BlobTxSidecar, error) {
+	if len(sc.Commitments) != len(sc.Blobs) || len(sc.Proofs) != len(sc.Blobs) {
+		return nil, fmt.Errorf("%w: %d blobs, %d commitments and %d proofs", ErrBlobSidecarLength, len(sc.Blobs), len(sc.Commitments), len(sc.Proofs))
+	}
+	split := make([]*BlobTxSidecar, len(sc.Blobs))
+	for i := range sc.Blobs {
+		split[i] = &BlobTxSidecar{
+			Blobs:       sc.Blobs[i : i+1 : i+1],
+			Commitments: sc.Commitments[i : i+1 : i+1],
+			Proofs:      sc.Proofs[i : i+1 : i+1],
+		}
+	}
+	return split, nil
+}
+
 // encodedSize computes the RLP size of the sidecar elements. This does NOT return the
 // encoded size of the BlobTxSidecar, it's just a helper for tx.Size().
 func (sc *BlobTxSidecar) encodedSize() uint64 {
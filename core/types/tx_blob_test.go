@@ -18,6 +18,7 @@ package types
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -74,6 +75,57 @@ func TestBlobTxSize(t *testing.T) {
 	}
 }
 
+func TestBlobTxSidecarValidateAgainst(t *testing.T) {
+	sidecar := createEmptyBlobTxInner(true).Sidecar
+	hashes := sidecar.BlobHashes()
+	if err := sidecar.ValidateAgainst(hashes); err != nil {
+		t.Fatalf("valid sidecar rejected: %v", err)
+	}
+	if err := sidecar.ValidateAgainst(nil); !errors.Is(err, ErrBlobSidecarLength) {
+		t.Fatalf("missing hashes: have %v, want %v", err, ErrBlobSidecarLength)
+	}
+	if err := sidecar.ValidateAgainst(append(hashes, hashes[0])); !errors.Is(err, ErrBlobSidecarLength) {
+		t.Fatalf("extra hash: have %v, want %v", err, ErrBlobSidecarLength)
+	}
+	if err := sidecar.ValidateAgainst([]common.Hash{{0x01}}); !errors.Is(err, ErrBlobHashMismatch) {
+		t.Fatalf("wrong hash: have %v, want %v", err, ErrBlobHashMismatch)
+	}
+}
+
+func TestBlobTxSidecarSplit(t *testing.T) {
+	blob := new(kzg4844.Blob)
+	blob[31] = 0x01
+	commit, _ := kzg4844.BlobToCommitment(blob)
+	proof, _ := kzg4844.ComputeBlobProof(blob, commit)
+
+	sidecar := &BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{*emptyBlob, *blob},
+		Commitments: []kzg4844.Commitment{emptyBlobCommit, commit},
+		Proofs:      []kzg4844.Proof{emptyBlobProof, proof},
+	}
+	hashes := sidecar.BlobHashes()
+
+	split, err := sidecar.Split()
+	if err != nil {
+		t.Fatalf("failed to split sidecar: %v", err)
+	}
+	if len(split) != len(hashes) {
+		t.Fatalf("split sidecar count mismatch: have %d, want %d", len(split), len(hashes))
+	}
+	for i, sc := range split {
+		if err := sc.ValidateAgainst(hashes[i : i+1]); err != nil {
+			t.Errorf("sidecar %d: invalid against its blob hash: %v", i, err)
+		}
+		if err := kzg4844.VerifyBlobProof(&sc.Blobs[0], sc.Commitments[0], sc.Proofs[0]); err != nil {
+			t.Errorf("sidecar %d: invalid blob proof: %v", i, err)
+		}
+	}
+	sidecar.Proofs = sidecar.Proofs[:1]
+	if _, err := sidecar.Split(); !errors.Is(err, ErrBlobSidecarLength) {
+		t.Fatalf("missing proof: have %v, want %v", err, ErrBlobSidecarLength)
+	}
+}
+
 var (
 	emptyBlob          = new(kzg4844.Blob)
 	emptyBlobCommit, _ = kzg4844.BlobToCommitment(emptyBlob)
@@ -0,0 +1,140 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DiffTransactions compares two transactions field by field and returns a
+// human-readable description of each differing field, in the form
+// "field: a != b". It returns nil if the transactions are equal.
+//
+// Transactions of different types are reported as such first, the remaining
+// fields being compared through the accessors common to all types.
+func DiffTransactions(a, b *Transaction) []string {
+	var diffs []string
+	report := func(field string, x, y interface{}) {
+		diffs = append(diffs, fmt.Sprintf("%s: %v != %v", field, x, y))
+	}
+	if a.Type() != b.Type() {
+		report("type", a.Type(), b.Type())
+	}
+	if !bigEqual(a.ChainId(), b.ChainId()) {
+		report("chainId", a.ChainId(), b.ChainId())
+	}
+	if a.Nonce() != b.Nonce() {
+		report("nonce", a.Nonce(), b.Nonce())
+	}
+	if !bigEqual(a.GasPrice(), b.GasPrice()) {
+		report("gasPrice", a.GasPrice(), b.GasPrice())
+	}
+	if !bigEqual(a.GasTipCap(), b.GasTipCap()) {
+		report("gasTipCap", a.GasTipCap(), b.GasTipCap())
+	}
+	if !bigEqual(a.GasFeeCap(), b.GasFeeCap()) {
+		report("gasFeeCap", a.GasFeeCap(), b.GasFeeCap())
+	}
+	if a.Gas() != b.Gas() {
+		report("gas", a.Gas(), b.Gas())
+	}
+	if x, y := a.To(), b.To(); (x == nil) != (y == nil) || (x != nil && *x != *y) {
+		report("to", formatAddress(x), formatAddress(y))
+	}
+	if !bigEqual(a.Value(), b.Value()) {
+		report("value", a.Value(), b.Value())
+	}
+	if !bytes.Equal(a.Data(), b.Data()) {
+		report("data", fmt.Sprintf("%#x", a.Data()), fmt.Sprintf("%#x", b.Data()))
+	}
+	if x, y := normalizeAccessList(a.AccessList()), normalizeAccessList(b.AccessList()); len(x) != len(y) {
+		report("accessList", fmt.Sprintf("%d entries", len(x)), fmt.Sprintf("%d entries", len(y)))
+	} else {
+		for i := range x {
+			if !reflect.DeepEqual(x[i], y[i]) {
+				report(fmt.Sprintf("accessList[%d]", i), x[i], y[i])
+			}
+		}
+	}
+	if !bigEqual(a.BlobGasFeeCap(), b.BlobGasFeeCap()) {
+		report("blobGasFeeCap", a.BlobGasFeeCap(), b.BlobGasFeeCap())
+	}
+	if !slices.Equal(a.BlobHashes(), b.BlobHashes()) {
+		report("blobHashes", a.BlobHashes(), b.BlobHashes())
+	}
+	if x, y := a.SetCodeAuthorizations(), b.SetCodeAuthorizations(); len(x) != len(y) {
+		report("authorizationList", fmt.Sprintf("%d entries", len(x)), fmt.Sprintf("%d entries", len(y)))
+	} else {
+		for i := range x {
+			if x[i] != y[i] {
+				report(fmt.Sprintf("authorizationList[%d]", i), x[i], y[i])
+			}
+		}
+	}
+	av, ar, as := a.RawSignatureValues()
+	bv, br, bs := b.RawSignatureValues()
+	if !bigEqual(av, bv) {
+		report("v", av, bv)
+	}
+	if !bigEqual(ar, br) {
+		report("r", ar, br)
+	}
+	if !bigEqual(as, bs) {
+		report("s", as, bs)
+	}
+	return diffs
+}
+
+// bigEqual reports whether two big integers are equal, treating nil as zero.
+func bigEqual(a, b *big.Int) bool {
+	if a == nil {
+		a = new(big.Int)
+	}
+	if b == nil {
+		b = new(big.Int)
+	}
+	return a.Cmp(b) == 0
+}
+
+func formatAddress(addr *common.Address) string {
+	if addr == nil {
+		return "nil"
+	}
+	return addr.Hex()
+}
+
+// normalizeAccessList returns nil for empty access lists, which are encoded
+// identically to missing ones.
+func normalizeAccessList(list AccessList) AccessList {
+	if len(list) == 0 {
+		return nil
+	}
+	norm := make(AccessList, len(list))
+	for i, tuple := range list {
+		norm[i] = AccessTuple{Address: tuple.Address}
+		if len(tuple.StorageKeys) > 0 {
+			norm[i].StorageKeys = tuple.StorageKeys
+		}
+	}
+	return norm
+}
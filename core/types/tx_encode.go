@@ -0,0 +1,70 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// EncodeTransactionsTo writes the RLP list encoding of the transactions to w,
+// identical to rlp.Encode(w, txs), but streaming the transactions one by one
+// instead of buffering the encoding of the whole list in memory. Typed
+// transactions are wrapped in their envelope as list elements.
+func EncodeTransactionsTo(w io.Writer, txs Transactions) error {
+	var size uint64
+	for _, tx := range txs {
+		size += tx.elemSize()
+	}
+	if _, err := w.Write(listHeader(size)); err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		if err := tx.EncodeRLP(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// elemSize returns the size of the transaction encoded as an RLP list element,
+// which wraps typed transactions into a byte string.
+func (tx *Transaction) elemSize() uint64 {
+	size := tx.EncodedSize()
+	if tx.Type() == LegacyTxType {
+		return size
+	}
+	// Typed transactions are at least two bytes, always prefixed by a header
+	// of the same size as a list header
+	return rlp.ListSize(size)
+}
+
+// listHeader returns the RLP header of a list with the given content size.
+func listHeader(size uint64) []byte {
+	if size < 56 {
+		return []byte{0xC0 + byte(size)}
+	}
+	n := int(rlp.ListSize(size)-size) - 1 // length of the big endian content size
+	head := make([]byte, 1+n)
+	head[0] = 0xF7 + byte(n)
+	for i := n; i > 0; i-- {
+		head[i] = byte(size)
+		size >>= 8
+	}
+	return head
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"math"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrGasUintOverflow is returned when the intrinsic gas overflows uint64.
+var ErrGasUintOverflow = errors.New("gas uint64 overflow")
+
+// IntrinsicGas computes the 'intrinsic gas' charged before executing a message
+// with the given data, access list and authorization list under the given rules.
+func IntrinsicGas(data []byte, accessList AccessList, authList []SetCodeAuthorization, isContractCreation bool, rules params.Rules) (uint64, error) {
+	// Set the starting gas for the raw transaction
+	var gas uint64
+	if isContractCreation && rules.IsHomestead {
+		gas = params.TxGasContractCreation
+	} else {
+		gas = params.TxGas
+	}
+	dataLen := uint64(len(data))
+	// Bump the required gas by the amount of transactional data
+	if dataLen > 0 {
+		// Zero and non-zero bytes are priced differently
+		z := uint64(bytes.Count(data, []byte{0}))
+		nz := dataLen - z
+
+		// Make sure we don't exceed uint64 for all data combinations
+		nonZeroGas := params.TxDataNonZeroGasFrontier
+		if rules.IsIstanbul {
+			nonZeroGas = params.TxDataNonZeroGasEIP2028
+		}
+		if (math.MaxUint64-gas)/nonZeroGas < nz {
+			return 0, ErrGasUintOverflow
+		}
+		gas += nz * nonZeroGas
+
+		if (math.MaxUint64-gas)/params.TxDataZeroGas < z {
+			return 0, ErrGasUintOverflow
+		}
+		gas += z * params.TxDataZeroGas
+
+		if isContractCreation && rules.IsShanghai {
+			lenWords := toWordSize(dataLen)
+			if (math.MaxUint64-gas)/params.InitCodeWordGas < lenWords {
+				return 0, ErrGasUintOverflow
+			}
+			gas += lenWords * params.InitCodeWordGas
+		}
+	}
+	gas += accessList.IntrinsicGas(rules)
+	if authList != nil {
+		gas += uint64(len(authList)) * params.CallNewAccountGas
+	}
+	return gas, nil
+}
+
+// IntrinsicGas returns the portion of the intrinsic gas charged for the access
+// list under the given rules. Access lists are priced since Berlin (EIP-2930).
+func (al AccessList) IntrinsicGas(rules params.Rules) uint64 {
+	if !rules.IsBerlin {
+		return 0
+	}
+	return uint64(len(al))*params.TxAccessListAddressGas + uint64(al.StorageKeys())*params.TxAccessListStorageKeyGas
+}
+
+// IntrinsicGas computes the 'intrinsic gas' of the transaction under the given
+// rules.
+func (tx *Transaction) IntrinsicGas(rules params.Rules) (uint64, error) {
+	return IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.IsContractCreation(), rules)
+}
+
+// toWordSize returns the ceiled word size required for init code payment calculation.
+func toWordSize(size uint64) uint64 {
+	if size > math.MaxUint64-31 {
+		return math.MaxUint64/32 + 1
+	}
+	return (size + 31) / 32
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestIntrinsicGas(t *testing.T) {
+	var (
+		to       = common.Address{0x01}
+		frontier = params.Rules{}
+		istanbul = params.Rules{IsHomestead: true, IsIstanbul: true}
+		berlin   = params.Rules{IsHomestead: true, IsIstanbul: true, IsBerlin: true}
+		shanghai = params.Rules{IsHomestead: true, IsIstanbul: true, IsBerlin: true, IsShanghai: true}
+	)
+	tests := []struct {
+		name  string
+		tx    TxData
+		rules params.Rules
+		want  uint64
+	}{
+		{"transfer", &LegacyTx{To: &to}, frontier, params.TxGas},
+		{"data-frontier", &LegacyTx{To: &to, Data: []byte{0x00, 0x01}}, frontier, params.TxGas + 4 + 68},
+		{"data-istanbul", &LegacyTx{To: &to, Data: []byte{0x00, 0x01}}, istanbul, params.TxGas + 4 + 16},
+		{"create-frontier", &LegacyTx{Data: []byte{0x01}}, frontier, params.TxGas + 68},
+		{"create-istanbul", &LegacyTx{Data: []byte{0x01}}, istanbul, params.TxGasContractCreation + 16},
+		{"create-shanghai", &LegacyTx{Data: []byte{0x01}}, shanghai, params.TxGasContractCreation + 16 + params.InitCodeWordGas},
+		{
+			"access-list",
+			&AccessListTx{ChainID: big.NewInt(1), To: &to, AccessList: AccessList{{Address: to, StorageKeys: []common.Hash{{0x01}, {0x02}}}}},
+			berlin,
+			params.TxGas + params.TxAccessListAddressGas + 2*params.TxAccessListStorageKeyGas,
+		},
+		{
+			"authorizations",
+			&SetCodeTx{ChainID: uint256.NewInt(1), To: to, AuthList: []SetCodeAuthorization{{Address: to}, {Address: to}}},
+			shanghai,
+			params.TxGas + 2*params.CallNewAccountGas,
+		},
+	}
+	for _, tt := range tests {
+		tx := NewTx(tt.tx)
+		have, err := tx.IntrinsicGas(tt.rules)
+		if err != nil || have != tt.want {
+			t.Errorf("%s: transaction intrinsic gas mismatch: have %d (%v), want %d", tt.name, have, err, tt.want)
+		}
+		// The raw message fields yield the same gas as the transaction
+		have, err = IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, tt.rules)
+		if err != nil || have != tt.want {
+			t.Errorf("%s: message intrinsic gas mismatch: have %d (%v), want %d", tt.name, have, err, tt.want)
+		}
+	}
+}
+
+func TestAccessListIntrinsicGas(t *testing.T) {
+	al := AccessList{
+		{Address: common.Address{0x01}, StorageKeys: []common.Hash{{0x01}, {0x02}}},
+		{Address: common.Address{0x02}},
+	}
+	want := 2*params.TxAccessListAddressGas + 2*params.TxAccessListStorageKeyGas
+	for _, config := range []*params.ChainConfig{params.MainnetChainConfig, params.MergedTestChainConfig} {
+		for _, number := range []int64{0, 12_243_999, 12_244_000} {
+			rules := config.Rules(big.NewInt(number), false, 0)
+			have := al.IntrinsicGas(rules)
+			if !rules.IsBerlin {
+				if have != 0 {
+					t.Errorf("block %d: access list priced before Berlin: %d", number, have)
+				}
+				continue
+			}
+			if have != want {
+				t.Errorf("block %d: access list gas mismatch: have %d, want %d", number, have, want)
+			}
+			// The access list portion is included in the intrinsic gas
+			total, err := IntrinsicGas(nil, al, nil, false, rules)
+			if err != nil || total != params.TxGas+want {
+				t.Errorf("block %d: intrinsic gas mismatch: have %d (%v), want %d", number, total, err, params.TxGas+want)
+			}
+		}
+	}
+	if have := AccessList(nil).IntrinsicGas(params.Rules{IsBerlin: true}); have != 0 {
+		t.Errorf("empty access list gas mismatch: have %d, want 0", have)
+	}
+}
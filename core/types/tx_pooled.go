@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrUnannouncedTx         = errors.New("unannounced transaction")
+	ErrDuplicatePooledTx     = errors.New("duplicate transaction")
+	ErrAnnouncedTypeMismatch = errors.New("announced transaction type mismatch")
+	ErrAnnouncedSizeMismatch = errors.New("announced transaction size mismatch")
+	ErrPooledTxTooLarge      = errors.New("pooled transaction too large")
+	errNilPooledTx           = errors.New("nil pooled transaction")
+)
+
+// AnnouncedSizeSlack is the difference tolerated between the announced and the
+// actual size of a transaction, due to the RLP vs consensus format messiness of
+// some clients.
+const AnnouncedSizeSlack = 8
+
+// TxAnnouncement is the metadata of a transaction announced over the network,
+// as of the eth/68 protocol.
+type TxAnnouncement struct {
+	Type byte
+	Size uint32
+	Hash common.Hash
+}
+
+// PooledTransactions is a batch of transactions delivered in response to a
+// request for previously announced ones. The delivery may omit some of the
+// announced transactions, but can't contain any others.
+type PooledTransactions struct {
+	Announced    []TxAnnouncement
+	Transactions []*Transaction
+}
+
+// Validate checks that every delivered transaction was announced exactly once
+// with its actual type and size, and that none of them exceeds maxSize.
+func (p *PooledTransactions) Validate(maxSize uint64) error {
+	announced := make(map[common.Hash]TxAnnouncement, len(p.Announced))
+	for _, ann := range p.Announced {
+		announced[ann.Hash] = ann
+	}
+	delivered := make(map[common.Hash]struct{}, len(p.Transactions))
+	for i, tx := range p.Transactions {
+		if tx == nil {
+			return fmt.Errorf("%w: index %d", errNilPooledTx, i)
+		}
+		hash := tx.Hash()
+		ann, ok := announced[hash]
+		if !ok {
+			return fmt.Errorf("%w: %x", ErrUnannouncedTx, hash)
+		}
+		if _, ok := delivered[hash]; ok {
+			return fmt.Errorf("%w: %x", ErrDuplicatePooledTx, hash)
+		}
+		delivered[hash] = struct{}{}
+
+		if tx.Type() != ann.Type {
+			return fmt.Errorf("%w: %x: type %d, announced %d", ErrAnnouncedTypeMismatch, hash, tx.Type(), ann.Type)
+		}
+		size := tx.Size()
+		if size > maxSize {
+			return fmt.Errorf("%w: %x: size %d, limit %d", ErrPooledTxTooLarge, hash, size, maxSize)
+		}
+		if diff := int64(size) - int64(ann.Size); diff > AnnouncedSizeSlack || diff < -AnnouncedSizeSlack {
+			return fmt.Errorf("%w: %x: size %d, announced %d", ErrAnnouncedSizeMismatch, hash, size, ann.Size)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPooledTransactionsValidate(t *testing.T) {
+	announce := func(tx *Transaction) TxAnnouncement {
+		return TxAnnouncement{Type: tx.Type(), Size: uint32(tx.Size()), Hash: tx.Hash()}
+	}
+	var (
+		legacy = announce(rightvrsTx)
+		typed  = announce(signedEip2718Tx)
+	)
+	tests := []struct {
+		name      string
+		announced []TxAnnouncement
+		txs       []*Transaction
+		maxSize   uint64
+		want      error
+	}{
+		{"valid", []TxAnnouncement{legacy, typed}, []*Transaction{rightvrsTx, signedEip2718Tx}, 1024, nil},
+		{"partial", []TxAnnouncement{legacy, typed}, []*Transaction{signedEip2718Tx}, 1024, nil},
+		{"empty", []TxAnnouncement{legacy}, nil, 1024, nil},
+		{"nil", []TxAnnouncement{legacy}, []*Transaction{nil}, 1024, errNilPooledTx},
+		{"unannounced", []TxAnnouncement{legacy}, []*Transaction{signedEip2718Tx}, 1024, ErrUnannouncedTx},
+		{"duplicate", []TxAnnouncement{legacy}, []*Transaction{rightvrsTx, rightvrsTx}, 1024, ErrDuplicatePooledTx},
+		{"type", []TxAnnouncement{{Type: DynamicFeeTxType, Size: legacy.Size, Hash: legacy.Hash}}, []*Transaction{rightvrsTx}, 1024, ErrAnnouncedTypeMismatch},
+		{"size slack", []TxAnnouncement{{Type: legacy.Type, Size: legacy.Size + AnnouncedSizeSlack, Hash: legacy.Hash}}, []*Transaction{rightvrsTx}, 1024, nil},
+		{"size over", []TxAnnouncement{{Type: legacy.Type, Size: legacy.Size + AnnouncedSizeSlack + 1, Hash: legacy.Hash}}, []*Transaction{rightvrsTx}, 1024, ErrAnnouncedSizeMismatch},
+		{"size under", []TxAnnouncement{{Type: legacy.Type, Size: legacy.Size - AnnouncedSizeSlack - 1, Hash: legacy.Hash}}, []*Transaction{rightvrsTx}, 1024, ErrAnnouncedSizeMismatch},
+		{"max size", []TxAnnouncement{legacy}, []*Transaction{rightvrsTx}, rightvrsTx.Size(), nil},
+		{"too large", []TxAnnouncement{legacy}, []*Transaction{rightvrsTx}, rightvrsTx.Size() - 1, ErrPooledTxTooLarge},
+	}
+	for _, tt := range tests {
+		p := &PooledTransactions{Announced: tt.announced, Transactions: tt.txs}
+		if err := p.Validate(tt.maxSize); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -113,6 +113,13 @@ func (a *SetCodeAuthorization) sigHash() common.Hash {
 	})
 }
 
+// ValidChainID reports whether the authorization is valid on the chain with the
+// given ID. Per EIP-7702, authorizations with a zero chain ID are valid on any
+// chain.
+func (a *SetCodeAuthorization) ValidChainID(chainID *big.Int) bool {
+	return a.ChainID.IsZero() || a.ChainID.CmpBig(chainID) == 0
+}
+
 // Authority recovers the the authorizing account of an authorization.
 func (a *SetCodeAuthorization) Authority() (common.Address, error) {
 	sighash := a.sigHash()
@@ -17,9 +17,12 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
 )
 
 // TestParseDelegation tests a few possible delegation designator values and
@@ -68,3 +71,33 @@ func TestParseDelegation(t *testing.T) {
 		}
 	}
 }
+
+func TestSetCodeAuthorizationSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	want := crypto.PubkeyToAddress(key.PublicKey)
+
+	for _, chainID := range []uint64{0, 1, 1337} {
+		auth, err := SignSetCode(key, SetCodeAuthorization{
+			ChainID: *uint256.NewInt(chainID),
+			Address: common.Address{0x42},
+			Nonce:   7,
+		})
+		if err != nil {
+			t.Fatalf("chain %d: failed to sign: %v", chainID, err)
+		}
+		if have, err := auth.Authority(); err != nil || have != want {
+			t.Fatalf("chain %d: wrong authority: have %v, want %v, err %v", chainID, have, want, err)
+		}
+		for _, target := range []uint64{1, 1337} {
+			valid := chainID == 0 || chainID == target
+			if auth.ValidChainID(new(big.Int).SetUint64(target)) != valid {
+				t.Errorf("chain %d: wrong validity on chain %d, want %v", chainID, target, valid)
+			}
+		}
+		// Tampering the authorization changes the recovered authority
+		auth.Nonce++
+		if have, err := auth.Authority(); err == nil && have == want {
+			t.Errorf("chain %d: authority recovered from tampered authorization", chainID)
+		}
+	}
+}
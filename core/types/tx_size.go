@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+// EncodedSize returns the length of the canonical encoding of the transaction,
+// as returned by MarshalBinary, including the blob sidecar if present. Unlike
+// Size, it is computed from the transaction fields without encoding them.
+func (tx *Transaction) EncodedSize() uint64 {
+	size := tx.inner.encodedSize()
+	if tx.Type() != LegacyTxType {
+		size += 1
+	}
+	return size
+}
+
+// integerSize returns the encoded size of an unsigned integer of the given bit
+// length.
+func integerSize(bitlen int) uint64 {
+	if bitlen <= 7 {
+		return 1 // zero is encoded as empty string, small values as a single byte
+	}
+	// Strings are prefixed by headers of the same size as lists
+	return rlp.ListSize(uint64((bitlen + 7) / 8))
+}
+
+// bigSize returns the encoded size of a big integer, nil being encoded as zero.
+func bigSize(x *big.Int) uint64 {
+	if x == nil {
+		return 1
+	}
+	return integerSize(x.BitLen())
+}
+
+// u256Size returns the encoded size of a 256 bit integer, nil being encoded as zero.
+func u256Size(x *uint256.Int) uint64 {
+	if x == nil {
+		return 1
+	}
+	return integerSize(x.BitLen())
+}
+
+// addressPtrSize returns the encoded size of an optional address, nil being
+// encoded as empty string.
+func addressPtrSize(addr *common.Address) uint64 {
+	if addr == nil {
+		return 1
+	}
+	return 1 + common.AddressLength
+}
+
+// accessListSize returns the encoded size of an access list.
+func accessListSize(list AccessList) uint64 {
+	var size uint64
+	for _, tuple := range list {
+		size += rlp.ListSize(1 + common.AddressLength + rlp.ListSize(uint64(len(tuple.StorageKeys))*(1+common.HashLength)))
+	}
+	return rlp.ListSize(size)
+}
+
+// authListSize returns the encoded size of a list of set-code authorizations.
+func authListSize(list []SetCodeAuthorization) uint64 {
+	var size uint64
+	for i := range list {
+		auth := &list[i]
+		size += rlp.ListSize(u256Size(&auth.ChainID) + 1 + common.AddressLength + uint64(rlp.IntSize(auth.Nonce)) + uint64(rlp.IntSize(uint64(auth.V))) + u256Size(&auth.R) + u256Size(&auth.S))
+	}
+	return rlp.ListSize(size)
+}
+
+func (tx *LegacyTx) encodedSize() uint64 {
+	return rlp.ListSize(uint64(rlp.IntSize(tx.Nonce)) + bigSize(tx.GasPrice) + uint64(rlp.IntSize(tx.Gas)) +
+		addressPtrSize(tx.To) + bigSize(tx.Value) + rlp.BytesSize(tx.Data) +
+		bigSize(tx.V) + bigSize(tx.R) + bigSize(tx.S))
+}
+
+func (tx *AccessListTx) encodedSize() uint64 {
+	return rlp.ListSize(bigSize(tx.ChainID) + uint64(rlp.IntSize(tx.Nonce)) + bigSize(tx.GasPrice) +
+		uint64(rlp.IntSize(tx.Gas)) + addressPtrSize(tx.To) + bigSize(tx.Value) + rlp.BytesSize(tx.Data) +
+		accessListSize(tx.AccessList) + bigSize(tx.V) + bigSize(tx.R) + bigSize(tx.S))
+}
+
+func (tx *DynamicFeeTx) encodedSize() uint64 {
+	return rlp.ListSize(bigSize(tx.ChainID) + uint64(rlp.IntSize(tx.Nonce)) + bigSize(tx.GasTipCap) +
+		bigSize(tx.GasFeeCap) + uint64(rlp.IntSize(tx.Gas)) + addressPtrSize(tx.To) + bigSize(tx.Value) +
+		rlp.BytesSize(tx.Data) + accessListSize(tx.AccessList) + bigSize(tx.V) + bigSize(tx.R) + bigSize(tx.S))
+}
+
+func (tx *BlobTx) encodedSize() uint64 {
+	size := rlp.ListSize(u256Size(tx.ChainID) + uint64(rlp.IntSize(tx.Nonce)) + u256Size(tx.GasTipCap) +
+		u256Size(tx.GasFeeCap) + uint64(rlp.IntSize(tx.Gas)) + 1 + common.AddressLength + u256Size(tx.Value) +
+		rlp.BytesSize(tx.Data) + accessListSize(tx.AccessList) + u256Size(tx.BlobFeeCap) +
+		rlp.ListSize(uint64(len(tx.BlobHashes))*(1+common.HashLength)) + u256Size(tx.V) + u256Size(tx.R) + u256Size(tx.S))
+
+	// The network encoding wraps the transaction and the sidecar into a list
+	if tx.Sidecar != nil {
+		size = rlp.ListSize(size + tx.Sidecar.encodedSize())
+	}
+	return size
+}
+
+func (tx *SetCodeTx) encodedSize() uint64 {
+	return rlp.ListSize(u256Size(tx.ChainID) + uint64(rlp.IntSize(tx.Nonce)) + u256Size(tx.GasTipCap) +
+		u256Size(tx.GasFeeCap) + uint64(rlp.IntSize(tx.Gas)) + 1 + common.AddressLength + u256Size(tx.Value) +
+		rlp.BytesSize(tx.Data) + accessListSize(tx.AccessList) + authListSize(tx.AuthList) +
+		u256Size(tx.V) + u256Size(tx.R) + u256Size(tx.S))
+}
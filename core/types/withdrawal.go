@@ -18,6 +18,8 @@ package types
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -25,6 +27,15 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+var (
+	ErrWithdrawalMissing        = errors.New("missing withdrawal")
+	ErrWithdrawalValidatorRange = errors.New("withdrawal validator index out of range")
+	ErrWithdrawalIndexOrder     = errors.New("withdrawal index not increasing")
+)
+
+// maxWithdrawalValidator is the VALIDATOR_REGISTRY_LIMIT of the consensus layer.
+const maxWithdrawalValidator = 1 << 40
+
 //go:generate go run github.com/fjl/gencodec -type Withdrawal -field-override withdrawalMarshaling -out gen_withdrawal_json.go
 //go:generate go run ../../rlp/rlpgen -type Withdrawal -out gen_withdrawal_rlp.go
 
@@ -43,6 +54,20 @@ type withdrawalMarshaling struct {
 	Amount    hexutil.Uint64
 }
 
+// Validate checks that the fields of the withdrawal are within the ranges
+// permitted by the consensus layer. The address and the numeric fields can't
+// exceed their encoding as they are fixed size, but malformed withdrawals of
+// non-geth block producers may still carry absurd values.
+func (w *Withdrawal) Validate() error {
+	if w == nil {
+		return ErrWithdrawalMissing
+	}
+	if w.Validator >= maxWithdrawalValidator {
+		return fmt.Errorf("%w: %d, limit %d", ErrWithdrawalValidatorRange, w.Validator, maxWithdrawalValidator)
+	}
+	return nil
+}
+
 // Withdrawals implements DerivableList for withdrawals.
 type Withdrawals []*Withdrawal
 
@@ -55,6 +80,21 @@ func (s Withdrawals) Size() int {
 	return withdrawalSize * len(s)
 }
 
+// Validate checks each withdrawal of the list, along with the list being ordered
+// by strictly increasing withdrawal index. These are consensus layer invariants
+// the execution layer doesn't enforce, so block validation doesn't apply them.
+func (s Withdrawals) Validate() error {
+	for i, w := range s {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("withdrawal %d: %w", i, err)
+		}
+		if i > 0 && w.Index <= s[i-1].Index {
+			return fmt.Errorf("%w: withdrawal %d index %d, previous %d", ErrWithdrawalIndexOrder, i, w.Index, s[i-1].Index)
+		}
+	}
+	return nil
+}
+
 // EncodeIndex encodes the i'th withdrawal to w. Note that this does not check for errors
 // because we assume that *Withdrawal will only ever contain valid withdrawals that were either
 // constructed by decoding or via public API in this package.
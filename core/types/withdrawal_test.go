@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWithdrawalsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		decode  bool // Whether decoding is expected to fail
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			input: `[{"index":"0x1","validatorIndex":"0xffffffffff","address":"0x00000000000000000000000000000000000000aa","amount":"0x1"},{"index":"0x2","validatorIndex":"0x0","address":"0x00000000000000000000000000000000000000bb","amount":"0xffffffffffffffff"}]`,
+		},
+		{
+			name:    "validator out of range",
+			input:   `[{"index":"0x1","validatorIndex":"0x10000000000","address":"0x00000000000000000000000000000000000000aa","amount":"0x1"}]`,
+			wantErr: ErrWithdrawalValidatorRange,
+		},
+		{
+			name:    "missing withdrawal",
+			input:   `[{"index":"0x1","validatorIndex":"0x1","address":"0x00000000000000000000000000000000000000aa","amount":"0x1"},null]`,
+			wantErr: ErrWithdrawalMissing,
+		},
+		{
+			name:    "index not increasing",
+			input:   `[{"index":"0x2","validatorIndex":"0x1","address":"0x00000000000000000000000000000000000000aa","amount":"0x1"},{"index":"0x2","validatorIndex":"0x1","address":"0x00000000000000000000000000000000000000aa","amount":"0x1"}]`,
+			wantErr: ErrWithdrawalIndexOrder,
+		},
+		{
+			name:   "amount exceeding encoding",
+			input:  `[{"index":"0x1","validatorIndex":"0x1","address":"0x00000000000000000000000000000000000000aa","amount":"0x10000000000000000"}]`,
+			decode: true,
+		},
+		{
+			name:   "short address",
+			input:  `[{"index":"0x1","validatorIndex":"0x1","address":"0xaa","amount":"0x1"}]`,
+			decode: true,
+		},
+	}
+	for _, tt := range tests {
+		var withdrawals Withdrawals
+		err := json.Unmarshal([]byte(tt.input), &withdrawals)
+		if tt.decode {
+			if err == nil {
+				t.Errorf("%s: expected decoding failure", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: failed to decode: %v", tt.name, err)
+		}
+		if err := withdrawals.Validate(); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: validation error mismatch: have %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	// A single withdrawal may carry the largest amount
+	if err := (&Withdrawal{Amount: math.MaxUint64}).Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
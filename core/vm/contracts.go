@@ -231,6 +231,27 @@ func RunPrecompiledContract(p PrecompiledContract, input []byte, suppliedGas uin
 	return output, suppliedGas, err
 }
 
+// repricedPrecompile is a precompiled contract whose gas cost is scaled by a
+// multiplier, rounded up and saturating at the maximum gas.
+type repricedPrecompile struct {
+	PrecompiledContract
+	multiplier float64
+}
+
+// validGasMultiplier reports whether the gas multiplier of a precompiled contract
+// is non-negative and finite.
+func validGasMultiplier(multiplier float64) bool {
+	return multiplier >= 0 && !math.IsInf(multiplier, 0)
+}
+
+func (c *repricedPrecompile) RequiredGas(input []byte) uint64 {
+	gas := math.Ceil(float64(c.PrecompiledContract.RequiredGas(input)) * c.multiplier)
+	if gas >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(gas)
+}
+
 // ecrecover implemented as a native contract.
 type ecrecover struct{}
 
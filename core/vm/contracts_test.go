@@ -20,11 +20,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -393,3 +399,59 @@ func BenchmarkPrecompiledBLS12381G2MultiExpWorstCase(b *testing.B) {
 	}
 	benchmarkPrecompiled("f0f", testcase, b)
 }
+
+func TestPrecompileGasMultiplier(t *testing.T) {
+	var (
+		caller    = common.BytesToAddress([]byte("caller"))
+		ecrecover = common.BytesToAddress([]byte{0x01})
+		sha256    = common.BytesToAddress([]byte{0x02})
+		identity  = common.BytesToAddress([]byte{0x04})
+		input     = make([]byte, 32)
+		vmctx     = BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int),
+		}
+		multipliers = map[common.Address]float64{
+			ecrecover: 0,
+			sha256:    2.5,
+			identity:  1e30,
+			// Invalid multipliers are dropped
+			common.BytesToAddress([]byte{0x03}): -1,
+			common.BytesToAddress([]byte{0x05}): math.NaN(),
+			common.BytesToAddress([]byte{0x06}): math.Inf(1),
+		}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	evm := NewEVM(vmctx, statedb, params.TestChainConfig, Config{PrecompileGasMultiplier: multipliers})
+
+	tests := []struct {
+		addr     common.Address
+		gas      uint64
+		leftover uint64
+		err      error
+	}{
+		// Repriced to free
+		{addr: ecrecover, gas: 1000, leftover: 1000},
+		// Repriced to (60 + 12) * 2.5, the leftover being returned to the caller
+		{addr: sha256, gas: 1000, leftover: 1000 - 180},
+		{addr: sha256, gas: 179, err: ErrOutOfGas},
+		// Repriced beyond the maximum gas
+		{addr: identity, gas: math.MaxUint64 - 1, err: ErrOutOfGas},
+		// Not repriced
+		{addr: common.BytesToAddress([]byte{0x03}), gas: 1000, leftover: 1000 - params.Ripemd160BaseGas - params.Ripemd160PerWordGas},
+		{addr: common.BytesToAddress([]byte{0x06}), gas: 1000, leftover: 1000 - params.Bn256AddGasIstanbul},
+	}
+	if len(multipliers) != 6 {
+		t.Fatalf("caller's multipliers modified: %v", multipliers)
+	}
+	for i, tt := range tests {
+		_, leftover, err := evm.Call(caller, tt.addr, input, tt.gas, new(uint256.Int))
+		if err != tt.err {
+			t.Fatalf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if leftover != tt.leftover {
+			t.Errorf("test %d: leftover gas mismatch: have %d, want %d", i, leftover, tt.leftover)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+// Debugger is an interactive debugger of the EVM. Unlike the tracing hooks, it
+// is consulted before any validation or gas charge of the instructions, and it
+// may block the interpreter for as long as it wants, e.g. to wait for the host
+// to step or resume the execution.
+//
+// Breakpoints on a pc or an opcode are implemented by the debugger itself, by
+// returning immediately from the instructions it doesn't want to pause at.
+type Debugger interface {
+	// Step is invoked synchronously before the execution of each instruction,
+	// the interpreter waiting for it to return. The stack, memory and contract
+	// of the call frame are available through the scope, the storage through
+	// the state. Neither must be modified nor retained after returning, and
+	// the debugger must not call back into the EVM.
+	//
+	// A non-nil error aborts the call frame with the error. If the EVM was
+	// cancelled while blocked in the debugger, the call frame is stopped
+	// as soon as the debugger returns.
+	Step(pc uint64, op OpCode, depth int, scope *ScopeContext, state StateDB) error
+}
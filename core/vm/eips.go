@@ -324,6 +324,23 @@ func enable6780(jt *JumpTable) {
 	}
 }
 
+// applySelfdestructMode overrides the SELFDESTRUCT semantics of the jump table,
+// keeping the gas rules of the fork. Jump tables without SELFDESTRUCT (EOF) are
+// left untouched.
+func applySelfdestructMode(jt *JumpTable, mode SelfdestructMode) {
+	if jt[SELFDESTRUCT].undefined {
+		return
+	}
+	switch mode {
+	case SelfdestructLegacy:
+		jt[SELFDESTRUCT].execute = opSelfdestruct
+	case SelfdestructEIP6780:
+		jt[SELFDESTRUCT].execute = opSelfdestruct6780
+	case SelfdestructDisabled:
+		jt[SELFDESTRUCT].execute = opSelfdestructDisabled
+	}
+}
+
 func opExtCodeCopyEIP4762(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
 	var (
 		stack      = scope.Stack
@@ -337,6 +354,7 @@ func opExtCodeCopyEIP4762(pc *uint64, interpreter *EVMInterpreter, scope *ScopeC
 		uint64CodeOffset = math.MaxUint64
 	}
 	addr := common.Address(a.Bytes20())
+	interpreter.evm.recordCodeRead(EXTCODECOPY, scope, addr)
 	code := interpreter.evm.StateDB.GetCode(addr)
 	paddedCodeCopy, copyOffset, nonPaddedCopyLength := getDataAndAdjustedBounds(code, uint64CodeOffset, length.Uint64())
 	if !scope.Contract.IsSystemCall {
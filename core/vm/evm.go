@@ -65,6 +65,8 @@ type BlockContext struct {
 	BaseFee     *big.Int       // Provides information for BASEFEE (0 if vm runs with NoBaseFee flag and 0 gas price)
 	BlobBaseFee *big.Int       // Provides information for BLOBBASEFEE (0 if vm runs with NoBaseFee flag and 0 blob gas price)
 	Random      *common.Hash   // Provides information for PREVRANDAO
+
+	ExcessBlobGas *uint64 // Excess blob gas of the block, nil before Cancun (tracing purpose)
 }
 
 // TxContext provides the EVM with information about a transaction.
@@ -125,6 +127,51 @@ type EVM struct {
 	// jumpDests is the aggregated result of JUMPDEST analysis made through
 	// the life cycle of EVM.
 	jumpDests map[common.Hash]bitvec
+
+	// peakStack and peakMemory are the largest stack and memory sizes reached
+	// by any call frame since the transaction context was set.
+	peakStack  int
+	peakMemory int
+
+	// selfDestructs are the self-destructs executed since the transaction
+	// context was set, excluding the ones of reverted call frames.
+	selfDestructs []SelfDestruct
+
+	// outOfGas is the operation the top-level call frame ran out of gas at
+	// since the transaction context was set, if any.
+	outOfGas *OutOfGasFault
+
+	// codeReads are the external code reads executed since the transaction
+	// context was set, if recording them is enabled.
+	codeReads []CodeRead
+}
+
+// CodeRead is an access of a call frame to the code of an account, either to
+// run it (CALL, CALLCODE, DELEGATECALL, STATICCALL) or to inspect it (EXTCODECOPY,
+// EXTCODEHASH).
+type CodeRead struct {
+	Op     OpCode         // Operation reading the code
+	Reader common.Address // Address of the contract running the reading frame
+	Depth  int            // Call depth of the reading frame, 1 for the top-level frame
+	Target common.Address // Address of the account whose code is read
+}
+
+// OutOfGasFault describes the operation at which a call frame ran out of gas.
+type OutOfGasFault struct {
+	Op        OpCode // Operation running out of gas
+	PC        uint64 // Program counter of the operation
+	Gas       uint64 // Gas available before the operation
+	Shortfall uint64 // Additional gas needed to execute the operation, zero if unknown
+}
+
+// SelfDestruct is a SELFDESTRUCT operation executed by a contract. The balance
+// of the contract is credited to the beneficiary when the operation is executed,
+// so multiple self-destructs are applied in execution order.
+type SelfDestruct struct {
+	Contract    common.Address // Address of the self-destructing contract
+	Beneficiary common.Address // Address credited with the balance of the contract
+	Amount      *uint256.Int   // Balance of the contract transferred to the beneficiary
+	Destructed  bool           // Whether the contract is deleted, only if created in the same transaction since EIP-6780
 }
 
 // NewEVM constructs an EVM instance with the supplied block context, state
@@ -159,6 +206,61 @@ func (evm *EVM) SetTxContext(txCtx TxContext) {
 		txCtx.AccessEvents = state.NewAccessEvents(evm.StateDB.PointCache())
 	}
 	evm.TxContext = txCtx
+	evm.peakStack, evm.peakMemory = 0, 0
+	evm.selfDestructs = nil
+	evm.outOfGas = nil
+	evm.codeReads = nil
+}
+
+// ResourcePeaks returns the largest number of stack items and the largest memory
+// size in bytes reached by any call frame executed since the transaction context
+// was last set.
+func (evm *EVM) ResourcePeaks() (stack int, memory int) {
+	return evm.peakStack, evm.peakMemory
+}
+
+// SelfDestructs returns the self-destructs executed since the transaction context
+// was last set in execution order, excluding the ones of reverted call frames.
+func (evm *EVM) SelfDestructs() []SelfDestruct {
+	return evm.selfDestructs
+}
+
+// OutOfGasFault returns the operation at which the top-level call frame ran out
+// of gas since the transaction context was last set, or nil if it didn't run
+// out of gas executing an operation.
+func (evm *EVM) OutOfGasFault() *OutOfGasFault {
+	return evm.outOfGas
+}
+
+// CodeReads returns the external code reads executed since the transaction context
+// was last set in execution order, including the ones of reverted call frames. It
+// is only populated if Config.RecordCodeReads is set.
+func (evm *EVM) CodeReads() []CodeRead {
+	return evm.codeReads
+}
+
+// recordCodeRead records a read of the code of the target by the current call
+// frame, if recording code reads is enabled.
+func (evm *EVM) recordCodeRead(op OpCode, scope *ScopeContext, target common.Address) {
+	if !evm.Config.RecordCodeReads {
+		return
+	}
+	evm.codeReads = append(evm.codeReads, CodeRead{
+		Op:     op,
+		Reader: scope.Contract.Address(),
+		Depth:  evm.depth,
+		Target: target,
+	})
+}
+
+// recordSelfDestruct records a self-destruct executed by the current call frame.
+func (evm *EVM) recordSelfDestruct(contract, beneficiary common.Address, amount *uint256.Int, destructed bool) {
+	evm.selfDestructs = append(evm.selfDestructs, SelfDestruct{
+		Contract:    contract,
+		Beneficiary: beneficiary,
+		Amount:      new(uint256.Int).Set(amount),
+		Destructed:  destructed,
+	})
 }
 
 // Cancel cancels any running EVM operation. This may be called concurrently and
@@ -172,6 +274,41 @@ func (evm *EVM) Cancelled() bool {
 	return evm.abort.Load()
 }
 
+// newContract creates the contract environment of a call frame, sharing the
+// JUMPDEST analysis made through the life cycle of the EVM.
+func (evm *EVM) newContract(caller common.Address, address common.Address, value *uint256.Int, gas uint64) *Contract {
+	contract := NewContract(caller, address, value, gas, evm.jumpDests)
+	contract.shared = evm.Config.JumpDestCache
+	return contract
+}
+
+// translateAddress rewrites the target address of an opcode through the
+// configured AddressTranslate hook, if any.
+func (evm *EVM) translateAddress(addr common.Address) common.Address {
+	if evm.Config.AddressTranslate != nil {
+		return evm.Config.AddressTranslate(addr)
+	}
+	return addr
+}
+
+// MaxCodeSize returns the maximum size of the deployed contract code, as enforced
+// from EIP-158 on.
+func (evm *EVM) MaxCodeSize() int {
+	if evm.Config.MaxCodeSize != 0 {
+		return evm.Config.MaxCodeSize
+	}
+	return params.MaxCodeSize
+}
+
+// MaxInitCodeSize returns the maximum size of the contract init code, as enforced
+// from Shanghai on.
+func (evm *EVM) MaxInitCodeSize() int {
+	if evm.Config.MaxInitCodeSize != 0 {
+		return evm.Config.MaxInitCodeSize
+	}
+	return params.MaxInitCodeSize
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() *EVMInterpreter {
 	return evm.interpreter
@@ -181,6 +318,19 @@ func isSystemCall(caller common.Address) bool {
 	return caller == params.SystemAddress
 }
 
+// runPrecompile runs the precompiled contract p at addr, recording its usage if
+// a collector is configured.
+func (evm *EVM) runPrecompile(p PrecompiledContract, addr common.Address, input []byte, gas uint64) ([]byte, uint64, error) {
+	if multiplier, ok := evm.Config.PrecompileGasMultiplier[addr]; ok {
+		p = &repricedPrecompile{PrecompiledContract: p, multiplier: multiplier}
+	}
+	ret, remaining, err := RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+	if evm.Config.PrecompileStats != nil {
+		evm.Config.PrecompileStats.record(addr, gas-remaining)
+	}
+	return ret, remaining, err
+}
+
 // Call executes the contract associated with the addr with the given input as
 // parameters. It also handles any necessary value transfer required and takse
 // the necessary steps to create accounts and reverses the state in case of an
@@ -197,11 +347,17 @@ func (evm *EVM) Call(caller common.Address, addr common.Address, input []byte, g
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, ErrDepth
 	}
+	// Fail if we're trying to transfer value in a forcibly static execution.
+	// Nested calls are rejected by the interpreter, this catches the top call.
+	if evm.Config.ForceStatic && !value.IsZero() {
+		return nil, gas, ErrWriteProtection
+	}
 	// Fail if we're trying to transfer more than the available balance
 	if !value.IsZero() && !evm.Context.CanTransfer(evm.StateDB, caller, value) {
 		return nil, gas, ErrInsufficientBalance
 	}
 	snapshot := evm.StateDB.Snapshot()
+	destructs := len(evm.selfDestructs)
 	p, isPrecompile := evm.precompile(addr)
 
 	if !evm.StateDB.Exist(addr) {
@@ -224,7 +380,7 @@ func (evm *EVM) Call(caller common.Address, addr common.Address, input []byte, g
 	evm.Context.Transfer(evm.StateDB, caller, addr, value)
 
 	if isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, addr, input, gas)
 	} else {
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		code := evm.resolveCode(addr)
@@ -232,7 +388,7 @@ func (evm *EVM) Call(caller common.Address, addr common.Address, input []byte, g
 			ret, err = nil, nil // gas is unchanged
 		} else {
 			// The contract is a scoped environment for this execution context only.
-			contract := NewContract(caller, addr, value, gas, evm.jumpDests)
+			contract := evm.newContract(caller, addr, value, gas)
 			contract.IsSystemCall = isSystemCall(caller)
 			contract.SetCallCode(evm.resolveCodeHash(addr), code)
 			ret, err = evm.interpreter.Run(contract, input, false)
@@ -244,6 +400,7 @@ func (evm *EVM) Call(caller common.Address, addr common.Address, input []byte, g
 	// when we're in homestead this also counts for code storage gas errors.
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.selfDestructs = evm.selfDestructs[:destructs]
 		if err != ErrExecutionReverted {
 			if evm.Config.Tracer != nil && evm.Config.Tracer.OnGasChange != nil {
 				evm.Config.Tracer.OnGasChange(gas, 0, tracing.GasChangeCallFailedExecution)
@@ -285,20 +442,22 @@ func (evm *EVM) CallCode(caller common.Address, addr common.Address, input []byt
 		return nil, gas, ErrInsufficientBalance
 	}
 	var snapshot = evm.StateDB.Snapshot()
+	destructs := len(evm.selfDestructs)
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, addr, input, gas)
 	} else {
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
-		contract := NewContract(caller, caller, value, gas, evm.jumpDests)
+		contract := evm.newContract(caller, caller, value, gas)
 		contract.SetCallCode(evm.resolveCodeHash(addr), evm.resolveCode(addr))
 		ret, err = evm.interpreter.Run(contract, input, false)
 		gas = contract.Gas
 	}
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.selfDestructs = evm.selfDestructs[:destructs]
 		if err != ErrExecutionReverted {
 			if evm.Config.Tracer != nil && evm.Config.Tracer.OnGasChange != nil {
 				evm.Config.Tracer.OnGasChange(gas, 0, tracing.GasChangeCallFailedExecution)
@@ -328,21 +487,23 @@ func (evm *EVM) DelegateCall(originCaller common.Address, caller common.Address,
 		return nil, gas, ErrDepth
 	}
 	var snapshot = evm.StateDB.Snapshot()
+	destructs := len(evm.selfDestructs)
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, addr, input, gas)
 	} else {
 		// Initialise a new contract and make initialise the delegate values
 		//
 		// Note: The value refers to the original value from the parent call.
-		contract := NewContract(originCaller, caller, value, gas, evm.jumpDests)
+		contract := evm.newContract(originCaller, caller, value, gas)
 		contract.SetCallCode(evm.resolveCodeHash(addr), evm.resolveCode(addr))
 		ret, err = evm.interpreter.Run(contract, input, false)
 		gas = contract.Gas
 	}
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.selfDestructs = evm.selfDestructs[:destructs]
 		if err != ErrExecutionReverted {
 			if evm.Config.Tracer != nil && evm.Config.Tracer.OnGasChange != nil {
 				evm.Config.Tracer.OnGasChange(gas, 0, tracing.GasChangeCallFailedExecution)
@@ -375,6 +536,7 @@ func (evm *EVM) StaticCall(caller common.Address, addr common.Address, input []b
 	// then certain tests start failing; stRevertTest/RevertPrecompiledTouchExactOOG.json.
 	// We could change this, but for now it's left for legacy reasons
 	var snapshot = evm.StateDB.Snapshot()
+	destructs := len(evm.selfDestructs)
 
 	// We do an AddBalance of zero here, just in order to trigger a touch.
 	// This doesn't matter on Mainnet, where all empties are gone at the time of Byzantium,
@@ -383,11 +545,11 @@ func (evm *EVM) StaticCall(caller common.Address, addr common.Address, input []b
 	evm.StateDB.AddBalance(addr, new(uint256.Int), tracing.BalanceChangeTouchAccount)
 
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, addr, input, gas)
 	} else {
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
-		contract := NewContract(caller, addr, new(uint256.Int), gas, evm.jumpDests)
+		contract := evm.newContract(caller, addr, new(uint256.Int), gas)
 		contract.SetCallCode(evm.resolveCodeHash(addr), evm.resolveCode(addr))
 
 		// When an error was returned by the EVM or when setting the creation code
@@ -398,6 +560,7 @@ func (evm *EVM) StaticCall(caller common.Address, addr common.Address, input []b
 	}
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.selfDestructs = evm.selfDestructs[:destructs]
 		if err != ErrExecutionReverted {
 			if evm.Config.Tracer != nil && evm.Config.Tracer.OnGasChange != nil {
 				evm.Config.Tracer.OnGasChange(gas, 0, tracing.GasChangeCallFailedExecution)
@@ -422,6 +585,9 @@ func (evm *EVM) create(caller common.Address, code []byte, gas uint64, value *ui
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, common.Address{}, gas, ErrDepth
 	}
+	if evm.Config.ForceStatic {
+		return nil, common.Address{}, gas, ErrWriteProtection
+	}
 	if !evm.Context.CanTransfer(evm.StateDB, caller, value) {
 		return nil, common.Address{}, gas, ErrInsufficientBalance
 	}
@@ -467,6 +633,7 @@ func (evm *EVM) create(caller common.Address, code []byte, gas uint64, value *ui
 	// It might be possible the contract code is deployed to a pre-existent
 	// account with non-zero balance.
 	snapshot := evm.StateDB.Snapshot()
+	destructs := len(evm.selfDestructs)
 	if !evm.StateDB.Exist(address) {
 		evm.StateDB.CreateAccount(address)
 	}
@@ -494,7 +661,7 @@ func (evm *EVM) create(caller common.Address, code []byte, gas uint64, value *ui
 
 	// Initialise a new contract and set the code that is to be used by the EVM.
 	// The contract is a scoped environment for this execution context only.
-	contract := NewContract(caller, address, value, gas, evm.jumpDests)
+	contract := evm.newContract(caller, address, value, gas)
 
 	// Explicitly set the code to a null hash to prevent caching of jump analysis
 	// for the initialization code.
@@ -504,6 +671,7 @@ func (evm *EVM) create(caller common.Address, code []byte, gas uint64, value *ui
 	ret, err = evm.initNewContract(contract, address)
 	if err != nil && (evm.chainRules.IsHomestead || err != ErrCodeStoreOutOfGas) {
 		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.selfDestructs = evm.selfDestructs[:destructs]
 		if err != ErrExecutionReverted {
 			contract.UseGas(contract.Gas, evm.Config.Tracer, tracing.GasChangeCallFailedExecution)
 		}
@@ -520,7 +688,7 @@ func (evm *EVM) initNewContract(contract *Contract, address common.Address) ([]b
 	}
 
 	// Check whether the max code size has been exceeded, assign err if the case.
-	if evm.chainRules.IsEIP158 && len(ret) > params.MaxCodeSize {
+	if evm.chainRules.IsEIP158 && len(ret) > evm.MaxCodeSize() {
 		return ret, ErrMaxCodeSizeExceeded
 	}
 
@@ -628,5 +796,8 @@ func (evm *EVM) GetVMContext() *tracing.VMContext {
 		Random:      evm.Context.Random,
 		BaseFee:     evm.Context.BaseFee,
 		StateDB:     evm.StateDB,
+
+		BlobBaseFee:   evm.Context.BlobBaseFee,
+		ExcessBlobGas: evm.Context.ExcessBlobGas,
 	}
 }
@@ -179,3 +179,66 @@ func TestCreateGas(t *testing.T) {
 		}
 	}
 }
+
+func TestCodeSizeLimits(t *testing.T) {
+	vmctx := BlockContext{
+		CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+		BlockNumber: big.NewInt(0),
+		Random:      &common.Hash{},
+	}
+	// deploy creates a contract returning size zero bytes: return(0, size)
+	deploy := func(config Config, size int, gas uint64) error {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		evm := NewEVM(vmctx, statedb, params.MergedTestChainConfig, config)
+		code := []byte{byte(PUSH3), byte(size >> 16), byte(size >> 8), byte(size), byte(PUSH1), 0, byte(RETURN)}
+		_, _, _, err := evm.Create(common.Address{}, code, gas, new(uint256.Int))
+		return err
+	}
+	deployTests := []struct {
+		limit int
+		size  int
+		gas   uint64
+		err   error
+	}{
+		{0, params.MaxCodeSize, 10_000_000, nil},
+		{0, params.MaxCodeSize + 1, 10_000_000, ErrMaxCodeSizeExceeded},
+		{params.MaxCodeSize + 1, params.MaxCodeSize + 1, 10_000_000, nil},
+		{params.MaxCodeSize + 1, params.MaxCodeSize + 2, 10_000_000, ErrMaxCodeSizeExceeded},
+		{100, 100, 100_000, nil},
+		{100, 101, 100_000, ErrMaxCodeSizeExceeded},
+		{100, 100, 10_000, ErrCodeStoreOutOfGas},
+	}
+	for i, tt := range deployTests {
+		if err := deploy(Config{MaxCodeSize: tt.limit}, tt.size, tt.gas); err != tt.err {
+			t.Errorf("deploy test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	// initCodeGas charges a CREATE of size bytes of init code
+	initCodeGas := func(config Config, size int) error {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		evm := NewEVM(vmctx, statedb, params.MergedTestChainConfig, config)
+		stack := newstack()
+		stack.push(uint256.NewInt(uint64(size))) // size
+		stack.push(new(uint256.Int))             // offset
+		stack.push(new(uint256.Int))             // value
+		_, err := gasCreateEip3860(evm, nil, stack, NewMemory(), 0)
+		return err
+	}
+	initCodeTests := []struct {
+		limit int
+		size  int
+		err   error
+	}{
+		{0, params.MaxInitCodeSize, nil},
+		{0, params.MaxInitCodeSize + 1, ErrMaxInitCodeSizeExceeded},
+		{2 * params.MaxInitCodeSize, 2 * params.MaxInitCodeSize, nil},
+		{2 * params.MaxInitCodeSize, 2*params.MaxInitCodeSize + 1, ErrMaxInitCodeSizeExceeded},
+		{100, 101, ErrMaxInitCodeSizeExceeded},
+	}
+	for i, tt := range initCodeTests {
+		if err := initCodeGas(Config{MaxInitCodeSize: tt.limit}, tt.size); !errors.Is(err, tt.err) {
+			t.Errorf("init code test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
@@ -56,6 +56,7 @@ type StateDB interface {
 	GetStorageRoot(addr common.Address) common.Hash
 
 	GetTransientState(addr common.Address, key common.Hash) common.Hash
+	GetTransientStorage(addr common.Address) map[common.Hash]common.Hash
 	SetTransientState(addr common.Address, key, value common.Hash)
 
 	SelfDestruct(common.Address) uint256.Int
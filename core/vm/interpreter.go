@@ -35,8 +35,48 @@ type Config struct {
 	ExtraEips               []int // Additional EIPS that are to be enabled
 
 	StatelessSelfValidation bool // Generate execution witnesses and self-check against them (testing purpose)
+
+	SelfdestructMode SelfdestructMode // Overrides the fork-derived SELFDESTRUCT semantics (testing purpose)
+	PrecompileStats  *PrecompileStats // Collects the usage of the precompiled contracts if non-nil
+	OpcodeHistogram  *OpcodeHistogram // Counts the executed opcodes if non-nil
+
+	// PrecompileGasMultiplier scales the gas cost of the precompiled contracts
+	// at the given addresses by a non-negative finite factor, rounding up. Invalid
+	// factors are dropped, contracts not present are priced by the fork rules
+	// (testing or L2 pricing purpose).
+	PrecompileGasMultiplier map[common.Address]float64
+
+	ForceStatic bool // Executes every call as a STATICCALL, rejecting all state modifications (read-only simulation)
+
+	MaxCodeSize     int // Overrides the EIP-170 limit of the deployed code if non-zero
+	MaxInitCodeSize int // Overrides the EIP-3860 limit of the init code if non-zero
+
+	// AddressTranslate rewrites the target addresses of the call opcodes and
+	// EXTCODECOPY before they are looked up, e.g. to simulate address aliasing
+	// (testing purpose). Gas is charged against the original targets.
+	AddressTranslate func(common.Address) common.Address
+
+	JumpDestCache *JumpDestCache // Shares the JUMPDEST analysis of contract code across EVMs if non-nil
+	Debugger      Debugger       // Pauses the execution before each instruction if non-nil
+
+	RecordCodeReads bool // Records the external code reads of the call frames (dependency analysis purpose)
+
+	// BaseFeeRecipient is credited the EIP-1559 base fee paid by transactions
+	// instead of it being burned, for chains redirecting the base fee if non-nil.
+	BaseFeeRecipient *common.Address
 }
 
+// SelfdestructMode selects the semantics of the SELFDESTRUCT opcode, independent
+// of the active fork rules. The gas costs are still derived from the fork.
+type SelfdestructMode uint8
+
+const (
+	SelfdestructDefault  SelfdestructMode = iota // Semantics derived from the fork rules
+	SelfdestructLegacy                           // Account is always deleted at the end of the transaction
+	SelfdestructEIP6780                          // Account is only deleted if created in the same transaction
+	SelfdestructDisabled                         // Account is never deleted, only the balance is transferred
+)
+
 // ScopeContext contains the things that are per-call, such as stack and memory,
 // but not transients like pc and gas
 type ScopeContext struct {
@@ -137,7 +177,7 @@ func NewEVMInterpreter(evm *EVM) *EVMInterpreter {
 		table = &frontierInstructionSet
 	}
 	var extraEips []int
-	if len(evm.Config.ExtraEips) > 0 {
+	if len(evm.Config.ExtraEips) > 0 || evm.Config.SelfdestructMode != SelfdestructDefault {
 		// Deep-copy jumptable to prevent modification of opcodes in other tables
 		table = copyJumpTable(table)
 	}
@@ -150,6 +190,20 @@ func NewEVMInterpreter(evm *EVM) *EVMInterpreter {
 		}
 	}
 	evm.Config.ExtraEips = extraEips
+
+	if len(evm.Config.PrecompileGasMultiplier) > 0 {
+		// Copy the multipliers to prevent modification of the caller's config
+		multipliers := make(map[common.Address]float64, len(evm.Config.PrecompileGasMultiplier))
+		for addr, multiplier := range evm.Config.PrecompileGasMultiplier {
+			if !validGasMultiplier(multiplier) {
+				log.Error("Invalid precompile gas multiplier", "addr", addr, "multiplier", multiplier)
+				continue
+			}
+			multipliers[addr] = multiplier
+		}
+		evm.Config.PrecompileGasMultiplier = multipliers
+	}
+	applySelfdestructMode(table, evm.Config.SelfdestructMode)
 	return &EVMInterpreter{evm: evm, table: table}
 }
 
@@ -166,7 +220,7 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 
 	// Make sure the readOnly is only set if we aren't in readOnly yet.
 	// This also makes sure that the readOnly flag isn't removed for child calls.
-	if readOnly && !in.readOnly {
+	if (readOnly || in.evm.Config.ForceStatic) && !in.readOnly {
 		in.readOnly = true
 		defer func() { in.readOnly = false }()
 	}
@@ -200,11 +254,21 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		logged  bool   // deferred EVMLogger should ignore already logged steps
 		res     []byte // result of the opcode execution function
 		debug   = in.evm.Config.Tracer != nil
+		hist    = in.evm.Config.OpcodeHistogram
+		dbg     = in.evm.Config.Debugger
+
+		peakStack int // largest stack size of the frame, memory only grows
 	)
 	// Don't move this deferred function, it's placed before the OnOpcode-deferred method,
 	// so that it gets executed _after_: the OnOpcode needs the stacks before
 	// they are returned to the pools
 	defer func() {
+		if peakStack > in.evm.peakStack {
+			in.evm.peakStack = peakStack
+		}
+		if mem.Len() > in.evm.peakMemory {
+			in.evm.peakMemory = mem.Len()
+		}
 		returnStack(stack)
 		mem.Free()
 	}()
@@ -243,16 +307,34 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		// Get the operation from the jump table and validate the stack to ensure there are
 		// enough stack items available to perform the operation.
 		op = contract.GetOp(pc)
+		if dbg != nil {
+			if err = dbg.Step(pc, op, in.evm.depth, callContext, in.evm.StateDB); err != nil {
+				return nil, err
+			}
+			// The EVM might have been cancelled while the debugger was blocking
+			if in.evm.abort.Load() {
+				err = errStopToken
+				break
+			}
+		}
 		operation := in.table[op]
+		if hist != nil {
+			hist.record(op, operation.undefined)
+		}
 		cost = operation.constantGas // For tracing
 		// Validate stack
-		if sLen := stack.len(); sLen < operation.minStack {
+		sLen := stack.len()
+		if sLen > peakStack {
+			peakStack = sLen
+		}
+		if sLen < operation.minStack {
 			return nil, &ErrStackUnderflow{stackLen: sLen, required: operation.minStack}
 		} else if sLen > operation.maxStack {
 			return nil, &ErrStackOverflow{stackLen: sLen, limit: operation.maxStack}
 		}
 		// for tracing: this gas consumption event is emitted below in the debug section.
 		if contract.Gas < cost {
+			in.recordOutOfGas(op, pc, contract.Gas, cost)
 			return nil, ErrOutOfGas
 		} else {
 			contract.Gas -= cost
@@ -282,10 +364,12 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 			dynamicCost, err = operation.dynamicGas(in.evm, contract, stack, mem, memorySize)
 			cost += dynamicCost // for tracing
 			if err != nil {
+				in.recordOutOfGas(op, pc, contract.Gas+operation.constantGas, 0)
 				return nil, fmt.Errorf("%w: %v", ErrOutOfGas, err)
 			}
 			// for tracing: this gas consumption event is emitted below in the debug section.
 			if contract.Gas < dynamicCost {
+				in.recordOutOfGas(op, pc, contract.Gas+operation.constantGas, cost)
 				return nil, ErrOutOfGas
 			} else {
 				contract.Gas -= dynamicCost
@@ -320,3 +404,18 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 
 	return res, err
 }
+
+// recordOutOfGas records the operation of the top-level call frame running out
+// of gas, given the gas available before the operation and its total cost, zero
+// if it couldn't be determined. The faults of nested frames are not recorded, as
+// their callers may continue executing.
+func (in *EVMInterpreter) recordOutOfGas(op OpCode, pc uint64, gas uint64, cost uint64) {
+	if in.evm.depth != 1 {
+		return
+	}
+	fault := &OutOfGasFault{Op: op, PC: pc, Gas: gas}
+	if cost > gas {
+		fault.Shortfall = cost - gas
+	}
+	in.evm.outOfGas = fault
+}
@@ -17,12 +17,16 @@
 package vm
 
 import (
+	"bytes"
+	"errors"
 	"math"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
@@ -74,3 +78,292 @@ func TestLoopInterrupt(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the SELFDESTRUCT semantics can be overridden independent of the
+// fork rules, both for pre-existing contracts and ones created within the
+// same transaction.
+func TestSelfdestructMode(t *testing.T) {
+	var (
+		contract    = common.BytesToAddress([]byte("contract"))
+		beneficiary = common.BytesToAddress([]byte("beneficiary"))
+		code        = append(append([]byte{byte(PUSH20)}, beneficiary.Bytes()...), byte(SELFDESTRUCT))
+		vmctx       = BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int),
+			Random:      &common.Hash{},
+		}
+	)
+	for i, tt := range []struct {
+		mode       SelfdestructMode
+		created    bool // Whether the contract is created in the same transaction
+		wantExists bool
+	}{
+		{mode: SelfdestructDefault, created: false, wantExists: true},
+		{mode: SelfdestructDefault, created: true, wantExists: false},
+		{mode: SelfdestructLegacy, created: false, wantExists: false},
+		{mode: SelfdestructLegacy, created: true, wantExists: false},
+		{mode: SelfdestructEIP6780, created: false, wantExists: true},
+		{mode: SelfdestructEIP6780, created: true, wantExists: false},
+		{mode: SelfdestructDisabled, created: false, wantExists: true},
+		{mode: SelfdestructDisabled, created: true, wantExists: true},
+	} {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.CreateAccount(contract)
+		statedb.SetCode(contract, code)
+		statedb.AddBalance(contract, uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+		if tt.created {
+			statedb.CreateContract(contract)
+		} else {
+			statedb.Finalise(true)
+		}
+		evm := NewEVM(vmctx, statedb, params.MergedTestChainConfig, Config{SelfdestructMode: tt.mode})
+		if _, _, err := evm.Call(common.Address{}, contract, nil, 100000, new(uint256.Int)); err != nil {
+			t.Fatalf("test %d: call failed: %v", i, err)
+		}
+		statedb.Finalise(true)
+
+		if exists := statedb.Exist(contract); exists != tt.wantExists {
+			t.Errorf("test %d: contract existence mismatch: have %t, want %t", i, exists, tt.wantExists)
+		}
+		if tt.wantExists && len(statedb.GetCode(contract)) == 0 {
+			t.Errorf("test %d: contract code removed", i)
+		}
+		if balance := statedb.GetBalance(contract); !balance.IsZero() {
+			t.Errorf("test %d: contract balance not transferred: %v", i, balance)
+		}
+		if balance := statedb.GetBalance(beneficiary); balance.Uint64() != 100 {
+			t.Errorf("test %d: beneficiary balance mismatch: have %v, want 100", i, balance)
+		}
+	}
+}
+
+// Tests that forcing static execution rejects state modifications both in the
+// top call and in nested calls, while read-only code executes normally.
+func TestForceStatic(t *testing.T) {
+	var (
+		writer = common.BytesToAddress([]byte("writer"))
+		caller = common.BytesToAddress([]byte("caller"))
+		reader = common.BytesToAddress([]byte("reader"))
+		vmctx  = BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int),
+			Random:      &common.Hash{},
+		}
+	)
+	newState := func() *state.StateDB {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		// writer: sstore(0, 1)
+		statedb.SetCode(writer, []byte{byte(PUSH1), 1, byte(PUSH1), 0, byte(SSTORE)})
+		// caller: mstore(0, call(gas, writer, 0, 0, 0, 0, 0)); return(0, 32)
+		code := []byte{byte(PUSH1), 0, byte(DUP1), byte(DUP1), byte(DUP1), byte(DUP1), byte(PUSH20)}
+		code = append(code, writer.Bytes()...)
+		code = append(code, byte(GAS), byte(CALL), byte(PUSH1), 0, byte(MSTORE), byte(PUSH1), 32, byte(PUSH1), 0, byte(RETURN))
+		statedb.SetCode(caller, code)
+		// reader: sload(0)
+		statedb.SetCode(reader, []byte{byte(PUSH1), 0, byte(SLOAD), byte(POP)})
+		statedb.Finalise(true)
+		return statedb
+	}
+	// Without forcing, the nested write succeeds
+	statedb := newState()
+	evm := NewEVM(vmctx, statedb, params.MergedTestChainConfig, Config{})
+	ret, _, err := evm.Call(common.Address{}, caller, nil, 100000, new(uint256.Int))
+	if err != nil || new(uint256.Int).SetBytes(ret).Uint64() != 1 {
+		t.Fatalf("unforced nested write failed: %x, %v", ret, err)
+	}
+	// Writes in the top call are rejected
+	statedb = newState()
+	evm = NewEVM(vmctx, statedb, params.MergedTestChainConfig, Config{ForceStatic: true})
+	if _, _, err := evm.Call(common.Address{}, writer, nil, 100000, new(uint256.Int)); err != ErrWriteProtection {
+		t.Fatalf("top call write error mismatch: have %v, want %v", err, ErrWriteProtection)
+	}
+	// Writes in nested calls are rejected, failing the nested call
+	ret, _, err = evm.Call(common.Address{}, caller, nil, 100000, new(uint256.Int))
+	if err != nil {
+		t.Fatalf("forced nested call failed: %v", err)
+	}
+	if new(uint256.Int).SetBytes(ret).Uint64() != 0 {
+		t.Fatal("nested write succeeded")
+	}
+	if have := statedb.GetState(writer, common.Hash{}); have != (common.Hash{}) {
+		t.Fatalf("storage modified: %x", have)
+	}
+	// Value transfers and contract creations are rejected
+	if _, _, err := evm.Call(common.Address{}, reader, nil, 100000, uint256.NewInt(1)); err != ErrWriteProtection {
+		t.Fatalf("value transfer error mismatch: have %v, want %v", err, ErrWriteProtection)
+	}
+	if _, _, _, err := evm.Create(common.Address{}, nil, 100000, new(uint256.Int)); err != ErrWriteProtection {
+		t.Fatalf("creation error mismatch: have %v, want %v", err, ErrWriteProtection)
+	}
+	// Read-only code executes normally
+	if _, _, err := evm.Call(common.Address{}, reader, nil, 100000, new(uint256.Int)); err != nil {
+		t.Fatalf("read-only call failed: %v", err)
+	}
+}
+
+// Tests that the address translation hook redirects the call and EXTCODECOPY
+// targets, without affecting the address of the caller seen by the callee.
+func TestAddressTranslate(t *testing.T) {
+	var (
+		alias  = common.BytesToAddress([]byte("alias"))
+		target = common.BytesToAddress([]byte("target"))
+		caller = common.BytesToAddress([]byte("caller"))
+		copier = common.BytesToAddress([]byte("copier"))
+		vmctx  = BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int),
+			Random:      &common.Hash{},
+		}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	// target: mstore(0, caller); return(0, 32)
+	targetCode := []byte{byte(CALLER), byte(PUSH1), 0, byte(MSTORE), byte(PUSH1), 32, byte(PUSH1), 0, byte(RETURN)}
+	statedb.SetCode(target, targetCode)
+	// caller: staticcall(gas, alias, 0, 0, 0, 32); return(0, 32)
+	code := []byte{byte(PUSH1), 32, byte(PUSH1), 0, byte(DUP1), byte(DUP1), byte(PUSH20)}
+	code = append(code, alias.Bytes()...)
+	code = append(code, byte(GAS), byte(STATICCALL), byte(POP), byte(PUSH1), 32, byte(PUSH1), 0, byte(RETURN))
+	statedb.SetCode(caller, code)
+	// copier: extcodecopy(alias, 0, 0, 9); return(0, 9)
+	code = []byte{byte(PUSH1), 9, byte(PUSH1), 0, byte(DUP1), byte(PUSH20)}
+	code = append(code, alias.Bytes()...)
+	code = append(code, byte(EXTCODECOPY), byte(PUSH1), 9, byte(PUSH1), 0, byte(RETURN))
+	statedb.SetCode(copier, code)
+	statedb.Finalise(true)
+
+	// Without translation, the alias has no code
+	evm := NewEVM(vmctx, statedb, params.MergedTestChainConfig, Config{})
+	ret, _, err := evm.Call(common.Address{}, caller, nil, 100000, new(uint256.Int))
+	if err != nil || common.BytesToAddress(ret) != (common.Address{}) {
+		t.Fatalf("untranslated call mismatch: %x, %v", ret, err)
+	}
+	// With translation, the alias executes the code of the target
+	translate := func(addr common.Address) common.Address {
+		if addr == alias {
+			return target
+		}
+		return addr
+	}
+	evm = NewEVM(vmctx, statedb, params.MergedTestChainConfig, Config{AddressTranslate: translate})
+	ret, _, err = evm.Call(common.Address{}, caller, nil, 100000, new(uint256.Int))
+	if err != nil {
+		t.Fatalf("translated call failed: %v", err)
+	}
+	if have := common.BytesToAddress(ret); have != caller {
+		t.Fatalf("msg.sender mismatch: have %x, want %x", have, caller)
+	}
+	ret, _, err = evm.Call(common.Address{}, copier, nil, 100000, new(uint256.Int))
+	if err != nil || !bytes.Equal(ret, targetCode) {
+		t.Fatalf("translated code copy mismatch: have %x (err %v), want %x", ret, err, targetCode)
+	}
+}
+
+// debuggerFunc is a debugger invoking the wrapped function at each step.
+type debuggerFunc func(pc uint64, op OpCode, depth int, scope *ScopeContext, state StateDB) error
+
+func (f debuggerFunc) Step(pc uint64, op OpCode, depth int, scope *ScopeContext, state StateDB) error {
+	return f(pc, op, depth, scope, state)
+}
+
+// Tests that the debugger can abort the execution, and that an EVM cancelled
+// while blocked in the debugger stops once it is resumed.
+func TestDebugger(t *testing.T) {
+	var (
+		address = common.BytesToAddress([]byte("contract"))
+		vmctx   = BlockContext{
+			Transfer: func(StateDB, common.Address, common.Address, *uint256.Int) {},
+		}
+		errAbort = errors.New("aborted by debugger")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.CreateAccount(address)
+	statedb.SetCode(address, common.Hex2Bytes(loopInterruptTests[0]))
+	statedb.Finalise(true)
+
+	// Abort the infinite loop after a number of instructions
+	var steps int
+	evm := NewEVM(vmctx, statedb, params.AllEthashProtocolChanges, Config{
+		Debugger: debuggerFunc(func(pc uint64, op OpCode, depth int, scope *ScopeContext, state StateDB) error {
+			if steps++; steps == 100 {
+				return errAbort
+			}
+			return nil
+		}),
+	})
+	if _, _, err := evm.Call(common.Address{}, address, nil, math.MaxUint64, new(uint256.Int)); !errors.Is(err, errAbort) {
+		t.Fatalf("abort error mismatch: have %v, want %v", err, errAbort)
+	}
+	if steps != 100 {
+		t.Fatalf("step count mismatch: have %d, want %d", steps, 100)
+	}
+	// Cancel the EVM while the debugger is blocking at the first instruction
+	var (
+		paused = make(chan struct{})
+		resume = make(chan struct{})
+	)
+	steps = 0
+	evm = NewEVM(vmctx, statedb, params.AllEthashProtocolChanges, Config{
+		Debugger: debuggerFunc(func(pc uint64, op OpCode, depth int, scope *ScopeContext, state StateDB) error {
+			if steps++; steps == 1 {
+				close(paused)
+				<-resume
+			}
+			return nil
+		}),
+	})
+	errc := make(chan error)
+	go func() {
+		_, _, err := evm.Call(common.Address{}, address, nil, math.MaxUint64, new(uint256.Int))
+		errc <- err
+	}()
+	<-paused
+	evm.Cancel()
+	close(resume)
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatal("cancelled execution timed out")
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("cancelled execution failed: %v", err)
+		}
+	}
+	if steps != 1 {
+		t.Fatalf("step count mismatch: have %d, want %d", steps, 1)
+	}
+}
+
+// Tests that static calls can be run on a read-only view of the state.
+func TestStaticCallReadOnlyState(t *testing.T) {
+	var (
+		address = common.BytesToAddress([]byte("contract"))
+		slot    = common.BytesToHash([]byte{0x01})
+		value   = common.BytesToHash([]byte{0x02})
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetCode(address, []byte{
+		byte(PUSH1), 0x01, byte(SLOAD),
+		byte(PUSH1), 0x00, byte(MSTORE),
+		byte(PUSH1), 0x20, byte(PUSH1), 0x00, byte(RETURN),
+	})
+	statedb.SetState(address, slot, value)
+	statedb.Finalise(true)
+
+	vmctx := BlockContext{
+		CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+		BlockNumber: new(big.Int),
+	}
+	evm := NewEVM(vmctx, statedb.ReadOnly(), params.MergedTestChainConfig, Config{})
+
+	ret, _, err := evm.StaticCall(common.Address{}, address, nil, 100_000)
+	if err != nil {
+		t.Fatalf("static call failed: %v", err)
+	}
+	if !bytes.Equal(ret, value.Bytes()) {
+		t.Fatalf("return value mismatch: have %x, want %x", ret, value)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// JumpDestCache is a size-bounded cache of the JUMPDEST analysis of contract
+// code, keyed by code hash. It's safe for concurrent use, so a single cache can
+// be shared by the EVMs of many executions, e.g. when simulating transactions
+// calling the same contracts, sparing the analysis of their code each time.
+type JumpDestCache struct {
+	cache *lru.SizeConstrainedCache[common.Hash, bitvec]
+}
+
+// NewJumpDestCache creates a JUMPDEST analysis cache holding up to maxSize bytes
+// of analysis, which is about an eighth of the size of the analyzed code.
+func NewJumpDestCache(maxSize uint64) *JumpDestCache {
+	return &JumpDestCache{cache: lru.NewSizeConstrainedCache[common.Hash, bitvec](maxSize)}
+}
+
+// analysis returns the JUMPDEST analysis of the code with the given hash,
+// analyzing and caching it if not yet known.
+func (c *JumpDestCache) analysis(hash common.Hash, code []byte) bitvec {
+	if analysis, ok := c.cache.Get(hash); ok {
+		return analysis
+	}
+	analysis := codeBitmap(code)
+	c.cache.Add(hash, analysis)
+	return analysis
+}
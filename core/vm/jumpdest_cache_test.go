@@ -0,0 +1,115 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// jumpingCode returns a contract of roughly the maximum code size, jumping over
+// its PUSH32 filled body to a JUMPDEST at the end.
+func jumpingCode() []byte {
+	code := []byte{byte(PUSH2), 0, 0, byte(JUMP)}
+	for len(code) < params.MaxCodeSize-34 {
+		code = append(code, byte(PUSH32))
+		code = append(code, make([]byte, 32)...)
+	}
+	code[1], code[2] = byte(len(code)>>8), byte(len(code))
+	return append(code, byte(JUMPDEST), byte(STOP))
+}
+
+// newJumpingState creates a state containing the jumping contract at addr.
+func newJumpingState(addr common.Address, code []byte) *state.StateDB {
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetCode(addr, code)
+	statedb.Finalise(true)
+	return statedb
+}
+
+// newJumpingContext creates a block context for calling the jumping contract.
+func newJumpingContext() BlockContext {
+	return BlockContext{
+		CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+		BlockNumber: new(big.Int),
+		Random:      &common.Hash{},
+	}
+}
+
+func TestJumpDestCache(t *testing.T) {
+	var (
+		addr    = common.BytesToAddress([]byte("contract"))
+		code    = jumpingCode()
+		statedb = newJumpingState(addr, code)
+		cache   = NewJumpDestCache(1024 * 1024)
+		vmctx   = newJumpingContext()
+	)
+	for i := 0; i < 2; i++ {
+		evm := NewEVM(vmctx, statedb, params.MergedTestChainConfig, Config{JumpDestCache: cache})
+		if _, _, err := evm.Call(common.Address{}, addr, nil, 100000, new(uint256.Int)); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+		analysis, ok := cache.cache.Get(crypto.Keccak256Hash(code))
+		if !ok {
+			t.Fatalf("call %d: analysis not cached", i)
+		}
+		// The analysis of the second execution must be the cached one
+		if i == 1 && &analysis[0] != &evm.jumpDests[crypto.Keccak256Hash(code)][0] {
+			t.Fatal("cached analysis not reused")
+		}
+	}
+	// Analysing other code beyond the size of the cache evicts the analysis
+	var (
+		other = append(jumpingCode(), byte(STOP))
+		small = NewJumpDestCache(uint64(len(codeBitmap(code))))
+	)
+	small.analysis(crypto.Keccak256Hash(code), code)
+	small.analysis(crypto.Keccak256Hash(other), other)
+	if _, ok := small.cache.Get(crypto.Keccak256Hash(code)); ok {
+		t.Fatal("analysis cached beyond the size limit")
+	}
+}
+
+// BenchmarkJumpDestCache simulates 10k calls to the same contract, each in a
+// fresh EVM, with and without sharing the JUMPDEST analysis.
+func BenchmarkJumpDestCache(b *testing.B) {
+	var (
+		addr    = common.BytesToAddress([]byte("contract"))
+		statedb = newJumpingState(addr, jumpingCode())
+		vmctx   = newJumpingContext()
+	)
+	bench := func(b *testing.B, cache *JumpDestCache) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < 10000; j++ {
+				evm := NewEVM(vmctx, statedb, params.MergedTestChainConfig, Config{JumpDestCache: cache})
+				if _, _, err := evm.Call(common.Address{}, addr, nil, 100000, new(uint256.Int)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	b.Run("uncached", func(b *testing.B) { bench(b, nil) })
+	b.Run("cached", func(b *testing.B) { bench(b, NewJumpDestCache(1024*1024)) })
+}
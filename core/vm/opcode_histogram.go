@@ -0,0 +1,63 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import "sync/atomic"
+
+// OpcodeHistogram counts the executed opcodes. Opcodes undefined in the active
+// fork are counted in a single catch-all bucket instead of their own. It's safe
+// for concurrent use, so a single histogram can be shared by multiple EVMs.
+type OpcodeHistogram struct {
+	counts    [256]atomic.Uint64
+	undefined atomic.Uint64
+}
+
+// NewOpcodeHistogram creates an empty opcode histogram.
+func NewOpcodeHistogram() *OpcodeHistogram {
+	return new(OpcodeHistogram)
+}
+
+// record accounts an execution of the opcode.
+func (h *OpcodeHistogram) record(op OpCode, undefined bool) {
+	if undefined {
+		h.undefined.Add(1)
+		return
+	}
+	h.counts[op].Add(1)
+}
+
+// Counts returns the number of executions of each defined opcode so far.
+func (h *OpcodeHistogram) Counts() [256]uint64 {
+	var counts [256]uint64
+	for i := range h.counts {
+		counts[i] = h.counts[i].Load()
+	}
+	return counts
+}
+
+// Undefined returns the number of executions of undefined opcodes so far.
+func (h *OpcodeHistogram) Undefined() uint64 {
+	return h.undefined.Load()
+}
+
+// Reset discards the counts collected so far.
+func (h *OpcodeHistogram) Reset() {
+	for i := range h.counts {
+		h.counts[i].Store(0)
+	}
+	h.undefined.Store(0)
+}
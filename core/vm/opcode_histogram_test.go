@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestOpcodeHistogram(t *testing.T) {
+	var (
+		caller   = common.BytesToAddress([]byte("caller"))
+		contract = common.BytesToAddress([]byte("contract"))
+		vmctx    = BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int),
+		}
+		hist = NewOpcodeHistogram()
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetCode(contract, []byte{byte(PUSH1), 1, byte(PUSH1), 2, byte(ADD), byte(POP), 0x0c})
+	evm := NewEVM(vmctx, statedb, params.TestChainConfig, Config{OpcodeHistogram: hist})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := evm.Call(caller, contract, nil, 100000, new(uint256.Int)); err == nil {
+			t.Fatal("undefined opcode executed successfully")
+		}
+	}
+	var want [256]uint64
+	want[PUSH1], want[ADD], want[POP] = 4, 2, 2
+	if have := hist.Counts(); have != want {
+		t.Fatalf("counts mismatch: have %v, want %v", have, want)
+	}
+	if have := hist.Undefined(); have != 2 {
+		t.Fatalf("undefined count mismatch: have %d, want 2", have)
+	}
+	hist.Reset()
+	if hist.Counts() != ([256]uint64{}) || hist.Undefined() != 0 {
+		t.Fatal("histogram not reset")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PrecompileStat is the usage of a single precompiled contract.
+type PrecompileStat struct {
+	Calls uint64 // Number of invocations, including the failed ones
+	Gas   uint64 // Total gas consumed by the invocations
+}
+
+// PrecompileStats collects the usage of the precompiled contracts. It's safe for
+// concurrent use, so a single collector can be shared by the EVMs executing the
+// transactions of a whole block.
+type PrecompileStats struct {
+	lock  sync.Mutex
+	stats map[common.Address]PrecompileStat
+}
+
+// NewPrecompileStats creates an empty precompile usage collector.
+func NewPrecompileStats() *PrecompileStats {
+	return &PrecompileStats{stats: make(map[common.Address]PrecompileStat)}
+}
+
+// record accounts an invocation of the precompiled contract at addr.
+func (s *PrecompileStats) record(addr common.Address, gas uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stat := s.stats[addr]
+	stat.Calls++
+	stat.Gas += gas
+	s.stats[addr] = stat
+}
+
+// Stats returns the usage collected so far, keyed by precompile address.
+func (s *PrecompileStats) Stats() map[common.Address]PrecompileStat {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return maps.Clone(s.stats)
+}
+
+// Reset discards the usage collected so far.
+func (s *PrecompileStats) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	clear(s.stats)
+}
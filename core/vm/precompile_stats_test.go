@@ -0,0 +1,69 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"maps"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestPrecompileStats(t *testing.T) {
+	var (
+		caller    = common.BytesToAddress([]byte("caller"))
+		ecrecover = common.BytesToAddress([]byte{0x01})
+		sha256    = common.BytesToAddress([]byte{0x02})
+		input     = make([]byte, 32)
+		vmctx     = BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int),
+		}
+		stats = NewPrecompileStats()
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	evm := NewEVM(vmctx, statedb, params.TestChainConfig, Config{PrecompileStats: stats})
+
+	if _, _, err := evm.Call(caller, sha256, input, 1000, new(uint256.Int)); err != nil {
+		t.Fatalf("sha256 call failed: %v", err)
+	}
+	if _, _, err := evm.StaticCall(caller, sha256, input, 1000); err != nil {
+		t.Fatalf("sha256 static call failed: %v", err)
+	}
+	// Invocations running out of gas consume all of it
+	if _, _, err := evm.Call(caller, ecrecover, input, 100, new(uint256.Int)); err != ErrOutOfGas {
+		t.Fatalf("ecrecover call error mismatch: have %v, want %v", err, ErrOutOfGas)
+	}
+	sha256Gas := params.Sha256BaseGas + params.Sha256PerWordGas
+	want := map[common.Address]PrecompileStat{
+		ecrecover: {Calls: 1, Gas: 100},
+		sha256:    {Calls: 2, Gas: 2 * sha256Gas},
+	}
+	if have := stats.Stats(); !maps.Equal(have, want) {
+		t.Fatalf("stats mismatch: have %v, want %v", have, want)
+	}
+	stats.Reset()
+	if have := stats.Stats(); len(have) != 0 {
+		t.Fatalf("stats not reset: %v", have)
+	}
+}
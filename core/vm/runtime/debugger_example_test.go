@@ -0,0 +1,79 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package runtime_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+)
+
+// stepper is a debugger pausing at a breakpoint, from where the host steps
+// through the instructions until it lets the execution continue.
+type stepper struct {
+	breakpoint uint64
+	paused     bool
+
+	states chan string // State of the paused call frame, sent to the host
+	resume chan bool   // Whether to pause at the next instruction, sent by the host
+}
+
+func (s *stepper) Step(pc uint64, op vm.OpCode, depth int, scope *vm.ScopeContext, state vm.StateDB) error {
+	if !s.paused && pc != s.breakpoint {
+		return nil
+	}
+	var stack []string
+	for _, item := range scope.StackData() {
+		stack = append(stack, item.Dec())
+	}
+	slot := state.GetState(scope.Address(), common.Hash{})
+	s.states <- fmt.Sprintf("pc=%d op=%v stack=[%s] slot0=%d", pc, op, strings.Join(stack, " "), slot.Big())
+
+	s.paused = <-s.resume
+	return nil
+}
+
+func ExampleConfig_debugger() {
+	// PUSH1 1, PUSH1 2, ADD, PUSH1 0, SSTORE, STOP
+	code := common.Hex2Bytes("600160020160005500")
+
+	dbg := &stepper{breakpoint: 4, states: make(chan string), resume: make(chan bool)}
+	done := make(chan error)
+	go func() {
+		_, _, err := runtime.Execute(code, nil, &runtime.Config{EVMConfig: vm.Config{Debugger: dbg}})
+		close(dbg.states)
+		done <- err
+	}()
+	// Step through three instructions from the breakpoint, then continue
+	steps := 0
+	for state := range dbg.states {
+		fmt.Println(state)
+		steps++
+		dbg.resume <- steps < 4
+	}
+	if err := <-done; err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// pc=4 op=ADD stack=[1 2] slot0=0
+	// pc=5 op=PUSH1 stack=[3] slot0=0
+	// pc=7 op=SSTORE stack=[3 0] slot0=0
+	// pc=8 op=STOP stack=[] slot0=3
+}
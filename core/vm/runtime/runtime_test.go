@@ -38,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 
 	// force-load js tracers to trigger registration
 	_ "github.com/ethereum/go-ethereum/eth/tracers/js"
@@ -950,3 +951,46 @@ func TestDelegatedAccountAccessCost(t *testing.T) {
 		}
 	}
 }
+
+func TestPrevRandao(t *testing.T) {
+	code := []byte{
+		byte(vm.PREVRANDAO),
+		byte(vm.PUSH1), 0,
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH1), 0,
+		byte(vm.RETURN),
+	}
+	// The configured randomness is returned post-merge
+	random := common.HexToHash("0xdeadbeef")
+	ret, _, err := Execute(code, nil, &Config{Random: &random, Difficulty: big.NewInt(7)})
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if common.BytesToHash(ret) != random {
+		t.Errorf("wrong randomness: have %x, want %x", ret, random)
+	}
+	// The default randomness is zero
+	ret, _, err = Execute(code, nil, nil)
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if common.BytesToHash(ret) != (common.Hash{}) {
+		t.Errorf("wrong default randomness: have %x", ret)
+	}
+	// Without randomness the block is pre-merge, returning the difficulty
+	cfg := &Config{Difficulty: big.NewInt(7)}
+	setDefaults(cfg)
+	cfg.Random = nil
+	cfg.State, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	address := common.HexToAddress("0xaa")
+	cfg.State.SetCode(address, code)
+
+	ret, _, err = NewEnv(cfg).Call(cfg.Origin, address, nil, cfg.GasLimit, new(uint256.Int))
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if have := new(big.Int).SetBytes(ret); have.Cmp(cfg.Difficulty) != 0 {
+		t.Errorf("wrong difficulty: have %v, want %v", have, cfg.Difficulty)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -265,6 +266,29 @@ func storageRangeAt(statedb *state.StateDB, root common.Hash, address common.Add
 	return result, nil
 }
 
+// SimulateBundle executes the given signed transactions sequentially against the
+// state of a block after its first txIndex transactions, returning the result of
+// each of them along with the balance change of the coinbase. The state can be
+// modified beforehand by the optional overrides.
+func (api *DebugAPI) SimulateBundle(ctx context.Context, txs []hexutil.Bytes, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, overrides *override.StateOverride) (*BundleResult, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %v not found", blockNrOrHash)
+	}
+	bundle := make(types.Transactions, len(txs))
+	for i, encoded := range txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(encoded); err != nil {
+			return nil, fmt.Errorf("bundle transaction %d: %w", i, err)
+		}
+		bundle[i] = tx
+	}
+	return api.eth.SimulateBundle(ctx, block, int(txIndex), bundle, overrides)
+}
+
 // GetModifiedAccountsByNumber returns all accounts that have changed between the
 // two blocks specified. A change is defined as a difference in nonce, balance,
 // code hash, or storage hash.
@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+)
+
+// errEmptyBundle is returned if a bundle without any transactions is simulated.
+var errEmptyBundle = errors.New("empty bundle")
+
+// bundleReexec is the number of blocks the bundle simulation is willing to go
+// back and reexecute to produce missing historical state, matching the tracers.
+const bundleReexec = uint64(128)
+
+// BundleTxResult is the outcome of a single transaction of a simulated bundle.
+type BundleTxResult struct {
+	TxHash       common.Hash    `json:"txHash"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	ReturnValue  hexutil.Bytes  `json:"returnValue,omitempty"`
+	Error        string         `json:"error,omitempty"`  // Execution error, including reverts
+	Revert       hexutil.Bytes  `json:"revert,omitempty"` // Revert reason, if any
+	CoinbaseDiff *hexutil.Big   `json:"coinbaseDiff"`     // Balance change of the block coinbase
+}
+
+// BundleResult is the outcome of a simulated bundle.
+type BundleResult struct {
+	Results      []BundleTxResult `json:"results"`
+	GasUsed      hexutil.Uint64   `json:"gasUsed"`
+	CoinbaseDiff *hexutil.Big     `json:"coinbaseDiff"`
+	StateBlock   common.Hash      `json:"stateBlockHash"`
+	StateIndex   hexutil.Uint     `json:"stateTxIndex"` // Number of block transactions executed before the bundle
+}
+
+// SimulateBundle executes an ordered list of transactions in the environment of
+// the given block, on top of the state after its first txIndex transactions (0
+// being the top of the block and the number of its transactions the end of it).
+// The state overrides, if any, are applied before executing the bundle.
+//
+// The bundle is atomic: if any of its transactions can't be included in the
+// block, e.g. for an invalid nonce or insufficient funds, the simulation fails.
+// Transactions reverting during execution are reported in their results.
+func (eth *Ethereum) SimulateBundle(ctx context.Context, block *types.Block, txIndex int, txs types.Transactions, overrides *override.StateOverride) (*BundleResult, error) {
+	if len(txs) == 0 {
+		return nil, errEmptyBundle
+	}
+	if txIndex < 0 || txIndex > len(block.Transactions()) {
+		return nil, fmt.Errorf("transaction index %d out of range for block %#x", txIndex, block.Hash())
+	}
+	var (
+		statedb *state.StateDB
+		release tracers.StateReleaseFunc
+		err     error
+	)
+	if txIndex == len(block.Transactions()) && txIndex > 0 {
+		statedb, release, err = eth.stateAtBlock(ctx, block, bundleReexec, nil, true, false)
+	} else {
+		_, _, statedb, release, err = eth.stateAtTransaction(ctx, block, txIndex, bundleReexec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// The bundle only gets the gas left by the preceding block transactions
+	used, err := eth.gasUsedBefore(block, txIndex)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config = eth.blockchain.Config()
+		header = block.Header()
+		vmctx  = core.NewEVMBlockContext(header, eth.blockchain, nil)
+		rules  = config.Rules(vmctx.BlockNumber, vmctx.Random != nil, vmctx.Time)
+		signer = types.MakeSigner(config, block.Number(), block.Time())
+		evm    = vm.NewEVM(vmctx, statedb, config, vm.Config{})
+		gp     = new(core.GasPool).AddGas(block.GasLimit() - used)
+	)
+	precompiles := vm.ActivePrecompiledContracts(rules)
+	if err := overrides.Apply(statedb, precompiles); err != nil {
+		return nil, err
+	}
+	evm.SetPrecompiles(precompiles)
+
+	// Measure the coinbase balance after the overrides, which the bundle doesn't pay
+	initial := statedb.GetBalance(vmctx.Coinbase).ToBig()
+
+	result := &BundleResult{
+		Results:    make([]BundleTxResult, 0, len(txs)),
+		StateBlock: block.Hash(),
+		StateIndex: hexutil.Uint(txIndex),
+	}
+	for i, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("bundle transaction %d (%#x): %w", i, tx.Hash(), err)
+		}
+		before := statedb.GetBalance(vmctx.Coinbase).ToBig()
+
+		statedb.SetTxContext(tx.Hash(), txIndex+i)
+		res, err := core.ApplyMessage(evm, msg, gp)
+		if err != nil {
+			return nil, fmt.Errorf("bundle transaction %d (%#x): %w", i, tx.Hash(), err)
+		}
+		statedb.Finalise(rules.IsEIP158)
+
+		txResult := BundleTxResult{
+			TxHash:       tx.Hash(),
+			GasUsed:      hexutil.Uint64(res.UsedGas),
+			ReturnValue:  res.Return(),
+			Revert:       res.Revert(),
+			CoinbaseDiff: (*hexutil.Big)(new(big.Int).Sub(statedb.GetBalance(vmctx.Coinbase).ToBig(), before)),
+		}
+		if res.Err != nil {
+			txResult.Error = res.Err.Error()
+		}
+		result.Results = append(result.Results, txResult)
+		result.GasUsed += txResult.GasUsed
+	}
+	result.CoinbaseDiff = (*hexutil.Big)(new(big.Int).Sub(statedb.GetBalance(vmctx.Coinbase).ToBig(), initial))
+	return result, nil
+}
+
+// gasUsedBefore returns the gas used by the first txIndex transactions of the block.
+func (eth *Ethereum) gasUsedBefore(block *types.Block, txIndex int) (uint64, error) {
+	switch txIndex {
+	case 0:
+		return 0, nil
+	case len(block.Transactions()):
+		return block.GasUsed(), nil
+	}
+	receipts := eth.blockchain.GetReceiptsByHash(block.Hash())
+	if len(receipts) != len(block.Transactions()) {
+		return 0, fmt.Errorf("missing receipts of block %#x", block.Hash())
+	}
+	return receipts[txIndex-1].CumulativeGasUsed, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSimulateBundle(t *testing.T) {
+	var (
+		key, _      = crypto.GenerateKey()
+		sender      = crypto.PubkeyToAddress(key.PublicKey)
+		otherKey, _ = crypto.GenerateKey()
+		other       = crypto.PubkeyToAddress(otherKey.PublicKey)
+		coinbase    = common.Address{0xc0}
+		recipient   = common.Address{0xaa}
+		reverter    = common.Address{0xee}
+		gspec       = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:   {Balance: big.NewInt(params.Ether)},
+				reverter: {Code: []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT)}},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		tip    = big.NewInt(2 * params.GWei)
+	)
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, baseFee *big.Int) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       100000,
+			GasTipCap: tip,
+			GasFeeCap: new(big.Int).Add(baseFee, tip),
+			Value:     big.NewInt(1),
+		})
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(coinbase)
+		b.AddTx(newTx(key, 0, recipient, b.BaseFee()))
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	var (
+		eth     = &Ethereum{blockchain: chain}
+		block   = blocks[0]
+		baseFee = block.BaseFee()
+	)
+	// Simulate a reverting and a successful transaction at the end of the block
+	bundle := types.Transactions{newTx(key, 1, reverter, baseFee), newTx(key, 2, recipient, baseFee)}
+	result, err := eth.SimulateBundle(context.Background(), block, 1, bundle, nil)
+	if err != nil {
+		t.Fatalf("failed to simulate bundle: %v", err)
+	}
+	if len(result.Results) != 2 {
+		t.Fatalf("result count mismatch: have %d, want 2", len(result.Results))
+	}
+	if result.Results[0].Error != vm.ErrExecutionReverted.Error() || result.Results[1].Error != "" {
+		t.Fatalf("execution errors mismatch: have %q, %q", result.Results[0].Error, result.Results[1].Error)
+	}
+	var total big.Int
+	for i, res := range result.Results {
+		want := new(big.Int).Mul(tip, new(big.Int).SetUint64(uint64(res.GasUsed)))
+		if res.CoinbaseDiff.ToInt().Cmp(want) != 0 {
+			t.Errorf("tx %d: coinbase diff mismatch: have %v, want %v", i, res.CoinbaseDiff, want)
+		}
+		total.Add(&total, want)
+	}
+	if result.CoinbaseDiff.ToInt().Cmp(&total) != 0 {
+		t.Errorf("total coinbase diff mismatch: have %v, want %v", result.CoinbaseDiff, &total)
+	}
+	if uint64(result.GasUsed) != uint64(result.Results[0].GasUsed+result.Results[1].GasUsed) {
+		t.Errorf("gas used mismatch: have %d", result.GasUsed)
+	}
+	// The same bundle at the top of the block misses the first block transaction
+	if _, err := eth.SimulateBundle(context.Background(), block, 0, bundle, nil); !errors.Is(err, core.ErrNonceTooHigh) {
+		t.Fatalf("top of block error mismatch: have %v, want %v", err, core.ErrNonceTooHigh)
+	}
+	// Transactions of unfunded accounts fail, unless overridden
+	bundle = types.Transactions{newTx(otherKey, 0, recipient, baseFee)}
+	if _, err := eth.SimulateBundle(context.Background(), block, 0, bundle, nil); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("unfunded error mismatch: have %v, want %v", err, core.ErrInsufficientFunds)
+	}
+	overrides := override.StateOverride{other: {Balance: (*hexutil.Big)(big.NewInt(params.Ether))}}
+	if _, err := eth.SimulateBundle(context.Background(), block, 0, bundle, &overrides); err != nil {
+		t.Fatalf("failed to simulate overridden bundle: %v", err)
+	}
+	// Coinbase balance overrides are not accounted as paid by the bundle
+	bundle = types.Transactions{newTx(key, 1, recipient, baseFee)}
+	overrides = override.StateOverride{coinbase: {Balance: (*hexutil.Big)(big.NewInt(params.Ether))}}
+	result, err = eth.SimulateBundle(context.Background(), block, 1, bundle, &overrides)
+	if err != nil {
+		t.Fatalf("failed to simulate bundle with coinbase override: %v", err)
+	}
+	if want := new(big.Int).Mul(tip, new(big.Int).SetUint64(uint64(result.GasUsed))); result.CoinbaseDiff.ToInt().Cmp(want) != 0 {
+		t.Errorf("overridden coinbase diff mismatch: have %v, want %v", result.CoinbaseDiff, want)
+	}
+	// The bundle only gets the gas left by the preceding block transactions
+	greedy := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   gspec.Config.ChainID,
+		Nonce:     1,
+		To:        &recipient,
+		Gas:       block.GasLimit() - block.GasUsed() + 1,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(baseFee, tip),
+	})
+	if _, err := eth.SimulateBundle(context.Background(), block, 1, types.Transactions{greedy}, nil); !errors.Is(err, core.ErrGasLimitReached) {
+		t.Fatalf("gas limit error mismatch: have %v, want %v", err, core.ErrGasLimitReached)
+	}
+	// Out of range positions are rejected
+	if _, err := eth.SimulateBundle(context.Background(), block, 2, bundle, nil); err == nil {
+		t.Fatal("out of range transaction index accepted")
+	}
+}
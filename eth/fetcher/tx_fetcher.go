@@ -141,10 +141,10 @@ type txRequest struct {
 // txDelivery is the notification that a batch of transactions have been added
 // to the pool and should be untracked.
 type txDelivery struct {
-	origin string        // Identifier of the peer originating the notification
-	hashes []common.Hash // Batch of transaction hashes having been delivered
-	metas  []txMetadata  // Batch of metadata associated with the delivered hashes
-	direct bool          // Whether this is a direct reply or a broadcast
+	origin string               // Identifier of the peer originating the notification
+	hashes []common.Hash        // Batch of transaction hashes having been delivered
+	txs    []*types.Transaction // Batch of transactions associated with the delivered hashes
+	direct bool                 // Whether this is a direct reply or a broadcast
 }
 
 // txDrop is the notification that a peer has disconnected.
@@ -323,8 +323,8 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 	// Push all the transactions into the pool, tracking underpriced ones to avoid
 	// re-requesting them and dropping the peer in case of malicious transfers.
 	var (
-		added = make([]common.Hash, 0, len(txs))
-		metas = make([]txMetadata, 0, len(txs))
+		added     = make([]common.Hash, 0, len(txs))
+		delivered = make([]*types.Transaction, 0, len(txs))
 	)
 	// proceed in batches
 	for i := 0; i < len(txs); i += 128 {
@@ -360,10 +360,7 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 				otherreject++
 			}
 			added = append(added, batch[j].Hash())
-			metas = append(metas, txMetadata{
-				kind: batch[j].Type(),
-				size: uint32(batch[j].Size()),
-			})
+			delivered = append(delivered, batch[j])
 		}
 		knownMeter.Mark(duplicate)
 		underpricedMeter.Mark(underpriced)
@@ -376,7 +373,7 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		}
 	}
 	select {
-	case f.cleanup <- &txDelivery{origin: peer, hashes: added, metas: metas, direct: direct}:
+	case f.cleanup <- &txDelivery{origin: peer, hashes: added, txs: delivered, direct: direct}:
 		return nil
 	case <-f.quit:
 		return errTerminated
@@ -647,20 +644,9 @@ func (f *TxFetcher) loop() {
 				if _, ok := f.waitlist[hash]; ok {
 					for peer, txset := range f.waitslots {
 						if meta := txset[hash]; meta != nil {
-							if delivery.metas[i].kind != meta.kind {
-								log.Warn("Announced transaction type mismatch", "peer", peer, "tx", hash, "type", delivery.metas[i].kind, "ann", meta.kind)
+							if err := checkAnnounced(delivery.txs[i], meta.txMetadata); err != nil {
+								log.Warn("Delivered transaction mismatches announcement", "peer", peer, "tx", hash, "err", err)
 								f.dropPeer(peer)
-							} else if delivery.metas[i].size != meta.size {
-								if math.Abs(float64(delivery.metas[i].size)-float64(meta.size)) > 8 {
-									log.Warn("Announced transaction size mismatch", "peer", peer, "tx", hash, "size", delivery.metas[i].size, "ann", meta.size)
-
-									// Normally we should drop a peer considering this is a protocol violation.
-									// However, due to the RLP vs consensus format messyness, allow a few bytes
-									// wiggle-room where we only warn, but don't drop.
-									//
-									// TODO(karalabe): Get rid of this relaxation when clients are proven stable.
-									f.dropPeer(peer)
-								}
 							}
 						}
 						delete(txset, hash)
@@ -673,20 +659,9 @@ func (f *TxFetcher) loop() {
 				} else {
 					for peer, txset := range f.announces {
 						if meta := txset[hash]; meta != nil {
-							if delivery.metas[i].kind != meta.kind {
-								log.Warn("Announced transaction type mismatch", "peer", peer, "tx", hash, "type", delivery.metas[i].kind, "ann", meta.kind)
+							if err := checkAnnounced(delivery.txs[i], meta.txMetadata); err != nil {
+								log.Warn("Delivered transaction mismatches announcement", "peer", peer, "tx", hash, "err", err)
 								f.dropPeer(peer)
-							} else if delivery.metas[i].size != meta.size {
-								if math.Abs(float64(delivery.metas[i].size)-float64(meta.size)) > 8 {
-									log.Warn("Announced transaction size mismatch", "peer", peer, "tx", hash, "size", delivery.metas[i].size, "ann", meta.size)
-
-									// Normally we should drop a peer considering this is a protocol violation.
-									// However, due to the RLP vs consensus format messyness, allow a few bytes
-									// wiggle-room where we only warn, but don't drop.
-									//
-									// TODO(karalabe): Get rid of this relaxation when clients are proven stable.
-									f.dropPeer(peer)
-								}
 							}
 						}
 						delete(txset, hash)
@@ -1019,6 +994,16 @@ func (f *TxFetcher) forEachAnnounce(announces map[common.Hash]*txMetadataWithSeq
 	}
 }
 
+// checkAnnounced verifies that a delivered transaction matches the type and size
+// it was announced with. The size limit is left to the pool.
+func checkAnnounced(tx *types.Transaction, meta txMetadata) error {
+	delivery := &types.PooledTransactions{
+		Announced:    []types.TxAnnouncement{{Type: meta.kind, Size: meta.size, Hash: tx.Hash()}},
+		Transactions: []*types.Transaction{tx},
+	}
+	return delivery.Validate(math.MaxUint64)
+}
+
 // rotateStrings rotates the contents of a slice by n steps. This method is only
 // used in tests to simulate random map iteration but keep it deterministic.
 func rotateStrings(slice []string, n int) {
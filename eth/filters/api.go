@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -39,6 +40,7 @@ var (
 	errInvalidBlockRange      = errors.New("invalid block range params")
 	errPendingLogsUnsupported = errors.New("pending logs are not supported")
 	errExceedMaxTopics        = errors.New("exceed max topics")
+	errExceedMaxAddresses     = errors.New("exceed max addresses")
 )
 
 // The maximum number of topic criteria allowed, vm.LOG4 - vm.LOG0
@@ -337,28 +339,9 @@ func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 
 // GetLogs returns logs matching the given argument that are stored within the state.
 func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
-	if len(crit.Topics) > maxTopics {
-		return nil, errExceedMaxTopics
-	}
-	var filter *Filter
-	if crit.BlockHash != nil {
-		// Block filter requested, construct a single-shot filter
-		filter = api.sys.NewBlockFilter(*crit.BlockHash, crit.Addresses, crit.Topics)
-	} else {
-		// Convert the RPC block numbers into internal representations
-		begin := rpc.LatestBlockNumber.Int64()
-		if crit.FromBlock != nil {
-			begin = crit.FromBlock.Int64()
-		}
-		end := rpc.LatestBlockNumber.Int64()
-		if crit.ToBlock != nil {
-			end = crit.ToBlock.Int64()
-		}
-		if begin > 0 && end > 0 && begin > end {
-			return nil, errInvalidBlockRange
-		}
-		// Construct the range filter
-		filter = api.sys.NewRangeFilter(begin, end, crit.Addresses, crit.Topics)
+	filter, err := api.sys.criteriaFilter(crit)
+	if err != nil {
+		return nil, err
 	}
 	// Run the filter and return all the logs
 	logs, err := filter.Logs(ctx)
@@ -368,6 +351,51 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*type
 	return returnLogs(logs), err
 }
 
+// LogsStream creates a subscription that delivers the logs matching the given
+// filter criteria which are stored within the state, one notification per log
+// as the blocks are scanned. Contrary to GetLogs, the results are not collected
+// upfront, which keeps the memory usage bounded for very large queries.
+//
+// A null notification is sent once the scan completes. The scan is aborted if
+// the client unsubscribes or it fails, in which case no final notification is
+// delivered.
+func (api *FilterAPI) LogsStream(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	// Validate the criteria before creating the subscription, the context of
+	// the subscribe call is canceled when it returns.
+	filter, err := api.sys.criteriaFilter(crit)
+	if err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-rpcSub.Err():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		err := filter.LogsStream(ctx, func(l *types.Log) error {
+			return notifier.Notify(rpcSub.ID, l)
+		})
+		if err != nil {
+			log.Debug("Log stream aborted", "id", rpcSub.ID, "err", err)
+			return
+		}
+		notifier.Notify(rpcSub.ID, nil)
+	}()
+
+	return rpcSub, nil
+}
+
 // UninstallFilter removes the filter with the given filter id.
 func (api *FilterAPI) UninstallFilter(id rpc.ID) bool {
 	api.filtersMu.Lock()
@@ -547,7 +575,7 @@ func (args *FilterCriteria) UnmarshalJSON(data []byte) error {
 		}
 	}
 	if len(raw.Topics) > maxTopics {
-		return errExceedMaxTopics
+		return fmt.Errorf("%w: %d topic positions given, logs have at most %d", errExceedMaxTopics, len(raw.Topics), maxTopics)
 	}
 
 	// topics is an array consisting of strings and/or arrays of strings.
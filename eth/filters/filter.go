@@ -83,6 +83,43 @@ func (sys *FilterSystem) NewBlockFilter(block common.Hash, addresses []common.Ad
 	return filter
 }
 
+// criteriaFilter creates a filter from the given RPC filter criteria.
+func (sys *FilterSystem) criteriaFilter(crit FilterCriteria) (*Filter, error) {
+	if err := sys.validateCriteria(crit.Addresses, crit.Topics); err != nil {
+		return nil, err
+	}
+	if crit.BlockHash != nil {
+		// Block filter requested, construct a single-shot filter
+		return sys.NewBlockFilter(*crit.BlockHash, crit.Addresses, crit.Topics), nil
+	}
+	// Convert the RPC block numbers into internal representations
+	begin := rpc.LatestBlockNumber.Int64()
+	if crit.FromBlock != nil {
+		begin = crit.FromBlock.Int64()
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if crit.ToBlock != nil {
+		end = crit.ToBlock.Int64()
+	}
+	if begin > 0 && end > 0 && begin > end {
+		return nil, errInvalidBlockRange
+	}
+	// Construct the range filter
+	return sys.NewRangeFilter(begin, end, crit.Addresses, crit.Topics), nil
+}
+
+// GetLogsStream searches the blockchain for the log entries matching the given
+// criteria, passing them to fn one by one as the blocks are scanned instead of
+// collecting them. If fn returns an error, the scan is aborted and the error is
+// returned.
+func (sys *FilterSystem) GetLogsStream(ctx context.Context, crit FilterCriteria, fn func(*types.Log) error) error {
+	filter, err := sys.criteriaFilter(crit)
+	if err != nil {
+		return err
+	}
+	return filter.LogsStream(ctx, fn)
+}
+
 // newFilter creates a generic filter that can either filter based on a block hash,
 // or based on range queries. The search criteria needs to be explicitly set.
 func newFilter(sys *FilterSystem, addresses []common.Address, topics [][]common.Hash) *Filter {
@@ -96,21 +133,42 @@ func newFilter(sys *FilterSystem, addresses []common.Address, topics [][]common.
 // Logs searches the blockchain for matching log entries, returning all from the
 // first block that contains matches, updating the start of the filter accordingly.
 func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
+	var logs []*types.Log
+	err := f.LogsStream(ctx, func(log *types.Log) error {
+		logs = append(logs, log)
+		return nil
+	})
+	return logs, err
+}
+
+// LogsStream searches the blockchain for matching log entries, passing them to
+// fn in order as they are found. The scan is aborted if fn returns an error,
+// which is then returned.
+func (f *Filter) LogsStream(ctx context.Context, fn func(*types.Log) error) error {
 	// If we're doing singleton block filtering, execute and return
 	if f.block != nil {
 		header, err := f.sys.backend.HeaderByHash(ctx, *f.block)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if header == nil {
-			return nil, errors.New("unknown block")
+			return errors.New("unknown block")
 		}
-		return f.blockLogs(ctx, header)
+		logs, err := f.blockLogs(ctx, header)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			if err := fn(log); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
 	// Disallow pending logs.
 	if f.begin == rpc.PendingBlockNumber.Int64() || f.end == rpc.PendingBlockNumber.Int64() {
-		return nil, errPendingLogsUnsupported
+		return errPendingLogsUnsupported
 	}
 
 	resolveSpecial := func(number int64) (int64, error) {
@@ -142,20 +200,32 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	var err error
 	// range query need to resolve the special begin/end block number
 	if f.begin, err = resolveSpecial(f.begin); err != nil {
-		return nil, err
+		return err
 	}
 	if f.end, err = resolveSpecial(f.end); err != nil {
-		return nil, err
+		return err
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	logChan, errChan := f.rangeLogsAsync(ctx)
-	var logs []*types.Log
 	for {
 		select {
 		case log := <-logChan:
-			logs = append(logs, log)
+			if err := fn(log); err != nil {
+				// Abort the scan and wait for it to terminate
+				cancel()
+				for {
+					select {
+					case <-logChan:
+					case <-errChan:
+						return err
+					}
+				}
+			}
 		case err := <-errChan:
-			return logs, err
+			return err
 		}
 	}
 }
@@ -238,7 +308,11 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64, logChan chan *type
 				return err
 			}
 			for _, log := range found {
-				logChan <- log
+				select {
+				case logChan <- log:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
 			}
 
 		case <-ctx.Done():
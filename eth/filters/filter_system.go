@@ -42,6 +42,7 @@ import (
 type Config struct {
 	LogCacheSize int           // maximum number of cached blocks (default: 32)
 	Timeout      time.Duration // how long filters stay active (default: 5min)
+	MaxAddresses int           // maximum number of addresses in a log filter (default: unlimited)
 }
 
 func (cfg Config) withDefaults() Config {
@@ -80,6 +81,19 @@ type FilterSystem struct {
 	cfg       *Config
 }
 
+// validateCriteria checks the addresses and topics of a log filter against the
+// limits of the filter system, rejecting filters which could never match or are
+// too costly to serve.
+func (sys *FilterSystem) validateCriteria(addresses []common.Address, topics [][]common.Hash) error {
+	if len(topics) > maxTopics {
+		return fmt.Errorf("%w: %d topic positions given, logs have at most %d", errExceedMaxTopics, len(topics), maxTopics)
+	}
+	if sys.cfg.MaxAddresses > 0 && len(addresses) > sys.cfg.MaxAddresses {
+		return fmt.Errorf("%w: %d addresses given, limit is %d", errExceedMaxAddresses, len(addresses), sys.cfg.MaxAddresses)
+	}
+	return nil
+}
+
 // NewFilterSystem creates a filter system.
 func NewFilterSystem(backend Backend, config Config) *FilterSystem {
 	config = config.withDefaults()
@@ -285,8 +299,8 @@ func (es *EventSystem) subscribe(sub *subscription) *Subscription {
 // given criteria to the given logs channel. Default value for the from and to
 // block is "latest". If the fromBlock > toBlock an error is returned.
 func (es *EventSystem) SubscribeLogs(crit ethereum.FilterQuery, logs chan []*types.Log) (*Subscription, error) {
-	if len(crit.Topics) > maxTopics {
-		return nil, errExceedMaxTopics
+	if err := es.sys.validateCriteria(crit.Addresses, crit.Topics); err != nil {
+		return nil, err
 	}
 	var from, to rpc.BlockNumber
 	if crit.FromBlock == nil {
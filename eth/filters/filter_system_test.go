@@ -464,6 +464,39 @@ func TestInvalidGetRangeLogsRequest(t *testing.T) {
 	}
 }
 
+// TestFilterCriteriaLimits tests that filters exceeding the topic or address
+// limits are rejected with a descriptive error.
+func TestFilterCriteriaLimits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{MaxAddresses: 2})
+		api    = NewFilterAPI(sys)
+	)
+	testCases := []struct {
+		crit FilterCriteria
+		err  error
+	}{
+		{FilterCriteria{Topics: [][]common.Hash{{}, {}, {}, {}, {}}}, errExceedMaxTopics},
+		{FilterCriteria{Addresses: []common.Address{{0x01}, {0x02}, {0x03}}}, errExceedMaxAddresses},
+		{FilterCriteria{Addresses: []common.Address{{0x01}, {0x02}}, Topics: [][]common.Hash{{}, {}, {}, {}}}, nil},
+	}
+	for i, test := range testCases {
+		id, err := api.NewFilter(test.crit)
+		if !errors.Is(err, test.err) {
+			t.Errorf("case #%d: NewFilter error mismatch: have %v, want %v", i, err, test.err)
+		}
+		if err == nil {
+			api.UninstallFilter(id)
+			continue
+		}
+		if _, err := api.GetLogs(context.Background(), test.crit); !errors.Is(err, test.err) {
+			t.Errorf("case #%d: GetLogs error mismatch: have %v, want %v", i, err, test.err)
+		}
+	}
+}
+
 // TestLogFilter tests whether log filters match the correct logs that are posted to the event feed.
 func TestLogFilter(t *testing.T) {
 	t.Parallel()
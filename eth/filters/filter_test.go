@@ -19,6 +19,7 @@ package filters
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"strings"
 	"testing"
@@ -374,6 +375,46 @@ func TestFilters(t *testing.T) {
 		}
 	}
 
+	t.Run("stream", func(t *testing.T) {
+		crit := FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())}
+		want, err := sys.NewRangeFilter(0, rpc.LatestBlockNumber.Int64(), nil, nil).Logs(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(want) < 3 {
+			t.Fatalf("not enough logs for the test: %d", len(want))
+		}
+		var logs []*types.Log
+		err = sys.GetLogsStream(context.Background(), crit, func(log *types.Log) error {
+			logs = append(logs, log)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		have, _ := json.Marshal(logs)
+		if exp, _ := json.Marshal(want); string(have) != string(exp) {
+			t.Fatalf("streamed logs mismatch, have:\n%s\nwant:\n%s", have, exp)
+		}
+		// Returning an error from the callback must abort the scan
+		var (
+			count   int
+			errStop = errors.New("stop")
+		)
+		err = sys.GetLogsStream(context.Background(), crit, func(log *types.Log) error {
+			if count++; count == 2 {
+				return errStop
+			}
+			return nil
+		})
+		if err != errStop {
+			t.Fatalf("expected callback error, got %v", err)
+		}
+		if count != 2 {
+			t.Fatalf("scan not aborted, callback invoked %d times", count)
+		}
+	})
+
 	t.Run("timeout", func(t *testing.T) {
 		f := sys.NewRangeFilter(0, rpc.LatestBlockNumber.Int64(), nil, nil)
 		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Hour))
@@ -244,21 +244,13 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks uint64, unresolvedL
 	if len(rewardPercentiles) != 0 {
 		maxFeeHistory = oracle.maxBlockHistory
 	}
-	if len(rewardPercentiles) > maxQueryLimit {
-		return common.Big0, nil, nil, nil, nil, nil, fmt.Errorf("%w: over the query limit %d", errInvalidPercentile, maxQueryLimit)
+	if err := validatePercentiles(rewardPercentiles); err != nil {
+		return common.Big0, nil, nil, nil, nil, nil, err
 	}
 	if blocks > maxFeeHistory {
 		log.Warn("Sanitizing fee history length", "requested", blocks, "truncated", maxFeeHistory)
 		blocks = maxFeeHistory
 	}
-	for i, p := range rewardPercentiles {
-		if p < 0 || p > 100 {
-			return common.Big0, nil, nil, nil, nil, nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
-		}
-		if i > 0 && p <= rewardPercentiles[i-1] {
-			return common.Big0, nil, nil, nil, nil, nil, fmt.Errorf("%w: #%d:%f >= #%d:%f", errInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
-		}
-	}
 	var (
 		pendingBlock    *types.Block
 		pendingReceipts []*types.Receipt
@@ -274,10 +266,7 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks uint64, unresolvedL
 	next.Store(oldestBlock)
 	results := make(chan *blockFees, blocks)
 
-	percentileKey := make([]byte, 8*len(rewardPercentiles))
-	for i, p := range rewardPercentiles {
-		binary.LittleEndian.PutUint64(percentileKey[i*8:(i+1)*8], math.Float64bits(p))
-	}
+	percentileKey := percentilesKey(rewardPercentiles)
 	for i := 0; i < maxBlockFetchers && i < int(blocks); i++ {
 		go func() {
 			for {
@@ -294,7 +283,7 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks uint64, unresolvedL
 					oracle.processBlock(fees, rewardPercentiles)
 					results <- fees
 				} else {
-					cacheKey := cacheKey{number: blockNumber, percentiles: string(percentileKey)}
+					cacheKey := cacheKey{number: blockNumber, percentiles: percentileKey}
 
 					if p, ok := oracle.historyCache.Get(cacheKey); ok {
 						fees.results = p
@@ -358,3 +347,117 @@ func (oracle *Oracle) FeeHistory(ctx context.Context, blocks uint64, unresolvedL
 	blobBaseFee, blobGasUsedRatio = blobBaseFee[:firstMissing+1], blobGasUsedRatio[:firstMissing]
 	return new(big.Int).SetUint64(oldestBlock), reward, baseFee, gasUsedRatio, blobBaseFee, blobGasUsedRatio, nil
 }
+
+// validatePercentiles checks that the reward percentiles are within the query
+// limit, in the [0, 100] range and sorted in strictly ascending order.
+func validatePercentiles(percentiles []float64) error {
+	if len(percentiles) > maxQueryLimit {
+		return fmt.Errorf("%w: over the query limit %d", errInvalidPercentile, maxQueryLimit)
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("%w: %f", errInvalidPercentile, p)
+		}
+		if i > 0 && p <= percentiles[i-1] {
+			return fmt.Errorf("%w: #%d:%f >= #%d:%f", errInvalidPercentile, i-1, percentiles[i-1], i, p)
+		}
+	}
+	return nil
+}
+
+// percentilesKey returns the history cache key part of the reward percentiles.
+func percentilesKey(percentiles []float64) string {
+	key := make([]byte, 8*len(percentiles))
+	for i, p := range percentiles {
+		binary.LittleEndian.PutUint64(key[i*8:(i+1)*8], math.Float64bits(p))
+	}
+	return string(key)
+}
+
+// FeeHistoryEntry is the fee history of a single block, as exported by
+// FeeHistoryRange.
+type FeeHistoryEntry struct {
+	Number           uint64
+	BaseFee          *big.Int   // Base fee of the block, zero before London
+	NextBaseFee      *big.Int   // Base fee of the next block, zero before London
+	GasUsedRatio     float64    // Ratio of the gas used to the gas limit
+	BlobBaseFee      *big.Int   // Blob base fee of the block, zero before Cancun
+	NextBlobBaseFee  *big.Int   // Blob base fee of the next block, zero before Cancun
+	BlobGasUsedRatio float64    // Ratio of the blob gas used to the max blob gas
+	Reward           []*big.Int // Effective tips at the requested percentiles, nil if unavailable
+}
+
+// FeeHistoryRange exports the fee history of the blocks in the [start, end] range
+// to fn, one entry per block in ascending order. Contrary to FeeHistory, the range
+// is not limited by the history configuration, allowing long-range analytics.
+//
+// The reward percentiles are computed the same way as by FeeHistory, sharing its
+// cache. Blocks whose body or receipts are not available, e.g. because they were
+// pruned, are still exported with their fees but without rewards.
+func (oracle *Oracle) FeeHistoryRange(ctx context.Context, start, end uint64, percentiles []float64, fn func(FeeHistoryEntry)) error {
+	if start > end {
+		return fmt.Errorf("invalid block range: start %d, end %d", start, end)
+	}
+	if err := validatePercentiles(percentiles); err != nil {
+		return err
+	}
+	key := percentilesKey(percentiles)
+	for number := start; ; number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fees, err := oracle.rangeBlockFees(ctx, number, percentiles, key)
+		if err != nil {
+			return err
+		}
+		fn(FeeHistoryEntry{
+			Number:           number,
+			BaseFee:          fees.baseFee,
+			NextBaseFee:      fees.nextBaseFee,
+			GasUsedRatio:     fees.gasUsedRatio,
+			BlobBaseFee:      fees.blobBaseFee,
+			NextBlobBaseFee:  fees.nextBlobBaseFee,
+			BlobGasUsedRatio: fees.blobGasUsedRatio,
+			Reward:           fees.reward,
+		})
+		if number == end {
+			return nil
+		}
+	}
+}
+
+// rangeBlockFees retrieves and processes the fees of a single block for
+// FeeHistoryRange, falling back to the header alone if the block body or
+// receipts are missing.
+func (oracle *Oracle) rangeBlockFees(ctx context.Context, number uint64, percentiles []float64, key string) (processedFees, error) {
+	cacheKey := cacheKey{number: number, percentiles: key}
+	if p, ok := oracle.historyCache.Get(cacheKey); ok {
+		return p, nil
+	}
+	header, err := oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return processedFees{}, err
+	}
+	if header == nil {
+		return processedFees{}, fmt.Errorf("%w: block %d", errRequestBeyondHead, number)
+	}
+	fees := &blockFees{blockNumber: number, header: header}
+	if len(percentiles) != 0 {
+		if fees.block, err = oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(number)); err != nil {
+			return processedFees{}, err
+		}
+		if fees.block != nil {
+			if fees.receipts, err = oracle.backend.GetReceipts(ctx, fees.block.Hash()); err != nil {
+				return processedFees{}, err
+			}
+		}
+		if fees.block == nil || (fees.receipts == nil && len(fees.block.Transactions()) != 0) {
+			// The rewards can't be computed, export the header fees only
+			oracle.processBlock(fees, nil)
+			return fees.results, nil
+		}
+	}
+	oracle.processBlock(fees, percentiles)
+	oracle.historyCache.Add(cacheKey, fees.results)
+	return fees.results, nil
+}
@@ -22,6 +22,8 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -95,3 +97,77 @@ func TestFeeHistory(t *testing.T) {
 		}
 	}
 }
+
+// prunedBackend is a test backend missing the receipts of some blocks.
+type prunedBackend struct {
+	*testBackend
+	pruned map[common.Hash]bool
+}
+
+func (b *prunedBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	if b.pruned[hash] {
+		return nil, nil
+	}
+	return b.testBackend.GetReceipts(ctx, hash)
+}
+
+func TestFeeHistoryRange(t *testing.T) {
+	backend := newTestBackend(t, big.NewInt(16), big.NewInt(28), false)
+	defer backend.teardown()
+
+	percentiles := []float64{0, 50, 100}
+	oracle := NewOracle(backend, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 1000}, nil)
+	first, reward, baseFee, ratio, blobBaseFee, blobRatio, err := oracle.FeeHistory(context.Background(), 20, 30, percentiles)
+	if err != nil {
+		t.Fatalf("failed to retrieve fee history: %v", err)
+	}
+	// Export the same range, with the receipts of a block pruned
+	pruned := backend.chain.GetHeaderByNumber(25).Hash()
+	oracle = NewOracle(&prunedBackend{backend, map[common.Hash]bool{pruned: true}}, Config{}, nil)
+
+	var entries []FeeHistoryEntry
+	if err := oracle.FeeHistoryRange(context.Background(), 11, 30, percentiles, func(e FeeHistoryEntry) {
+		entries = append(entries, e)
+	}); err != nil {
+		t.Fatalf("failed to export fee history: %v", err)
+	}
+	if len(entries) != 20 {
+		t.Fatalf("entry count mismatch: have %d, want 20", len(entries))
+	}
+	for i, e := range entries {
+		if e.Number != first.Uint64()+uint64(i) {
+			t.Fatalf("entry %d: number mismatch: have %d, want %d", i, e.Number, first.Uint64()+uint64(i))
+		}
+		if e.BaseFee.Cmp(baseFee[i]) != 0 || e.GasUsedRatio != ratio[i] {
+			t.Errorf("entry %d: base fee mismatch", i)
+		}
+		if e.BlobBaseFee.Cmp(blobBaseFee[i]) != 0 || e.BlobGasUsedRatio != blobRatio[i] {
+			t.Errorf("entry %d: blob base fee mismatch", i)
+		}
+		// Fee history only reports the next fees of the last block
+		if i == len(entries)-1 && (e.NextBaseFee.Cmp(baseFee[i+1]) != 0 || e.NextBlobBaseFee.Cmp(blobBaseFee[i+1]) != 0) {
+			t.Errorf("entry %d: next base fee mismatch", i)
+		}
+		if e.Number == 25 {
+			if e.Reward != nil {
+				t.Errorf("entry %d: rewards of pruned block: %v", i, e.Reward)
+			}
+			continue
+		}
+		if len(e.Reward) != len(percentiles) {
+			t.Fatalf("entry %d: reward count mismatch: have %d, want %d", i, len(e.Reward), len(percentiles))
+		}
+		for j := range e.Reward {
+			if e.Reward[j].Cmp(reward[i][j]) != 0 {
+				t.Errorf("entry %d: reward %d mismatch: have %v, want %v", i, j, e.Reward[j], reward[i][j])
+			}
+		}
+	}
+	// Ranges beyond the head and invalid percentiles are rejected
+	if err := oracle.FeeHistoryRange(context.Background(), 30, testHead+1, nil, func(FeeHistoryEntry) {}); !errors.Is(err, errRequestBeyondHead) {
+		t.Errorf("beyond head error mismatch: have %v, want %v", err, errRequestBeyondHead)
+	}
+	if err := oracle.FeeHistoryRange(context.Background(), 0, 1, []float64{50, 10}, func(FeeHistoryEntry) {}); !errors.Is(err, errInvalidPercentile) {
+		t.Errorf("percentile error mismatch: have %v, want %v", err, errInvalidPercentile)
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"context"
 	"math/big"
 	"slices"
+	"sort"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -55,6 +57,7 @@ type OracleBackend interface {
 	BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error)
 	GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error)
 	Pending() (*types.Block, types.Receipts, *state.StateDB)
+	GetPoolTransactions() (types.Transactions, error)
 	ChainConfig() *params.ChainConfig
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
 }
@@ -232,6 +235,52 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 	return new(big.Int).Set(price), nil
 }
 
+// PredictTipCap returns the marginal tip needed for a transaction to be included
+// in the next block given the current content of the transaction pool, i.e. the
+// effective tip of the pending transaction at which the pool fills the gas limit
+// when sorted by decreasing effective tip. Zero is returned if the pending pool
+// doesn't fill the next block.
+//
+// Unlike SuggestTipCap, the prediction reflects the real-time congestion. It is
+// an approximation though, as it doesn't account for the nonce ordering of the
+// transactions of an account and charges the transactions their full gas limit.
+func (oracle *Oracle) PredictTipCap(ctx context.Context) (*big.Int, error) {
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := oracle.backend.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config  = oracle.backend.ChainConfig()
+		baseFee *big.Int
+	)
+	if config.IsLondon(new(big.Int).Add(head.Number, common.Big1)) {
+		baseFee = eip1559.CalcBaseFee(config, head)
+	}
+	pending := slices.Clone(pool)
+	sort.Sort(types.TxByEffectiveTip(pending, baseFee))
+
+	var gas uint64
+	for _, tx := range pending {
+		// Transactions with a fee cap below the base fee can't be included, these
+		// are sorted last
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			break
+		}
+		if gas += tx.Gas(); gas >= head.GasLimit {
+			if tip.Cmp(oracle.maxPrice) > 0 {
+				return new(big.Int).Set(oracle.maxPrice), nil
+			}
+			return tip, nil
+		}
+	}
+	return new(big.Int), nil
+}
+
 type results struct {
 	values []*big.Int
 	err    error
@@ -43,7 +43,8 @@ const testHead = 32
 
 type testBackend struct {
 	chain   *core.BlockChain
-	pending bool // pending block available
+	pending bool               // pending block available
+	pool    types.Transactions // pending transactions of the pool
 }
 
 func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
@@ -111,6 +112,10 @@ func (b *testBackend) Pending() (*types.Block, types.Receipts, *state.StateDB) {
 	return nil, nil, nil
 }
 
+func (b *testBackend) GetPoolTransactions() (types.Transactions, error) {
+	return b.pool, nil
+}
+
 func (b *testBackend) ChainConfig() *params.ChainConfig {
 	return b.chain.Config()
 }
@@ -263,3 +268,49 @@ func TestSuggestTipCap(t *testing.T) {
 		}
 	}
 }
+
+func TestPredictTipCap(t *testing.T) {
+	backend := newTestBackend(t, big.NewInt(0), nil, false)
+	defer backend.teardown()
+
+	var (
+		head, _ = backend.HeaderByNumber(context.Background(), rpc.LatestBlockNumber)
+		feeCap  = big.NewInt(1000 * params.GWei)
+		quarter = head.GasLimit / 4
+	)
+	tx := func(gas uint64, tip int64, feeCap *big.Int) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{Gas: gas, GasTipCap: big.NewInt(tip * params.GWei), GasFeeCap: feeCap})
+	}
+	tests := []struct {
+		name string
+		pool types.Transactions
+		want *big.Int
+	}{
+		{"empty", nil, new(big.Int)},
+		{"not-full", types.Transactions{tx(quarter, 5, feeCap), tx(quarter, 3, feeCap)}, new(big.Int)},
+		{
+			"full",
+			types.Transactions{tx(quarter, 2, feeCap), tx(quarter, 8, feeCap), tx(quarter, 4, feeCap), tx(quarter, 10, feeCap), tx(quarter, 6, feeCap)},
+			big.NewInt(4 * params.GWei),
+		},
+		{
+			// The fee cap of the first transaction doesn't cover the next base fee
+			"underpriced",
+			types.Transactions{tx(head.GasLimit, 100, big.NewInt(1)), tx(quarter, 8, feeCap), tx(3*quarter, 7, feeCap)},
+			big.NewInt(7 * params.GWei),
+		},
+		{"capped", types.Transactions{tx(head.GasLimit, 600, feeCap)}, DefaultMaxPrice},
+	}
+	for _, tt := range tests {
+		backend.pool = tt.pool
+		oracle := NewOracle(backend, Config{}, nil)
+
+		have, err := oracle.PredictTipCap(context.Background())
+		if err != nil {
+			t.Fatalf("%s: failed to predict tip cap: %v", tt.name, err)
+		}
+		if have.Cmp(tt.want) != 0 {
+			t.Errorf("%s: tip cap mismatch: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
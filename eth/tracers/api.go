@@ -158,6 +158,16 @@ type TraceConfig struct {
 	// Config specific to given tracer. Note struct logger
 	// config are historically embedded in main object.
 	TracerConfig json.RawMessage
+	// WithSchemaVersion wraps the output of registered tracers along with the
+	// version of their output schema.
+	WithSchemaVersion bool
+}
+
+// versionedResult is the output of a tracer along with the version of its
+// output schema.
+type versionedResult struct {
+	SchemaVersion uint64          `json:"schemaVersion"`
+	Result        json.RawMessage `json:"result"`
 }
 
 // TraceCallConfig is the config for traceCall API. It holds one more
@@ -858,6 +868,20 @@ func containsTx(block *types.Block, hash common.Hash) bool {
 // TraceTransaction returns the structured logs created during the execution of EVM
 // and returns them as a JSON object.
 func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, error) {
+	return api.traceTransaction(ctx, hash, nil, config)
+}
+
+// TraceTransactionWithOverrides returns the structured logs created during the
+// execution of a transaction like TraceTransaction, but with the given state
+// overrides applied on top of the state the transaction is replayed on. The
+// overrides only affect the trace and are discarded afterwards.
+func (api *API) TraceTransactionWithOverrides(ctx context.Context, hash common.Hash, overrides *override.StateOverride, config *TraceConfig) (interface{}, error) {
+	return api.traceTransaction(ctx, hash, overrides, config)
+}
+
+// traceTransaction replays a mined transaction on top of its parent state with
+// the optional overrides applied, and traces it.
+func (api *API) traceTransaction(ctx context.Context, hash common.Hash, overrides *override.StateOverride, config *TraceConfig) (interface{}, error) {
 	found, _, blockHash, blockNumber, index, err := api.backend.GetTransaction(ctx, hash)
 	if err != nil {
 		return nil, ethapi.NewTxIndexingError()
@@ -883,6 +907,13 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 		return nil, err
 	}
 	defer release()
+
+	if overrides != nil {
+		rules := api.backend.ChainConfig().Rules(vmctx.BlockNumber, vmctx.Random != nil, vmctx.Time)
+		if err := overrides.Apply(statedb, vm.ActivePrecompiledContracts(rules)); err != nil {
+			return nil, err
+		}
+	}
 	msg, err := core.TransactionToMessage(tx, types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time()), block.BaseFee())
 	if err != nil {
 		return nil, err
@@ -1033,7 +1064,20 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	if err != nil {
 		return nil, fmt.Errorf("tracing failed: %w", err)
 	}
-	return tracer.GetResult()
+	res, err := tracer.GetResult()
+	if err != nil || !config.WithSchemaVersion || config.Tracer == nil {
+		return res, err
+	}
+	if version, ok := DefaultDirectory.SchemaVersion(*config.Tracer); ok {
+		return &versionedResult{SchemaVersion: version, Result: res}, nil
+	}
+	return res, nil
+}
+
+// TracerSchemaVersions returns the versions of the output schemas of the
+// registered tracers, bumped whenever the shape of their output changes.
+func (api *API) TracerSchemaVersions() map[string]uint64 {
+	return DefaultDirectory.SchemaVersions()
 }
 
 // APIs return the collection of RPC services the tracer package offers.
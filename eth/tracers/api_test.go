@@ -548,6 +548,110 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestTraceTransactionWithOverrides(t *testing.T) {
+	t.Parallel()
+
+	// The contract reverts if its first storage slot is set
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			accounts[1].addr: {Code: common.FromHex("0x600054600757005b600080fd")},
+		},
+	}
+	var target common.Hash
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			To:       &accounts[1].addr,
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		}), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	trace := func(overrides *override.StateOverride) *logger.ExecutionResult {
+		result, err := api.TraceTransactionWithOverrides(context.Background(), target, overrides, nil)
+		if err != nil {
+			t.Fatalf("failed to trace transaction: %v", err)
+		}
+		var have *logger.ExecutionResult
+		if err := json.Unmarshal(result.(json.RawMessage), &have); err != nil {
+			t.Fatalf("failed to unmarshal result: %v", err)
+		}
+		return have
+	}
+	if res := trace(nil); res.Failed {
+		t.Fatal("transaction failed without overrides")
+	}
+	res := trace(&override.StateOverride{
+		accounts[1].addr: override.OverrideAccount{StateDiff: map[common.Hash]common.Hash{{}: {0x01}}},
+	})
+	if !res.Failed {
+		t.Fatal("transaction succeeded with storage override")
+	}
+	// The overrides are not persisted across traces
+	if res := trace(nil); res.Failed {
+		t.Fatal("storage override persisted")
+	}
+}
+
+// Tests that the outputs of registered tracers are wrapped along with the version
+// of their schema on request. Not parallel to register the tracer safely.
+func TestTraceTransactionSchemaVersion(t *testing.T) {
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	var target common.Hash
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			To:       &accounts[1].addr,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+
+	DefaultDirectory.Register("versionedTracer", newStateTracer, false)
+	DefaultDirectory.SetSchemaVersion("versionedTracer", 3)
+	api := NewAPI(backend)
+
+	if version := api.TracerSchemaVersions()["versionedTracer"]; version != 3 {
+		t.Fatalf("schema version mismatch: have %d, want %d", version, 3)
+	}
+	tracer := "versionedTracer"
+	plain, err := api.TraceTransaction(context.Background(), target, &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	res, err := api.TraceTransaction(context.Background(), target, &TraceConfig{Tracer: &tracer, WithSchemaVersion: true})
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	blob, _ := json.Marshal(res)
+	if want := fmt.Sprintf(`{"schemaVersion":3,"result":%s}`, plain); string(blob) != want {
+		t.Fatalf("versioned result mismatch: have %s, want %s", blob, want)
+	}
+	// The struct logger isn't a registered tracer, its output is left as is
+	res, err = api.TraceTransaction(context.Background(), target, &TraceConfig{WithSchemaVersion: true})
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	if _, ok := res.(json.RawMessage); !ok {
+		t.Fatalf("struct logger result wrapped: %T", res)
+	}
+}
+
 func TestTraceBlock(t *testing.T) {
 	t.Parallel()
 
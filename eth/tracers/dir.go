@@ -49,8 +49,9 @@ type ctorFn func(*Context, json.RawMessage, *params.ChainConfig) (*Tracer, error
 type jsCtorFn func(string, *Context, json.RawMessage, *params.ChainConfig) (*Tracer, error)
 
 type elem struct {
-	ctor ctorFn
-	isJS bool
+	ctor    ctorFn
+	isJS    bool
+	version uint64 // Version of the output schema, bumped on shape changes
 }
 
 // DefaultDirectory is the collection of tracers bundled by default.
@@ -65,9 +66,37 @@ type directory struct {
 }
 
 // Register registers a method as a lookup for tracers, meaning that
-// users can invoke a named tracer through that lookup.
+// users can invoke a named tracer through that lookup. The output schema
+// of the tracer starts at version 1.
 func (d *directory) Register(name string, f ctorFn, isJS bool) {
-	d.elems[name] = elem{ctor: f, isJS: isJS}
+	d.elems[name] = elem{ctor: f, isJS: isJS, version: 1}
+}
+
+// SetSchemaVersion sets the version of the output schema of a registered
+// tracer. It must be bumped whenever the shape of the output changes, so
+// consumers of the traces can detect incompatibilities.
+func (d *directory) SetSchemaVersion(name string, version uint64) {
+	if elem, ok := d.elems[name]; ok {
+		elem.version = version
+		d.elems[name] = elem
+	}
+}
+
+// SchemaVersion returns the version of the output schema of the given tracer,
+// or false if it's not a registered one.
+func (d *directory) SchemaVersion(name string) (uint64, bool) {
+	elem, ok := d.elems[name]
+	return elem.version, ok
+}
+
+// SchemaVersions returns the versions of the output schemas of all the
+// registered tracers.
+func (d *directory) SchemaVersions() map[string]uint64 {
+	versions := make(map[string]uint64, len(d.elems))
+	for name, elem := range d.elems {
+		versions[name] = elem.version
+	}
+	return versions
 }
 
 // RegisterJSEval registers a tracer that is able to parse
@@ -74,6 +74,7 @@ func (c *callContext) toBlockContext(genesis *core.Genesis) vm.BlockContext {
 		excess := eip4844.CalcExcessBlobGas(genesis.Config, header, genesis.Timestamp)
 		header.ExcessBlobGas = &excess
 		context.BlobBaseFee = eip4844.CalcBlobFee(genesis.Config, header)
+		context.ExcessBlobGas = &excess
 	}
 	return context
 }
@@ -17,20 +17,23 @@ var _ = (*structLogMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (s StructLog) MarshalJSON() ([]byte, error) {
 	type StructLog struct {
-		Pc            uint64                      `json:"pc"`
-		Op            vm.OpCode                   `json:"op"`
-		Gas           math.HexOrDecimal64         `json:"gas"`
-		GasCost       math.HexOrDecimal64         `json:"gasCost"`
-		Memory        hexutil.Bytes               `json:"memory,omitempty"`
-		MemorySize    int                         `json:"memSize"`
-		Stack         []hexutil.U256              `json:"stack"`
-		ReturnData    hexutil.Bytes               `json:"returnData,omitempty"`
-		Storage       map[common.Hash]common.Hash `json:"-"`
-		Depth         int                         `json:"depth"`
-		RefundCounter uint64                      `json:"refund"`
-		Err           error                       `json:"-"`
-		OpName        string                      `json:"opName"`
-		ErrorString   string                      `json:"error,omitempty"`
+		Pc               uint64                      `json:"pc"`
+		Op               vm.OpCode                   `json:"op"`
+		Gas              math.HexOrDecimal64         `json:"gas"`
+		GasCost          math.HexOrDecimal64         `json:"gasCost"`
+		Memory           hexutil.Bytes               `json:"memory,omitempty"`
+		MemorySize       int                         `json:"memSize"`
+		Stack            []hexutil.U256              `json:"stack"`
+		ReturnData       hexutil.Bytes               `json:"returnData,omitempty"`
+		Storage          map[common.Hash]common.Hash `json:"-"`
+		TransientStorage map[common.Hash]common.Hash `json:"-"`
+		Depth            int                         `json:"depth"`
+		RefundCounter    uint64                      `json:"refund"`
+		CallGas          *math.HexOrDecimal64        `json:"callGas,omitempty"`
+		CallStipend      bool                        `json:"callStipend,omitempty"`
+		Err              error                       `json:"-"`
+		OpName           string                      `json:"opName"`
+		ErrorString      string                      `json:"error,omitempty"`
 	}
 	var enc StructLog
 	enc.Pc = s.Pc
@@ -47,8 +50,11 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 	}
 	enc.ReturnData = s.ReturnData
 	enc.Storage = s.Storage
+	enc.TransientStorage = s.TransientStorage
 	enc.Depth = s.Depth
 	enc.RefundCounter = s.RefundCounter
+	enc.CallGas = (*math.HexOrDecimal64)(s.CallGas)
+	enc.CallStipend = s.CallStipend
 	enc.Err = s.Err
 	enc.OpName = s.OpName()
 	enc.ErrorString = s.ErrorString()
@@ -58,18 +64,21 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (s *StructLog) UnmarshalJSON(input []byte) error {
 	type StructLog struct {
-		Pc            *uint64                     `json:"pc"`
-		Op            *vm.OpCode                  `json:"op"`
-		Gas           *math.HexOrDecimal64        `json:"gas"`
-		GasCost       *math.HexOrDecimal64        `json:"gasCost"`
-		Memory        *hexutil.Bytes              `json:"memory,omitempty"`
-		MemorySize    *int                        `json:"memSize"`
-		Stack         []hexutil.U256              `json:"stack"`
-		ReturnData    *hexutil.Bytes              `json:"returnData,omitempty"`
-		Storage       map[common.Hash]common.Hash `json:"-"`
-		Depth         *int                        `json:"depth"`
-		RefundCounter *uint64                     `json:"refund"`
-		Err           error                       `json:"-"`
+		Pc               *uint64                     `json:"pc"`
+		Op               *vm.OpCode                  `json:"op"`
+		Gas              *math.HexOrDecimal64        `json:"gas"`
+		GasCost          *math.HexOrDecimal64        `json:"gasCost"`
+		Memory           *hexutil.Bytes              `json:"memory,omitempty"`
+		MemorySize       *int                        `json:"memSize"`
+		Stack            []hexutil.U256              `json:"stack"`
+		ReturnData       *hexutil.Bytes              `json:"returnData,omitempty"`
+		Storage          map[common.Hash]common.Hash `json:"-"`
+		TransientStorage map[common.Hash]common.Hash `json:"-"`
+		Depth            *int                        `json:"depth"`
+		RefundCounter    *uint64                     `json:"refund"`
+		CallGas          *math.HexOrDecimal64        `json:"callGas,omitempty"`
+		CallStipend      *bool                       `json:"callStipend,omitempty"`
+		Err              error                       `json:"-"`
 	}
 	var dec StructLog
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -105,12 +114,21 @@ func (s *StructLog) UnmarshalJSON(input []byte) error {
 	if dec.Storage != nil {
 		s.Storage = dec.Storage
 	}
+	if dec.TransientStorage != nil {
+		s.TransientStorage = dec.TransientStorage
+	}
 	if dec.Depth != nil {
 		s.Depth = *dec.Depth
 	}
 	if dec.RefundCounter != nil {
 		s.RefundCounter = *dec.RefundCounter
 	}
+	if dec.CallGas != nil {
+		s.CallGas = (*uint64)(dec.CallGas)
+	}
+	if dec.CallStipend != nil {
+		s.CallStipend = *dec.CallStipend
+	}
 	if dec.Err != nil {
 		s.Err = dec.Err
 	}
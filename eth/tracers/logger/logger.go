@@ -42,11 +42,13 @@ type Storage map[common.Hash]common.Hash
 
 // Config are the configuration options for structured logger the EVM
 type Config struct {
-	EnableMemory     bool // enable memory capture
-	DisableStack     bool // disable stack capture
-	DisableStorage   bool // disable storage capture
-	EnableReturnData bool // enable return data capture
-	Limit            int  // maximum size of output, but zero means unlimited
+	EnableMemory           bool // enable memory capture
+	DisableStack           bool // disable stack capture
+	DisableStorage         bool // disable storage capture
+	EnableReturnData       bool // enable return data capture
+	EnableTransientStorage bool // enable transient storage capture
+	EnableCallGas          bool // enable capture of the gas forwarded to calls
+	Limit                  int  // maximum size of output, but zero means unlimited
 	// Chain overrides, can be used to execute a trace using future fork rules
 	Overrides *params.ChainConfig `json:"overrides,omitempty"`
 }
@@ -56,18 +58,21 @@ type Config struct {
 // StructLog is emitted to the EVM each cycle and lists information about the
 // current internal state prior to the execution of the statement.
 type StructLog struct {
-	Pc            uint64                      `json:"pc"`
-	Op            vm.OpCode                   `json:"op"`
-	Gas           uint64                      `json:"gas"`
-	GasCost       uint64                      `json:"gasCost"`
-	Memory        []byte                      `json:"memory,omitempty"`
-	MemorySize    int                         `json:"memSize"`
-	Stack         []uint256.Int               `json:"stack"`
-	ReturnData    []byte                      `json:"returnData,omitempty"`
-	Storage       map[common.Hash]common.Hash `json:"-"`
-	Depth         int                         `json:"depth"`
-	RefundCounter uint64                      `json:"refund"`
-	Err           error                       `json:"-"`
+	Pc               uint64                      `json:"pc"`
+	Op               vm.OpCode                   `json:"op"`
+	Gas              uint64                      `json:"gas"`
+	GasCost          uint64                      `json:"gasCost"`
+	Memory           []byte                      `json:"memory,omitempty"`
+	MemorySize       int                         `json:"memSize"`
+	Stack            []uint256.Int               `json:"stack"`
+	ReturnData       []byte                      `json:"returnData,omitempty"`
+	Storage          map[common.Hash]common.Hash `json:"-"`
+	TransientStorage map[common.Hash]common.Hash `json:"-"`
+	Depth            int                         `json:"depth"`
+	RefundCounter    uint64                      `json:"refund"`
+	CallGas          *uint64                     `json:"callGas,omitempty"`
+	CallStipend      bool                        `json:"callStipend,omitempty"`
+	Err              error                       `json:"-"`
 }
 
 // overrides for gencodec
@@ -77,6 +82,7 @@ type structLogMarshaling struct {
 	Memory      hexutil.Bytes
 	ReturnData  hexutil.Bytes
 	Stack       []hexutil.U256
+	CallGas     *math.HexOrDecimal64
 	OpName      string `json:"opName"`          // adds call to OpName() in MarshalJSON
 	ErrorString string `json:"error,omitempty"` // adds call to ErrorString() in MarshalJSON
 }
@@ -97,6 +103,9 @@ func (s *StructLog) ErrorString() string {
 // WriteTo writes the human-readable log data into the supplied writer.
 func (s *StructLog) WriteTo(writer io.Writer) {
 	fmt.Fprintf(writer, "%-16spc=%08d gas=%v cost=%v", s.Op, s.Pc, s.Gas, s.GasCost)
+	if s.CallGas != nil {
+		fmt.Fprintf(writer, " callGas=%v stipend=%t", *s.CallGas, s.CallStipend)
+	}
 	if s.Err != nil {
 		fmt.Fprintf(writer, " ERROR: %v", s.Err)
 	}
@@ -118,6 +127,12 @@ func (s *StructLog) WriteTo(writer io.Writer) {
 			fmt.Fprintf(writer, "%x: %x\n", h, item)
 		}
 	}
+	if len(s.TransientStorage) > 0 {
+		fmt.Fprintln(writer, "Transient storage:")
+		for h, item := range s.TransientStorage {
+			fmt.Fprintf(writer, "%x: %x\n", h, item)
+		}
+	}
 	if len(s.ReturnData) > 0 {
 		fmt.Fprintln(writer, "ReturnData:")
 		fmt.Fprint(writer, hex.Dump(s.ReturnData))
@@ -140,20 +155,26 @@ func (s *StructLog) WriteTo(writer io.Writer) {
 // Legacy uses a list of 64-char strings, each representing 32-byte chunks
 // of evm memory. Non-legacy just uses a string of hexdata, no chunking.
 //
-// storage:
-// Legacy has a storage field while non-legacy doesn't.
+// storage, transientStorage:
+// Legacy has storage fields while non-legacy doesn't.
+//
+// callGas:
+// Legacy uses integers, non-legacy hex-strings
 type structLogLegacy struct {
-	Pc            uint64             `json:"pc"`
-	Op            string             `json:"op"`
-	Gas           uint64             `json:"gas"`
-	GasCost       uint64             `json:"gasCost"`
-	Depth         int                `json:"depth"`
-	Error         string             `json:"error,omitempty"`
-	Stack         *[]string          `json:"stack,omitempty"`
-	ReturnData    string             `json:"returnData,omitempty"`
-	Memory        *[]string          `json:"memory,omitempty"`
-	Storage       *map[string]string `json:"storage,omitempty"`
-	RefundCounter uint64             `json:"refund,omitempty"`
+	Pc               uint64             `json:"pc"`
+	Op               string             `json:"op"`
+	Gas              uint64             `json:"gas"`
+	GasCost          uint64             `json:"gasCost"`
+	Depth            int                `json:"depth"`
+	Error            string             `json:"error,omitempty"`
+	Stack            *[]string          `json:"stack,omitempty"`
+	ReturnData       string             `json:"returnData,omitempty"`
+	Memory           *[]string          `json:"memory,omitempty"`
+	Storage          *map[string]string `json:"storage,omitempty"`
+	TransientStorage *map[string]string `json:"transientStorage,omitempty"`
+	RefundCounter    uint64             `json:"refund,omitempty"`
+	CallGas          *uint64            `json:"callGas,omitempty"`
+	CallStipend      bool               `json:"callStipend,omitempty"`
 }
 
 // toLegacyJSON converts the structLog to legacy json-encoded legacy form.
@@ -166,6 +187,8 @@ func (s *StructLog) toLegacyJSON() json.RawMessage {
 		Depth:         s.Depth,
 		Error:         s.ErrorString(),
 		RefundCounter: s.RefundCounter,
+		CallGas:       s.CallGas,
+		CallStipend:   s.CallStipend,
 	}
 	if s.Stack != nil {
 		stack := make([]string, len(s.Stack))
@@ -191,6 +214,13 @@ func (s *StructLog) toLegacyJSON() json.RawMessage {
 		}
 		msg.Storage = &storage
 	}
+	if s.TransientStorage != nil {
+		transient := make(map[string]string)
+		for i, value := range s.TransientStorage {
+			transient[fmt.Sprintf("%x", i)] = fmt.Sprintf("%x", value)
+		}
+		msg.TransientStorage = &transient
+	}
 	element, _ := json.Marshal(msg)
 	return element
 }
@@ -219,6 +249,8 @@ type StructLogger struct {
 	interrupt atomic.Bool // Atomic flag to signal execution interruption
 	reason    error       // Textual reason for the interruption
 	skip      bool        // skip processing hooks.
+
+	pending *StructLog // Call log awaiting the gas forwarded to the callee
 }
 
 // NewStreamingStructLogger returns a new streaming logger.
@@ -246,6 +278,7 @@ func (l *StructLogger) Hooks() *tracing.Hooks {
 		OnTxEnd:             l.OnTxEnd,
 		OnSystemCallStartV2: l.OnSystemCallStart,
 		OnSystemCallEnd:     l.OnSystemCallEnd,
+		OnEnter:             l.OnEnter,
 		OnExit:              l.OnExit,
 		OnOpcode:            l.OnOpcode,
 	}
@@ -253,7 +286,8 @@ func (l *StructLogger) Hooks() *tracing.Hooks {
 
 // OnOpcode logs a new structured log message and pushes it out to the environment
 //
-// OnOpcode also tracks SLOAD/SSTORE ops to track storage change.
+// OnOpcode also tracks SLOAD/SSTORE ops to track storage change, and snapshots
+// the transient storage on TLOAD/TSTORE ops if enabled.
 func (l *StructLogger) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
 	// If tracing was interrupted, exit
 	if l.interrupt.Load() {
@@ -263,6 +297,8 @@ func (l *StructLogger) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope
 	if l.skip {
 		return
 	}
+	l.flushPending()
+
 	// check if already accumulated the size of the response.
 	if l.cfg.Limit != 0 && l.resultSize > l.cfg.Limit {
 		return
@@ -274,7 +310,7 @@ func (l *StructLogger) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope
 		stack        = scope.StackData()
 		stackLen     = len(stack)
 	)
-	log := StructLog{pc, op, gas, cost, nil, len(memory), nil, nil, nil, depth, l.env.StateDB.GetRefund(), err}
+	log := StructLog{pc, op, gas, cost, nil, len(memory), nil, nil, nil, nil, depth, l.env.StateDB.GetRefund(), nil, false, err}
 	if l.cfg.EnableMemory {
 		log.Memory = memory
 	}
@@ -313,7 +349,37 @@ func (l *StructLogger) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope
 	}
 	log.Storage = storage
 
-	// create a log
+	// Capture a snapshot of the transient storage of the contract, including
+	// the slot about to be written
+	if l.cfg.EnableTransientStorage && (op == vm.TLOAD || op == vm.TSTORE) {
+		transient := l.env.StateDB.GetTransientStorage(contractAddr)
+		if transient == nil {
+			transient = make(Storage)
+		}
+		if op == vm.TSTORE && stackLen >= 2 {
+			var (
+				value   = common.Hash(stack[stackLen-2].Bytes32())
+				address = common.Hash(stack[stackLen-1].Bytes32())
+			)
+			if value == (common.Hash{}) {
+				delete(transient, address)
+			} else {
+				transient[address] = value
+			}
+		}
+		log.TransientStorage = transient
+	}
+
+	// Hold back the log of calls until the gas forwarded is known
+	if l.cfg.EnableCallGas && err == nil && (op == vm.CALL || op == vm.CALLCODE || op == vm.DELEGATECALL || op == vm.STATICCALL) {
+		l.pending = &log
+		return
+	}
+	l.emit(&log)
+}
+
+// emit outputs a structured log.
+func (l *StructLogger) emit(log *StructLog) {
 	if l.writer == nil {
 		entry := log.toLegacyJSON()
 		l.resultSize += len(entry)
@@ -323,12 +389,38 @@ func (l *StructLogger) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope
 	log.WriteTo(l.writer)
 }
 
+// flushPending outputs the log of the last call, if any, without the gas
+// forwarded, e.g. if the call was aborted before entering the callee.
+func (l *StructLogger) flushPending() {
+	if l.pending != nil {
+		l.emit(l.pending)
+		l.pending = nil
+	}
+}
+
+// OnEnter is called when a call frame is entered. It completes the log of the
+// call with the gas forwarded to the callee, after applying the 63/64 rule and
+// adding the stipend of value transfers.
+func (l *StructLogger) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if l.pending == nil || l.skip {
+		return
+	}
+	if l.pending.Depth == depth {
+		op := vm.OpCode(typ)
+		l.pending.CallGas = &gas
+		l.pending.CallStipend = (op == vm.CALL || op == vm.CALLCODE) && value != nil && value.Sign() > 0
+	}
+	l.flushPending()
+}
+
 // OnExit is called a call frame finishes processing.
 func (l *StructLogger) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
-	if depth != 0 {
+	if l.skip {
 		return
 	}
-	if l.skip {
+	l.flushPending()
+
+	if depth != 0 {
 		return
 	}
 	l.output = output
@@ -19,11 +19,15 @@ package logger
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
@@ -62,6 +66,140 @@ func TestStoreCapture(t *testing.T) {
 	}
 }
 
+func TestTransientStorageCapture(t *testing.T) {
+	// tstore(0, 1); tstore(1, 2); tstore(0, 0); tload(1)
+	code := []byte{
+		byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.TSTORE),
+		byte(vm.PUSH1), 0x2, byte(vm.PUSH1), 0x1, byte(vm.TSTORE),
+		byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.TSTORE),
+		byte(vm.PUSH1), 0x1, byte(vm.TLOAD),
+	}
+	for _, enabled := range []bool{false, true} {
+		var (
+			statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+			logger     = NewStructLogger(&Config{EnableTransientStorage: enabled})
+			evm        = vm.NewEVM(vm.BlockContext{BlockNumber: new(big.Int), Random: &common.Hash{}}, statedb, params.MergedTestChainConfig, vm.Config{Tracer: logger.Hooks()})
+			contract   = vm.NewContract(common.Address{}, common.Address{}, new(uint256.Int), 100000, nil)
+		)
+		contract.Code = code
+		logger.OnTxStart(evm.GetVMContext(), nil, common.Address{})
+		if _, err := evm.Interpreter().Run(contract, []byte{}, false); err != nil {
+			t.Fatal(err)
+		}
+		var have []map[string]string
+		for _, entry := range logger.logs {
+			var log struct {
+				Op        string             `json:"op"`
+				Transient *map[string]string `json:"transientStorage"`
+			}
+			if err := json.Unmarshal(entry, &log); err != nil {
+				t.Fatal(err)
+			}
+			if log.Transient != nil {
+				have = append(have, *log.Transient)
+			} else if enabled && (log.Op == "TSTORE" || log.Op == "TLOAD") {
+				t.Fatalf("missing transient storage at %s", log.Op)
+			}
+		}
+		if !enabled {
+			if len(have) != 0 {
+				t.Fatalf("unexpected transient storage capture: %v", have)
+			}
+			continue
+		}
+		var (
+			slot0 = fmt.Sprintf("%x", common.Hash{})
+			slot1 = fmt.Sprintf("%x", common.BigToHash(big.NewInt(1)))
+			one   = fmt.Sprintf("%x", common.BigToHash(big.NewInt(1)))
+			two   = fmt.Sprintf("%x", common.BigToHash(big.NewInt(2)))
+		)
+		want := []map[string]string{
+			{slot0: one},
+			{slot0: one, slot1: two},
+			{slot1: two},
+			{slot1: two},
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Fatalf("transient storage mismatch: have %v, want %v", have, want)
+		}
+	}
+}
+
+func TestCallGasCapture(t *testing.T) {
+	var (
+		caller = common.HexToAddress("0xc0")
+		callee = common.HexToAddress("0xee") // empty account
+	)
+	// call(0, callee, 1, 0, 0, 0, 0); call(0xffffff, callee, 0, 0, 0, 0, 0)
+	var code []byte
+	for _, call := range []struct{ gas, value byte }{{0, 1}, {0xff, 0}} {
+		code = append(code, byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.PUSH1), call.value, byte(vm.PUSH20))
+		code = append(code, callee.Bytes()...)
+		code = append(code, byte(vm.PUSH3), call.gas, call.gas, call.gas, byte(vm.CALL), byte(vm.POP))
+	}
+	for _, enabled := range []bool{false, true} {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.SetCode(caller, code)
+		statedb.SetBalance(caller, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+
+		var (
+			logger = NewStructLogger(&Config{EnableCallGas: enabled})
+			vmctx  = vm.BlockContext{
+				CanTransfer: func(vm.StateDB, common.Address, *uint256.Int) bool { return true },
+				Transfer:    func(vm.StateDB, common.Address, common.Address, *uint256.Int) {},
+				BlockNumber: new(big.Int),
+				Random:      &common.Hash{},
+			}
+			evm = vm.NewEVM(vmctx, statedb, params.MergedTestChainConfig, vm.Config{Tracer: logger.Hooks()})
+		)
+		logger.OnTxStart(evm.GetVMContext(), nil, common.Address{})
+		if _, _, err := evm.Call(common.Address{}, caller, nil, 100000, new(uint256.Int)); err != nil {
+			t.Fatal(err)
+		}
+		var calls []StructLog
+		for _, entry := range logger.logs {
+			var log struct {
+				Op          string  `json:"op"`
+				Gas         uint64  `json:"gas"`
+				GasCost     uint64  `json:"gasCost"`
+				CallGas     *uint64 `json:"callGas"`
+				CallStipend bool    `json:"callStipend"`
+			}
+			if err := json.Unmarshal(entry, &log); err != nil {
+				t.Fatal(err)
+			}
+			if log.Op == "CALL" {
+				calls = append(calls, StructLog{Gas: log.Gas, GasCost: log.GasCost, CallGas: log.CallGas, CallStipend: log.CallStipend})
+			} else if log.CallGas != nil {
+				t.Fatalf("unexpected call gas at %s", log.Op)
+			}
+		}
+		if len(calls) != 2 {
+			t.Fatalf("call logs mismatch: have %d, want 2", len(calls))
+		}
+		if !enabled {
+			for i, call := range calls {
+				if call.CallGas != nil || call.CallStipend {
+					t.Fatalf("call %d: unexpected call gas capture", i)
+				}
+			}
+			continue
+		}
+		// The value transfer only forwards the stipend
+		if call := calls[0]; call.CallGas == nil || *call.CallGas != params.CallStipend || !call.CallStipend {
+			t.Fatalf("value call mismatch: have gas %v (stipend %t), want %d", call.CallGas, call.CallStipend, params.CallStipend)
+		}
+		// The request exceeding the available gas is capped by the 63/64 rule
+		call := calls[1]
+		if call.CallGas == nil || call.CallStipend {
+			t.Fatalf("plain call mismatch: have gas %v (stipend %t)", call.CallGas, call.CallStipend)
+		}
+		if avail := call.Gas - (call.GasCost - *call.CallGas); *call.CallGas != avail-avail/64 {
+			t.Fatalf("plain call gas mismatch: have %d, want %d", *call.CallGas, avail-avail/64)
+		}
+	}
+}
+
 // Tests that blank fields don't appear in logs when JSON marshalled, to reduce
 // logs bloat and confusion. See https://github.com/ethereum/go-ethereum/issues/24487
 func TestStructLogMarshalingOmitEmpty(t *testing.T) {
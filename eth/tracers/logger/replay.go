@@ -0,0 +1,217 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+)
+
+// StepState is the execution state reconstructed from a recorded struct log at
+// a given step, prior to the execution of the step's opcode.
+type StepState struct {
+	Step    int
+	Pc      uint64
+	Op      vm.OpCode
+	Gas     uint64
+	GasCost uint64
+	Depth   int
+	Error   string
+
+	Stack  []uint256.Int // Stack items, nil if stack capture was disabled
+	Memory []byte        // Memory content, nil if memory capture was disabled
+
+	// Storage contains the slots of the executing contract which were read or
+	// written by the trace up to this step. Slots written by a call which was
+	// later reverted are still included, as they are in the recorded log.
+	Storage Storage
+}
+
+// Replayer reconstructs the execution state at any step of a trace recorded by
+// the StructLogger, without re-executing the transaction. It only restores the
+// information captured by the logger.
+type Replayer struct {
+	logs []structLogLegacy
+}
+
+// NewReplayer creates a replayer from the json output of the StructLogger. Both
+// the complete tracer result and a bare list of struct logs are accepted.
+func NewReplayer(data []byte) (*Replayer, error) {
+	var logs []structLogLegacy
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &logs); err != nil {
+			return nil, err
+		}
+	} else {
+		var result struct {
+			StructLogs []structLogLegacy `json:"structLogs"`
+		}
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		logs = result.StructLogs
+	}
+	return &Replayer{logs: logs}, nil
+}
+
+// Len returns the number of recorded steps.
+func (r *Replayer) Len() int {
+	return len(r.logs)
+}
+
+// replayFrame is the storage context of a call frame. Delegated calls share the
+// context with their parent frame.
+type replayFrame struct {
+	storage Storage
+}
+
+// StateAtStep reconstructs the execution state at step n (zero based).
+func (r *Replayer) StateAtStep(n int) (*StepState, error) {
+	if n < 0 || n >= len(r.logs) {
+		return nil, fmt.Errorf("step %d out of range [0, %d)", n, len(r.logs))
+	}
+	var (
+		frames   = []*replayFrame{{storage: make(Storage)}}
+		contexts = make(map[common.Address]*replayFrame)
+	)
+	for i := 0; i <= n; i++ {
+		log := &r.logs[i]
+
+		// Track the call frames based on the depth transitions. Any depth
+		// decrease unwinds the frames, regardless of the amount of frames
+		// exited (e.g. out of gas in nested calls).
+		if i > 0 {
+			prev := &r.logs[i-1]
+			switch {
+			case log.Depth > prev.Depth:
+				frames = append(frames, r.enterFrame(prev, frames[len(frames)-1], contexts))
+			case log.Depth < prev.Depth:
+				exited := prev.Depth - log.Depth
+				if exited >= len(frames) {
+					return nil, fmt.Errorf("step %d: invalid depth transition %d -> %d", i, prev.Depth, log.Depth)
+				}
+				frames = frames[:len(frames)-exited]
+			}
+		}
+		// The logger emits the accumulated storage of the executing contract
+		// on storage access, replace the known content entirely.
+		if log.Storage != nil {
+			storage, err := decodeStorage(*log.Storage)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: %v", i, err)
+			}
+			frames[len(frames)-1].storage = storage
+		}
+	}
+	log := &r.logs[n]
+	state := &StepState{
+		Step:    n,
+		Pc:      log.Pc,
+		Op:      vm.StringToOp(log.Op),
+		Gas:     log.Gas,
+		GasCost: log.GasCost,
+		Depth:   log.Depth,
+		Error:   log.Error,
+		Storage: maps.Clone(frames[len(frames)-1].storage),
+	}
+	if log.Stack != nil {
+		stack, err := decodeStack(*log.Stack)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %v", n, err)
+		}
+		state.Stack = stack
+	}
+	if log.Memory != nil {
+		memory, err := decodeMemory(*log.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %v", n, err)
+		}
+		state.Memory = memory
+	}
+	return state, nil
+}
+
+// enterFrame resolves the storage context of the frame entered by the given call
+// step. Storage of contracts entered multiple times is shared across the frames.
+func (r *Replayer) enterFrame(call *structLogLegacy, parent *replayFrame, contexts map[common.Address]*replayFrame) *replayFrame {
+	switch vm.StringToOp(call.Op) {
+	case vm.DELEGATECALL, vm.CALLCODE:
+		return parent
+	case vm.CALL, vm.STATICCALL:
+		// The callee address is the second item from the top of the stack
+		if call.Stack != nil && len(*call.Stack) >= 2 {
+			stack := *call.Stack
+			var addr uint256.Int
+			if err := addr.SetFromHex(stack[len(stack)-2]); err == nil {
+				callee := common.Address(addr.Bytes20())
+				if frame, ok := contexts[callee]; ok {
+					return frame
+				}
+				frame := &replayFrame{storage: make(Storage)}
+				contexts[callee] = frame
+				return frame
+			}
+		}
+	}
+	// Contract creations or calls with unknown callee get a fresh context
+	return &replayFrame{storage: make(Storage)}
+}
+
+func decodeStack(items []string) ([]uint256.Int, error) {
+	stack := make([]uint256.Int, len(items))
+	for i, item := range items {
+		if err := stack[i].SetFromHex(item); err != nil {
+			return nil, fmt.Errorf("invalid stack item %q: %v", item, err)
+		}
+	}
+	return stack, nil
+}
+
+func decodeMemory(chunks []string) ([]byte, error) {
+	memory := make([]byte, 0, 32*len(chunks))
+	for _, chunk := range chunks {
+		b, err := hex.DecodeString(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory chunk %q: %v", chunk, err)
+		}
+		memory = append(memory, b...)
+	}
+	return memory, nil
+}
+
+func decodeStorage(slots map[string]string) (Storage, error) {
+	storage := make(Storage, len(slots))
+	for k, v := range slots {
+		key, err := hex.DecodeString(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid storage key %q: %v", k, err)
+		}
+		val, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid storage value %q: %v", v, err)
+		}
+		storage[common.BytesToHash(key)] = common.BytesToHash(val)
+	}
+	return storage, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package logger
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the execution state is reconstructed correctly from a recorded
+// trace, across call depth transitions.
+func TestReplayStateAtStep(t *testing.T) {
+	var (
+		caller = common.HexToAddress("0xc0ffee")
+		callee = common.HexToAddress("0xbb")
+		entry  = common.HexToAddress("0xaa")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	// The callee stores 0x2 into slot 0x2.
+	statedb.SetCode(callee, []byte{
+		byte(vm.PUSH1), 0x2, byte(vm.PUSH1), 0x2, byte(vm.SSTORE), byte(vm.STOP),
+	})
+	// The entry contract stores 0x1 into slot 0x1, writes 0xff into memory,
+	// calls the callee and loads slot 0x1 afterwards.
+	statedb.SetCode(entry, append(append([]byte{
+		byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x1, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0xff, byte(vm.PUSH1), 0x0, byte(vm.MSTORE8),
+		byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0,
+		byte(vm.PUSH20)}, callee.Bytes()...), []byte{
+		byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+		byte(vm.PUSH1), 0x1, byte(vm.SLOAD), byte(vm.STOP),
+	}...))
+
+	var (
+		tracer  = NewStructLogger(&Config{EnableMemory: true})
+		context = vm.BlockContext{
+			CanTransfer: func(vm.StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(vm.StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int),
+		}
+		evm = vm.NewEVM(context, statedb, params.TestChainConfig, vm.Config{Tracer: tracer.Hooks()})
+	)
+	tracer.OnTxStart(evm.GetVMContext(), nil, caller)
+	if _, _, err := evm.Call(caller, entry, nil, 100000, new(uint256.Int)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := NewReplayer(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Locate the steps of interest
+	var (
+		calleeStore = -1
+		afterCall   = -1
+		entryLoad   = -1
+	)
+	for i := 0; i < replayer.Len(); i++ {
+		s, err := replayer.StateAtStep(i)
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		switch {
+		case s.Op == vm.SSTORE && s.Depth == 2:
+			calleeStore = i
+		case s.Op == vm.POP && s.Depth == 1:
+			afterCall = i
+		case s.Op == vm.SLOAD && s.Depth == 1:
+			entryLoad = i
+		}
+	}
+	if calleeStore < 0 || afterCall < 0 || entryLoad < 0 {
+		t.Fatalf("missing steps in trace: %d %d %d", calleeStore, afterCall, entryLoad)
+	}
+	slot1, slot2 := common.HexToHash("0x1"), common.HexToHash("0x2")
+
+	s, _ := replayer.StateAtStep(calleeStore)
+	if len(s.Storage) != 1 || s.Storage[slot2] != slot2 {
+		t.Errorf("wrong callee storage: %v", s.Storage)
+	}
+	if len(s.Memory) != 0 {
+		t.Errorf("callee memory not empty: %x", s.Memory)
+	}
+	want := []uint256.Int{*uint256.NewInt(2), *uint256.NewInt(2)}
+	if !slices.Equal(s.Stack, want) {
+		t.Errorf("wrong callee stack: have %v, want %v", s.Stack, want)
+	}
+	// Returning from the call restores the context of the entry contract
+	s, _ = replayer.StateAtStep(afterCall)
+	if len(s.Storage) != 1 || s.Storage[slot1] != slot1 {
+		t.Errorf("wrong entry storage after call: %v", s.Storage)
+	}
+	if len(s.Memory) != 32 || s.Memory[0] != 0xff {
+		t.Errorf("wrong entry memory after call: %x", s.Memory)
+	}
+	if len(s.Stack) != 1 || s.Stack[0].Uint64() != 1 {
+		t.Errorf("wrong entry stack after call: %v", s.Stack)
+	}
+	s, _ = replayer.StateAtStep(entryLoad)
+	if len(s.Storage) != 1 || s.Storage[slot1] != slot1 {
+		t.Errorf("wrong entry storage at load: %v", s.Storage)
+	}
+	if _, err := replayer.StateAtStep(replayer.Len()); err == nil {
+		t.Error("expected error for out of range step")
+	}
+}
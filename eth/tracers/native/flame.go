@@ -0,0 +1,195 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("flameTracer", newFlameTracer, false)
+}
+
+// flameFrame is an active call frame of the flame tracer.
+type flameFrame struct {
+	name     string // Folding key of the frame
+	gasUsed  uint64 // Gas used by the frame, including its children
+	childGas uint64 // Gas used by the children of the frame
+}
+
+type flameTracerConfig struct {
+	Signatures map[string]string `json:"signatures"` // Names of the method selectors, e.g. {"0xa9059cbb": "transfer"}
+}
+
+// flameTracer attributes the gas used by a transaction to its call stacks and
+// reports them in the folded-stack format consumed by FlameGraph and pprof, one
+// "frame;frame;frame gas" line per stack. The gas of each line is the one used
+// by the innermost frame itself, excluding its children. Frames are named by the
+// called address and the method selector, resolved through the configured
+// signatures where available. Intrinsic gas is attributed to the top frame.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "flameTracer", tracerConfig: {signatures: {"0xa9059cbb": "transfer"}}})
+//	"0xaa...:transfer 21830\n0xaa...:transfer;0xbb...:0x70a08231 2630"
+type flameTracer struct {
+	config    flameTracerConfig
+	stack     []flameFrame      // Active call frames
+	path      []string          // Names of the active call frames
+	folded    map[string]uint64 // Gas used by the completed frames, keyed by folded stack
+	root      *flameFrame       // Completed top frame, adjusted to the receipt
+	interrupt atomic.Bool       // Atomic flag to signal execution interruption
+	reason    error             // Textual reason for the interruption
+}
+
+// newFlameTracer returns a native go tracer which attributes the gas used by a
+// transaction to its call stacks.
+func newFlameTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	var config flameTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	// Selectors are matched in lowercase hex
+	signatures := make(map[string]string, len(config.Signatures))
+	for selector, name := range config.Signatures {
+		signatures[strings.ToLower(selector)] = name
+	}
+	config.Signatures = signatures
+
+	t := &flameTracer{
+		config: config,
+		folded: make(map[string]uint64),
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxEnd: t.OnTxEnd,
+			OnEnter: t.OnEnter,
+			OnExit:  t.OnExit,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// frameName returns the folding key of a call, sanitized from the separators
+// of the folded-stack format.
+func (t *flameTracer) frameName(to common.Address, input []byte) string {
+	name := to.Hex()
+	if len(input) >= 4 {
+		selector := bytesToHex(input[:4])
+		if sig, ok := t.config.Signatures[selector]; ok {
+			selector = sig
+		}
+		name += ":" + selector
+	}
+	return strings.NewReplacer(";", "_", " ", "_").Replace(name)
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *flameTracer) OnEnter(depth int, opcode byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	name := t.frameName(to, input)
+	t.stack = append(t.stack, flameFrame{name: name})
+	t.path = append(t.path, name)
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *flameTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.stack) == 0 {
+		return
+	}
+	frame := t.stack[len(t.stack)-1]
+	frame.gasUsed = gasUsed
+	t.stack = t.stack[:len(t.stack)-1]
+
+	if len(t.stack) == 0 {
+		// The gas of the top frame is finalized by the receipt, if any
+		t.root = &frame
+		t.path = t.path[:0]
+		return
+	}
+	t.stack[len(t.stack)-1].childGas += gasUsed
+	t.fold(frame)
+	t.path = t.path[:len(t.path)-1]
+}
+
+// OnTxEnd is called after the transaction is executed, adjusting the gas of
+// the top frame to include the intrinsic gas and the refunds.
+func (t *flameTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if err != nil || receipt == nil || t.root == nil {
+		return
+	}
+	t.root.gasUsed = receipt.GasUsed
+}
+
+// fold accounts the gas used by the frame itself to the current stack.
+func (t *flameTracer) fold(frame flameFrame) {
+	t.folded[strings.Join(t.path, ";")] += frame.selfGas()
+}
+
+// selfGas returns the gas used by the frame, excluding its children.
+func (f *flameFrame) selfGas() uint64 {
+	if f.gasUsed < f.childGas {
+		return 0
+	}
+	return f.gasUsed - f.childGas
+}
+
+// GetResult returns the folded stacks as a json-encoded string, one line per
+// stack, and any error arising from the encoding or forceful termination (via
+// `Stop`).
+func (t *flameTracer) GetResult() (json.RawMessage, error) {
+	folded := t.folded
+	if t.root != nil {
+		folded = maps.Clone(t.folded)
+		folded[t.root.name] += t.root.selfGas()
+	}
+	lines := make([]string, 0, len(folded))
+	for stack, gas := range folded {
+		lines = append(lines, stack+" "+strconv.FormatUint(gas, 10))
+	}
+	slices.Sort(lines)
+
+	res, err := json.Marshal(strings.Join(lines, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *flameTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlameTracer(t *testing.T) {
+	cfg := json.RawMessage(`{"signatures": {"0xa9059cbb": "transfer"}}`)
+	tracer, err := tracers.DefaultDirectory.New("flameTracer", &tracers.Context{}, cfg, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	var (
+		sender   = common.HexToAddress("0x01")
+		token    = common.HexToAddress("0xaa")
+		lib      = common.HexToAddress("0xbb")
+		transfer = hexutil.MustDecode("0xa9059cbb")
+		balance  = hexutil.MustDecode("0x70a08231")
+	)
+	// Top level call with two calls into the same library method, and a plain
+	// value transfer
+	tracer.OnEnter(0, byte(vm.CALL), sender, token, transfer, 100000, big.NewInt(0))
+	tracer.OnEnter(1, byte(vm.STATICCALL), token, lib, balance, 50000, nil)
+	tracer.OnExit(1, nil, 1000, nil, false)
+	tracer.OnEnter(1, byte(vm.STATICCALL), token, lib, balance, 50000, nil)
+	tracer.OnEnter(2, byte(vm.CALL), lib, sender, nil, 20000, big.NewInt(1))
+	tracer.OnExit(2, nil, 700, nil, false)
+	tracer.OnExit(1, nil, 1500, vm.ErrExecutionReverted, true)
+	tracer.OnExit(0, nil, 5000, nil, false)
+	tracer.OnTxEnd(&types.Receipt{GasUsed: 26000}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var folded string
+	require.NoError(t, json.Unmarshal(res, &folded))
+	want := token.Hex() + ":transfer 23500\n" +
+		token.Hex() + ":transfer;" + lib.Hex() + ":0x70a08231 1800\n" +
+		token.Hex() + ":transfer;" + lib.Hex() + ":0x70a08231;" + sender.Hex() + " 700"
+	require.Equal(t, want, folded)
+}
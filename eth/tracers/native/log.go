@@ -0,0 +1,154 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("logTracer", newLogTracer, false)
+}
+
+// logEntry is a single log emitted during execution, along with the call frame
+// emitting it.
+type logEntry struct {
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     hexutil.Bytes  `json:"data"`
+	Depth    int            `json:"depth"`    // Call depth of the emitting frame, 0 being the top level
+	Frame    int            `json:"frame"`    // Index of the emitting frame in the order frames were entered
+	CallType string         `json:"callType"` // Opcode entering the emitting frame
+	Reverted bool           `json:"reverted"` // Whether the log was discarded by a revert
+}
+
+// logFrame is an active call frame, tracking the logs it emitted.
+type logFrame struct {
+	index    int    // Index of the frame in the order frames were entered
+	callType string // Opcode entering the frame
+	start    int    // Index of the first log emitted by the frame or its subcalls
+}
+
+// logTracer records every log emitted by a transaction in emission order,
+// including the ones discarded by a reverting call frame (or one of its
+// parents), which are flagged as reverted.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "logTracer"})
+//	[
+//	  {address: "0x...", topics: ["0x..."], data: "0x", depth: 0, frame: 0, callType: "CALL", reverted: false},
+//	  {address: "0x...", topics: [], data: "0x01", depth: 1, frame: 2, callType: "DELEGATECALL", reverted: true}
+//	]
+type logTracer struct {
+	frames    []logFrame  // Active call frames
+	frameNum  int         // Number of call frames entered so far
+	logs      []logEntry  // Logs emitted by the transaction
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newLogTracer returns a native go tracer which records the logs emitted by a
+// transaction along with their call context.
+func newLogTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := &logTracer{}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnEnter: t.OnEnter,
+			OnExit:  t.OnExit,
+			OnLog:   t.OnLog,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *logTracer) OnEnter(depth int, opcode byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.frames = append(t.frames, logFrame{
+		index:    t.frameNum,
+		callType: vm.OpCode(opcode).String(),
+		start:    len(t.logs),
+	})
+	t.frameNum++
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *logTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	// Logs of reverted scopes are discarded, including the ones of subcalls
+	if reverted {
+		for i := frame.start; i < len(t.logs); i++ {
+			t.logs[i].Reverted = true
+		}
+	}
+}
+
+// OnLog is called when a log is emitted.
+func (t *logTracer) OnLog(log *types.Log) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.logs = append(t.logs, logEntry{
+		Address:  log.Address,
+		Topics:   log.Topics,
+		Data:     log.Data,
+		Depth:    len(t.frames) - 1,
+		Frame:    frame.index,
+		CallType: frame.callType,
+	})
+}
+
+// GetResult returns the json-encoded list of logs, and any error arising
+// from the encoding or forceful termination (via `Stop`).
+func (t *logTracer) GetResult() (json.RawMessage, error) {
+	logs := t.logs
+	if logs == nil {
+		logs = []logEntry{}
+	}
+	res, err := json.Marshal(logs)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *logTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
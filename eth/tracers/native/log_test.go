@@ -0,0 +1,82 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogTracer(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("logTracer", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	var (
+		sender = common.HexToAddress("0x01")
+		a      = common.HexToAddress("0xaa")
+		b      = common.HexToAddress("0xbb")
+		c      = common.HexToAddress("0xcc")
+		topic  = common.HexToHash("0x01")
+	)
+	tracer.OnEnter(0, byte(vm.CALL), sender, a, nil, 0, big.NewInt(0))
+	tracer.OnLog(&types.Log{Address: a, Topics: []common.Hash{topic}})
+
+	// Reverted call, discarding the logs of its subcalls too
+	tracer.OnEnter(1, byte(vm.CALL), a, b, nil, 0, big.NewInt(0))
+	tracer.OnLog(&types.Log{Address: b, Data: []byte{0x01}})
+	tracer.OnEnter(2, byte(vm.DELEGATECALL), b, c, nil, 0, nil)
+	tracer.OnLog(&types.Log{Address: b, Topics: []common.Hash{topic, topic}})
+	tracer.OnExit(2, nil, 0, nil, false)
+	tracer.OnExit(1, nil, 0, vm.ErrExecutionReverted, true)
+
+	// Successful call after the revert
+	tracer.OnEnter(1, byte(vm.STATICCALL), a, c, nil, 0, nil)
+	tracer.OnLog(&types.Log{Address: c})
+	tracer.OnExit(1, nil, 0, nil, false)
+	tracer.OnExit(0, nil, 0, nil, false)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	want := `[` +
+		`{"address":"0x00000000000000000000000000000000000000aa","topics":["0x0000000000000000000000000000000000000000000000000000000000000001"],"data":"0x","depth":0,"frame":0,"callType":"CALL","reverted":false},` +
+		`{"address":"0x00000000000000000000000000000000000000bb","topics":null,"data":"0x01","depth":1,"frame":1,"callType":"CALL","reverted":true},` +
+		`{"address":"0x00000000000000000000000000000000000000bb","topics":["0x0000000000000000000000000000000000000000000000000000000000000001","0x0000000000000000000000000000000000000000000000000000000000000001"],"data":"0x","depth":2,"frame":2,"callType":"DELEGATECALL","reverted":true},` +
+		`{"address":"0x00000000000000000000000000000000000000cc","topics":null,"data":"0x","depth":1,"frame":3,"callType":"STATICCALL","reverted":false}` +
+		`]`
+	require.JSONEq(t, want, string(res))
+}
+
+func TestLogTracerTopLevelRevert(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("logTracer", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	addr := common.HexToAddress("0xaa")
+	tracer.OnEnter(0, byte(vm.CREATE), common.HexToAddress("0x01"), addr, nil, 0, big.NewInt(0))
+	tracer.OnLog(&types.Log{Address: addr})
+	tracer.OnExit(0, nil, 0, vm.ErrExecutionReverted, true)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"address":"0x00000000000000000000000000000000000000aa","topics":null,"data":"0x","depth":0,"frame":0,"callType":"CREATE","reverted":true}]`, string(res))
+}
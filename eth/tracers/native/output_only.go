@@ -0,0 +1,94 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("outputOnly", newOutputOnlyTracer, false)
+}
+
+// outputResult is the output of the top-level call frame of a transaction.
+type outputResult struct {
+	Output   hexutil.Bytes `json:"output"`
+	Reverted bool          `json:"reverted"`
+}
+
+// outputOnlyTracer records the data returned by the top-level call frame of a
+// transaction and whether the frame was reverted, ignoring the nested frames.
+// The output of frames failing with an error other than a revert, e.g. running
+// out of gas or hitting an invalid opcode, is empty.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "outputOnly"})
+//	{output: "0x08c379a0...", reverted: true}
+type outputOnlyTracer struct {
+	result    outputResult
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newOutputOnlyTracer returns a native go tracer which records the output of
+// the top-level call frame.
+func newOutputOnlyTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := &outputOnlyTracer{result: outputResult{Output: []byte{}}}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnExit: t.OnExit,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *outputOnlyTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || depth != 0 {
+		return
+	}
+	t.result = outputResult{Output: common.CopyBytes(output), Reverted: reverted}
+	if t.result.Output == nil {
+		t.result.Output = []byte{}
+	}
+}
+
+// GetResult returns the json-encoded output of the top-level call frame, and
+// any error arising from the encoding or forceful termination (via `Stop`).
+func (t *outputOnlyTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.result)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *outputOnlyTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
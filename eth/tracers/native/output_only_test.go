@@ -0,0 +1,77 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputOnlyTracer(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		// mstore8(0, 0x2a); return(0, 1)
+		{"return", "0x602a60005360016000f3", `{"output":"0x2a","reverted":false}`},
+		// mstore8(0, 0x2a); revert(0, 1)
+		{"revert", "0x602a60005360016000fd", `{"output":"0x2a","reverted":true}`},
+		{"stop", "0x00", `{"output":"0x","reverted":false}`},
+		{"invalid-opcode", "0xfe", `{"output":"0x","reverted":true}`},
+		// jumpdest; jump(0)
+		{"out-of-gas", "0x5b600056", `{"output":"0x","reverted":true}`},
+	}
+	for _, tt := range tests {
+		tracer, err := tracers.DefaultDirectory.New("outputOnly", &tracers.Context{}, nil, params.MainnetChainConfig)
+		require.NoError(t, err)
+
+		runtime.Execute(common.FromHex(tt.code), nil, &runtime.Config{
+			GasLimit:  100000,
+			EVMConfig: vm.Config{Tracer: tracer.Hooks},
+		})
+		res, err := tracer.GetResult()
+		require.NoError(t, err, tt.name)
+		require.JSONEq(t, tt.want, string(res), tt.name)
+	}
+}
+
+func TestOutputOnlyTracerNested(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("outputOnly", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	// The output of nested frames is ignored, even if exiting last
+	tracer.OnExit(1, []byte{0x01}, 0, vm.ErrExecutionReverted, true)
+	tracer.OnExit(0, []byte{0x02}, 0, nil, false)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"output":"0x02","reverted":false}`, string(res))
+
+	// No top-level frame exited, e.g. the transaction was rejected
+	tracer, err = tracers.DefaultDirectory.New("outputOnly", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+	res, err = tracer.GetResult()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"output":"0x","reverted":false}`, string(res))
+}
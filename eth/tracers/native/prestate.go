@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -38,6 +39,7 @@ import (
 
 func init() {
 	tracers.DefaultDirectory.Register("prestateTracer", newPrestateTracer, false)
+	tracers.DefaultDirectory.SetSchemaVersion("prestateTracer", 2) // Blob accounting in diff mode
 }
 
 type stateMap = map[common.Address]*account
@@ -59,16 +61,28 @@ type accountMarshaling struct {
 	Code    hexutil.Bytes
 }
 
+// blobState is the blob gas accounting of a blob transaction, reported in diff
+// mode along with the account diffs.
+type blobState struct {
+	BlobGasUsed        hexutil.Uint64 `json:"blobGasUsed"`
+	BlobGasPrice       *hexutil.Big   `json:"blobGasPrice"`
+	ExcessBlobGas      hexutil.Uint64 `json:"excessBlobGas"`      // Excess blob gas of the block
+	ExcessBlobGasDelta hexutil.Uint64 `json:"excessBlobGasDelta"` // Increase of the next excess blob gas caused by the transaction alone
+}
+
 type prestateTracer struct {
-	env       *tracing.VMContext
-	pre       stateMap
-	post      stateMap
-	to        common.Address
-	config    prestateTracerConfig
-	interrupt atomic.Bool // Atomic flag to signal execution interruption
-	reason    error       // Textual reason for the interruption
-	created   map[common.Address]bool
-	deleted   map[common.Address]bool
+	env         *tracing.VMContext
+	chainConfig *params.ChainConfig
+	pre         stateMap
+	post        stateMap
+	to          common.Address
+	config      prestateTracerConfig
+	interrupt   atomic.Bool // Atomic flag to signal execution interruption
+	reason      error       // Textual reason for the interruption
+	created     map[common.Address]bool
+	deleted     map[common.Address]bool
+	blobGas     uint64     // Blob gas used by the transaction
+	blob        *blobState // Blob gas accounting in diff mode, nil for non-blob transactions
 }
 
 type prestateTracerConfig struct {
@@ -83,11 +97,12 @@ func newPrestateTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *p
 		return nil, err
 	}
 	t := &prestateTracer{
-		pre:     stateMap{},
-		post:    stateMap{},
-		config:  config,
-		created: make(map[common.Address]bool),
-		deleted: make(map[common.Address]bool),
+		chainConfig: chainConfig,
+		pre:         stateMap{},
+		post:        stateMap{},
+		config:      config,
+		created:     make(map[common.Address]bool),
+		deleted:     make(map[common.Address]bool),
 	}
 	return &tracers.Tracer{
 		Hooks: &tracing.Hooks{
@@ -149,6 +164,7 @@ func (t *prestateTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scop
 
 func (t *prestateTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
 	t.env = env
+	t.blobGas = tx.BlobGas()
 	if tx.To() == nil {
 		t.to = crypto.CreateAddress(from, env.StateDB.GetNonce(from))
 		t.created[t.to] = true
@@ -176,6 +192,9 @@ func (t *prestateTracer) OnTxEnd(receipt *types.Receipt, err error) {
 	}
 	if t.config.DiffMode {
 		t.processDiffState()
+		if t.blobGas > 0 {
+			t.processBlobState()
+		}
 	}
 	// the new created contracts' prestate were empty, so delete them
 	for a := range t.created {
@@ -193,9 +212,10 @@ func (t *prestateTracer) GetResult() (json.RawMessage, error) {
 	var err error
 	if t.config.DiffMode {
 		res, err = json.Marshal(struct {
-			Post stateMap `json:"post"`
-			Pre  stateMap `json:"pre"`
-		}{t.post, t.pre})
+			Post stateMap   `json:"post"`
+			Pre  stateMap   `json:"pre"`
+			Blob *blobState `json:"blob,omitempty"`
+		}{t.post, t.pre, t.blob})
 	} else {
 		res, err = json.Marshal(t.pre)
 	}
@@ -211,6 +231,27 @@ func (t *prestateTracer) Stop(err error) {
 	t.interrupt.Store(true)
 }
 
+// processBlobState records the blob gas accounting of a blob transaction, along
+// with its effect on the excess blob gas of the next block. The price is the blob
+// base fee the execution was charged, which might be overridden. The effect
+// accounts for the blob gas of the transaction only, the rest of the block is
+// unknown.
+func (t *prestateTracer) processBlobState() {
+	if t.env.ExcessBlobGas == nil || t.chainConfig == nil {
+		return
+	}
+	var (
+		excess = *t.env.ExcessBlobGas
+		target = eip4844.TargetBlobGasPerBlock(t.chainConfig, t.env.Time)
+	)
+	t.blob = &blobState{
+		BlobGasUsed:        hexutil.Uint64(t.blobGas),
+		BlobGasPrice:       (*hexutil.Big)(t.env.BlobBaseFee),
+		ExcessBlobGas:      hexutil.Uint64(excess),
+		ExcessBlobGasDelta: hexutil.Uint64(types.CalcExcessBlobGas(excess, t.blobGas, target) - types.CalcExcessBlobGas(excess, 0, target)),
+	}
+}
+
 func (t *prestateTracer) processDiffState() {
 	for addr, state := range t.pre {
 		// The deleted account's state is pruned from `post` but kept in `pre`
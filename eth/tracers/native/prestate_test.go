@@ -0,0 +1,150 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the prestate tracer reports the blob gas accounting of a blob
+// transaction in diff mode, next to the unaltered account diffs.
+func TestPrestateTracerDiffModeBlob(t *testing.T) {
+	var (
+		config  = params.MergedTestChainConfig
+		key, _  = crypto.GenerateKey()
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		to      = common.HexToAddress("0xbb")
+		baseFee = big.NewInt(params.InitialBaseFee)
+		signer  = types.LatestSigner(config)
+		blobGas = uint64(2 * params.BlobTxBlobGasPerBlob)
+		target  = eip4844.TargetBlobGasPerBlock(config, 0)
+	)
+	tx := types.MustSignNewTx(key, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(config.ChainID),
+		Gas:        params.TxGas,
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.MustFromBig(baseFee),
+		To:         to,
+		BlobFeeCap: uint256.NewInt(params.GWei),
+		BlobHashes: []common.Hash{{0x01}, {0x01, 0x01}},
+	})
+	// The blob accounting changed the output schema of the diff mode
+	version, ok := tracers.DefaultDirectory.SchemaVersion("prestateTracer")
+	require.True(t, ok)
+	require.Equal(t, uint64(2), version)
+
+	for _, tt := range []struct {
+		excess uint64
+		delta  uint64
+		fee    *big.Int // Overridden blob base fee, nil to derive it from the excess
+	}{
+		{excess: 0, delta: 0},                            // Below the target even with the blobs
+		{excess: target - blobGas/2, delta: blobGas / 2}, // Reaching over the target
+		{excess: 2 * target, delta: blobGas},             // Above the target
+		{excess: 0, delta: 0, fee: big.NewInt(7)},        // Blob base fee overridden
+	} {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.AddBalance(sender, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+
+		tracer, err := tracers.DefaultDirectory.New("prestateTracer", &tracers.Context{}, json.RawMessage(`{"diffMode":true}`), config)
+		require.NoError(t, err)
+
+		var (
+			excess  = tt.excess
+			header  = &types.Header{Time: 0, ExcessBlobGas: &excess}
+			blobFee = eip4844.CalcBlobFee(config, header)
+		)
+		if tt.fee != nil {
+			blobFee = tt.fee
+		}
+		evm := vm.NewEVM(vm.BlockContext{
+			CanTransfer:   core.CanTransfer,
+			Transfer:      core.Transfer,
+			BlockNumber:   big.NewInt(1),
+			GasLimit:      params.GenesisGasLimit,
+			BaseFee:       baseFee,
+			BlobBaseFee:   blobFee,
+			Random:        &common.Hash{},
+			ExcessBlobGas: &excess,
+		}, statedb, config, vm.Config{Tracer: tracer.Hooks})
+
+		msg, err := core.TransactionToMessage(tx, signer, baseFee)
+		require.NoError(t, err)
+		tracer.OnTxStart(evm.GetVMContext(), tx, msg.From)
+		res, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(tx.Gas()))
+		require.NoError(t, err)
+		tracer.OnTxEnd(&types.Receipt{GasUsed: res.UsedGas}, nil)
+
+		out, err := tracer.GetResult()
+		require.NoError(t, err)
+
+		var result struct {
+			Post map[common.Address]json.RawMessage `json:"post"`
+			Pre  map[common.Address]json.RawMessage `json:"pre"`
+			Blob *struct {
+				BlobGasUsed        hexutil.Uint64 `json:"blobGasUsed"`
+				BlobGasPrice       *hexutil.Big   `json:"blobGasPrice"`
+				ExcessBlobGas      hexutil.Uint64 `json:"excessBlobGas"`
+				ExcessBlobGasDelta hexutil.Uint64 `json:"excessBlobGasDelta"`
+			} `json:"blob"`
+		}
+		require.NoError(t, json.Unmarshal(out, &result))
+		require.Contains(t, result.Pre, sender)
+		require.Contains(t, result.Post, sender)
+		require.NotNil(t, result.Blob, "excess %d", tt.excess)
+		require.Equal(t, blobGas, uint64(result.Blob.BlobGasUsed))
+		require.Equal(t, blobFee, result.Blob.BlobGasPrice.ToInt())
+		require.Equal(t, tt.excess, uint64(result.Blob.ExcessBlobGas))
+		require.Equal(t, tt.delta, uint64(result.Blob.ExcessBlobGasDelta), "excess %d", tt.excess)
+	}
+}
+
+// Tests that non-blob transactions don't carry any blob accounting in diff mode.
+func TestPrestateTracerDiffModeNoBlob(t *testing.T) {
+	var (
+		excess = uint64(0)
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+	)
+	tracer, err := tracers.DefaultDirectory.New("prestateTracer", &tracers.Context{}, json.RawMessage(`{"diffMode":true}`), params.MergedTestChainConfig)
+	require.NoError(t, err)
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	tx := types.NewTx(&types.DynamicFeeTx{To: &sender, Gas: params.TxGas})
+	tracer.OnTxStart(&tracing.VMContext{StateDB: statedb, ExcessBlobGas: &excess}, tx, sender)
+	tracer.OnTxEnd(&types.Receipt{}, nil)
+
+	out, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"post":{},"pre":{}}`, string(out))
+}
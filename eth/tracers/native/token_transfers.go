@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("tokenTransfers", newTokenTransferTracer, false)
+}
+
+// transferEventTopic is the topic of the Transfer(address,address,uint256) event,
+// shared by ERC-20 and ERC-721.
+var transferEventTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+const (
+	transferNative = "native"
+	transferERC20  = "erc20"
+	transferERC721 = "erc721"
+)
+
+// tokenTransfer is a single value movement executed by the transaction.
+type tokenTransfer struct {
+	Type    string          `json:"type"`
+	Token   *common.Address `json:"token,omitempty"` // Emitting contract, nil for native transfers
+	From    common.Address  `json:"from"`
+	To      common.Address  `json:"to"`
+	Value   *hexutil.Big    `json:"value,omitempty"`   // Amount of native and ERC-20 transfers
+	TokenID *hexutil.Big    `json:"tokenId,omitempty"` // Identifier of ERC-721 transfers
+}
+
+// tokenTransferTracer collects the native value transfers and the ERC-20 and
+// ERC-721 token transfers of a transaction, including the ones between contracts.
+// Transfers executed by reverted calls are discarded.
+//
+// Both token standards emit the same Transfer event, they are told apart by the
+// amount of indexed arguments: ERC-721 indexes the token ID too (4 topics), while
+// ERC-20 keeps the amount in the data (3 topics). Non-compliant ERC-721 contracts
+// not indexing the token ID can't be distinguished from ERC-20 ones on the event
+// layout alone and are reported as ERC-20 transfers. Transfer events following
+// neither layout are ignored.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "tokenTransfers"})
+//	[
+//	  {type: "native", from: "0x...", to: "0x...", value: "0xde0b6b3a7640000"},
+//	  {type: "erc20", token: "0x...", from: "0x...", to: "0x...", value: "0x64"},
+//	  {type: "erc721", token: "0x...", from: "0x...", to: "0x...", tokenId: "0x2a"}
+//	]
+type tokenTransferTracer struct {
+	frames    [][]tokenTransfer // Transfers of the active call frames
+	result    []tokenTransfer   // Transfers of the completed transaction
+	interrupt atomic.Bool       // Atomic flag to signal execution interruption
+	reason    error             // Textual reason for the interruption
+}
+
+// newTokenTransferTracer returns a native go tracer which collects the value
+// transfers of a transaction.
+func newTokenTransferTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := &tokenTransferTracer{}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnEnter: t.OnEnter,
+			OnExit:  t.OnExit,
+			OnLog:   t.OnLog,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *tokenTransferTracer) OnEnter(depth int, opcode byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	var transfers []tokenTransfer
+
+	// Delegated and static calls don't move any value, CALLCODE only to itself
+	switch vm.OpCode(opcode) {
+	case vm.CALL, vm.CREATE, vm.CREATE2, vm.SELFDESTRUCT:
+		if value != nil && value.Sign() > 0 {
+			transfers = append(transfers, tokenTransfer{
+				Type:  transferNative,
+				From:  from,
+				To:    to,
+				Value: (*hexutil.Big)(new(big.Int).Set(value)),
+			})
+		}
+	}
+	t.frames = append(t.frames, transfers)
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *tokenTransferTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	// Transfers of reverted scopes never took place
+	if reverted {
+		return
+	}
+	if len(t.frames) == 0 {
+		t.result = append(t.result, frame...)
+		return
+	}
+	t.frames[len(t.frames)-1] = append(t.frames[len(t.frames)-1], frame...)
+}
+
+// OnLog is called when a log is emitted, it decodes the token transfer events.
+func (t *tokenTransferTracer) OnLog(log *types.Log) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != transferEventTopic {
+		return
+	}
+	token := log.Address
+	transfer := tokenTransfer{Token: &token}
+
+	switch {
+	case len(log.Topics) == 3 && len(log.Data) == 32:
+		// Transfer(address indexed from, address indexed to, uint256 value)
+		transfer.Type = transferERC20
+		transfer.Value = (*hexutil.Big)(new(big.Int).SetBytes(log.Data))
+
+	case len(log.Topics) == 4 && len(log.Data) == 0:
+		// Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+		transfer.Type = transferERC721
+		transfer.TokenID = (*hexutil.Big)(log.Topics[3].Big())
+
+	default:
+		return
+	}
+	transfer.From = common.BytesToAddress(log.Topics[1].Bytes())
+	transfer.To = common.BytesToAddress(log.Topics[2].Bytes())
+
+	t.frames[len(t.frames)-1] = append(t.frames[len(t.frames)-1], transfer)
+}
+
+// GetResult returns the json-encoded list of transfers, and any error arising
+// from the encoding or forceful termination (via `Stop`).
+func (t *tokenTransferTracer) GetResult() (json.RawMessage, error) {
+	result := t.result
+	if result == nil {
+		result = []tokenTransfer{}
+	}
+	res, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *tokenTransferTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
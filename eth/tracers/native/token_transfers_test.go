@@ -0,0 +1,74 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenTransfers(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("tokenTransfers", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	var (
+		sender   = common.HexToAddress("0x01")
+		erc20    = common.HexToAddress("0xaa")
+		erc721   = common.HexToAddress("0xbb")
+		reverted = common.HexToAddress("0xcc")
+		lib      = common.HexToAddress("0xdd")
+		topic    = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+		from     = common.BytesToHash(sender.Bytes())
+		to       = common.BytesToHash(erc721.Bytes())
+	)
+	// Top level call transferring ether and tokens
+	tracer.OnEnter(0, byte(vm.CALL), sender, erc20, nil, 0, big.NewInt(1000))
+	tracer.OnLog(&types.Log{Address: erc20, Topics: []common.Hash{topic, from, to}, Data: common.LeftPadBytes([]byte{100}, 32)})
+
+	// Nested call minting an NFT, including a non-standard Transfer which is ignored
+	tracer.OnEnter(1, byte(vm.CALL), erc20, erc721, nil, 0, big.NewInt(5))
+	tracer.OnLog(&types.Log{Address: erc721, Topics: []common.Hash{topic, {}, from, common.BigToHash(big.NewInt(42))}})
+	tracer.OnLog(&types.Log{Address: erc721, Topics: []common.Hash{topic, from}, Data: make([]byte, 64)})
+	tracer.OnExit(1, nil, 0, nil, false)
+
+	// Reverted call, all transfers are discarded
+	tracer.OnEnter(1, byte(vm.CALL), erc20, reverted, nil, 0, big.NewInt(7))
+	tracer.OnLog(&types.Log{Address: reverted, Topics: []common.Hash{topic, from, to}, Data: make([]byte, 32)})
+	tracer.OnExit(1, nil, 0, vm.ErrExecutionReverted, true)
+
+	// Delegated call doesn't move any value
+	tracer.OnEnter(1, byte(vm.DELEGATECALL), erc20, lib, nil, 0, big.NewInt(1000))
+	tracer.OnExit(1, nil, 0, nil, false)
+	tracer.OnExit(0, nil, 0, nil, false)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	want := `[` +
+		`{"type":"native","from":"0x0000000000000000000000000000000000000001","to":"0x00000000000000000000000000000000000000aa","value":"0x3e8"},` +
+		`{"type":"erc20","token":"0x00000000000000000000000000000000000000aa","from":"0x0000000000000000000000000000000000000001","to":"0x00000000000000000000000000000000000000bb","value":"0x64"},` +
+		`{"type":"native","from":"0x00000000000000000000000000000000000000aa","to":"0x00000000000000000000000000000000000000bb","value":"0x5"},` +
+		`{"type":"erc721","token":"0x00000000000000000000000000000000000000bb","from":"0x0000000000000000000000000000000000000000","to":"0x0000000000000000000000000000000000000001","tokenId":"0x2a"}` +
+		`]`
+	require.JSONEq(t, want, string(res))
+}
@@ -53,6 +53,10 @@ import (
 // allowed to produce in order to speed up calculations.
 const estimateGasErrorRatio = 0.015
 
+// rpcTxMaxSize is the size of the largest transaction accepted over RPC, that
+// of a blob transaction with its sidecar. The pools apply their own limits.
+const rpcTxMaxSize = 1024 * 1024
+
 var errBlobTxNotSupported = errors.New("signing blob transactions not supported")
 
 // EthereumAPI provides an API to access Ethereum related information.
@@ -86,6 +90,17 @@ func (api *EthereumAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big,
 	return (*hexutil.Big)(tipcap), err
 }
 
+// PredictMaxPriorityFeePerGas returns the gas tip cap needed for a dynamic fee
+// transaction to be included in the next block, predicted from the content of
+// the transaction pool.
+func (api *EthereumAPI) PredictMaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tipcap, err := api.b.PredictGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tipcap), nil
+}
+
 type feeHistoryResult struct {
 	OldestBlock      *hexutil.Big     `json:"oldestBlock"`
 	Reward           [][]*hexutil.Big `json:"reward,omitempty"`
@@ -1413,6 +1428,10 @@ func (api *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*ty
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	// Reject absurd transactions before doing anything with them
+	if err := tx.SanityCheck(rpcTxMaxSize); err != nil {
+		return common.Hash{}, err
+	}
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
@@ -17,6 +17,8 @@
 package miner
 
 import (
+	"bytes"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -36,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
 var (
@@ -52,6 +55,9 @@ var (
 	testUserKey, _  = crypto.GenerateKey()
 	testUserAddress = crypto.PubkeyToAddress(testUserKey.PublicKey)
 
+	testSystemKey, _  = crypto.GenerateKey()
+	testSystemAddress = crypto.PubkeyToAddress(testSystemKey.PublicKey)
+
 	// Test transactions
 	pendingTxs []*types.Transaction
 	newTxs     []*types.Transaction
@@ -107,7 +113,10 @@ type testWorkerBackend struct {
 func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, n int) *testWorkerBackend {
 	var gspec = &core.Genesis{
 		Config: chainConfig,
-		Alloc:  types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
+		Alloc: types.GenesisAlloc{
+			testBankAddress:   {Balance: testBankFunds},
+			testSystemAddress: {Balance: testBankFunds},
+		},
 	}
 	switch e := engine.(type) {
 	case *clique.Clique:
@@ -194,6 +203,128 @@ func TestBuildPayload(t *testing.T) {
 	}
 }
 
+func TestBuildPayloadSystemTxs(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), db, 0)
+
+	// The system transaction is sent by an account outside of the pool, without
+	// any tip above the base fee.
+	systemTx := types.MustSignNewTx(testSystemKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		To:        &common.Address{0x01},
+		Gas:       params.TxGas,
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+		GasTipCap: new(big.Int),
+	})
+	args := &BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		SystemTxs:    []*types.Transaction{systemTx},
+	}
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	enc, _ := systemTx.MarshalBinary()
+	verify := func(outer *engine.ExecutionPayloadEnvelope, txs int) {
+		payload := outer.ExecutionPayload
+		if len(payload.Transactions) != txs {
+			t.Fatalf("Unexpected transaction count: have %d, want %d", len(payload.Transactions), txs)
+		}
+		if !bytes.Equal(payload.Transactions[0], enc) {
+			t.Fatal("System transaction not placed first")
+		}
+	}
+	verify(payload.ResolveEmpty(), 1)
+	verify(payload.ResolveFull(), 1+len(pendingTxs))
+
+	// The system transaction is included in the receipts and their root
+	res := w.generateWork(&generateParams{
+		timestamp:  args.Timestamp,
+		parentHash: args.Parent,
+		coinbase:   args.FeeRecipient,
+		noTxs:      true,
+		systemTxs:  args.SystemTxs,
+	}, false)
+	if res.err != nil {
+		t.Fatalf("Failed to generate work: %v", res.err)
+	}
+	if len(res.receipts) != 1 || res.receipts[0].TxHash != systemTx.Hash() || res.receipts[0].Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("Unexpected receipts: %v", res.receipts)
+	}
+	if res.block.GasUsed() != params.TxGas {
+		t.Fatalf("Unexpected gas used: have %d, want %d", res.block.GasUsed(), params.TxGas)
+	}
+	if root := types.DeriveSha(types.Receipts(res.receipts), trie.NewStackTrie(nil)); root != res.block.ReceiptHash() {
+		t.Fatalf("Receipt root mismatch: have %x, want %x", res.block.ReceiptHash(), root)
+	}
+	// The built block must be accepted by a node importing it
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, b.genesis, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create importing chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(types.Blocks{res.block}); err != nil {
+		t.Fatalf("Failed to import block with system transaction: %v", err)
+	}
+	if chain.CurrentBlock().Root != res.block.Root() {
+		t.Fatalf("Imported state root mismatch: have %x, want %x", chain.CurrentBlock().Root, res.block.Root())
+	}
+	// Logs of system transactions are attributed to them, keeping the block importable
+	logger := types.MustSignNewTx(testSystemKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		Gas:       100_000,
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+		GasTipCap: new(big.Int),
+		Data:      []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG0), byte(vm.STOP)},
+	})
+	res = w.generateWork(&generateParams{
+		timestamp:  args.Timestamp,
+		parentHash: args.Parent,
+		coinbase:   args.FeeRecipient,
+		noTxs:      true,
+		systemTxs:  []*types.Transaction{logger},
+	}, false)
+	if res.err != nil {
+		t.Fatalf("Failed to generate work: %v", res.err)
+	}
+	if logs := res.receipts[0].Logs; len(logs) != 1 || logs[0].TxHash != logger.Hash() {
+		t.Fatalf("Unexpected system transaction logs: %v", logs)
+	}
+	chain, err = core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, b.genesis, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create importing chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(types.Blocks{res.block}); err != nil {
+		t.Fatalf("Failed to import block with logging system transaction: %v", err)
+	}
+	// Invalid system transactions abort the building
+	args.SystemTxs = []*types.Transaction{systemTx, systemTx}
+	if _, err := w.buildPayload(args, false); err == nil {
+		t.Fatal("Payload built with a replayed system transaction")
+	}
+	// System transactions not paying the base fee would be rejected on import
+	underpriced := types.MustSignNewTx(testSystemKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		To:        &common.Address{0x01},
+		Gas:       params.TxGas,
+		GasFeeCap: new(big.Int),
+		GasTipCap: new(big.Int),
+	})
+	args.SystemTxs = []*types.Transaction{underpriced}
+	if _, err := w.buildPayload(args, false); !errors.Is(err, core.ErrFeeCapTooLow) {
+		t.Fatalf("Underpriced system transaction error mismatch: have %v, want %v", err, core.ErrFeeCapTooLow)
+	}
+}
+
 func TestPayloadId(t *testing.T) {
 	t.Parallel()
 	ids := make(map[string]int)
@@ -83,14 +83,15 @@ type newPayloadResult struct {
 
 // generateParams wraps various settings for generating sealing task.
 type generateParams struct {
-	timestamp   uint64            // The timestamp for sealing task
-	forceTime   bool              // Flag whether the given timestamp is immutable or not
-	parentHash  common.Hash       // Parent block hash, empty means the latest chain head
-	coinbase    common.Address    // The fee recipient address for including transaction
-	random      common.Hash       // The randomness generated by beacon chain, empty before the merge
-	withdrawals types.Withdrawals // List of withdrawals to include in block (shanghai field)
-	beaconRoot  *common.Hash      // The beacon root (cancun field).
-	noTxs       bool              // Flag whether an empty block without any transaction is expected
+	timestamp   uint64               // The timestamp for sealing task
+	forceTime   bool                 // Flag whether the given timestamp is immutable or not
+	parentHash  common.Hash          // Parent block hash, empty means the latest chain head
+	coinbase    common.Address       // The fee recipient address for including transaction
+	random      common.Hash          // The randomness generated by beacon chain, empty before the merge
+	withdrawals types.Withdrawals    // List of withdrawals to include in block (shanghai field)
+	beaconRoot  *common.Hash         // The beacon root (cancun field).
+	noTxs       bool                 // Flag whether an empty block without any transaction is expected
+	systemTxs   []*types.Transaction // Transactions placed first in the block, bypassing the pool
 }
 
 // generateWork generates a sealing block based on the given parameters.
@@ -99,6 +100,9 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 	if err != nil {
 		return &newPayloadResult{err: err}
 	}
+	if err := miner.commitSystemTransactions(work, params.systemTxs); err != nil {
+		return &newPayloadResult{err: err}
+	}
 	if !params.noTxs {
 		interrupt := new(atomic.Int32)
 		timer := time.AfterFunc(miner.config.Recommit, func() {
@@ -272,6 +276,38 @@ func (miner *Miner) commitTransaction(env *environment, tx *types.Transaction) e
 	return nil
 }
 
+// commitSystemTransactions executes the system transactions at the top of the
+// block. They bypass the pool and its minimum tip, but not the fee rules: the
+// importing nodes validate them like any other transaction, so they must pay the
+// base fee for the built block to import. Failing to apply any of them aborts the
+// block building.
+func (miner *Miner) commitSystemTransactions(env *environment, txs []*types.Transaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	for i, tx := range txs {
+		if tx.Type() == types.BlobTxType {
+			return fmt.Errorf("system transaction %d: blob transactions not supported", i)
+		}
+		msg, err := core.TransactionToMessage(tx, env.signer, env.header.BaseFee)
+		if err != nil {
+			return fmt.Errorf("system transaction %d: %w", i, err)
+		}
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		receipt, err := core.ApplyTransactionWithEVM(msg, env.gasPool, env.state, env.header.Number, env.header.Hash(), tx, &env.header.GasUsed, env.evm)
+		if err != nil {
+			return fmt.Errorf("system transaction %d: %w", i, err)
+		}
+		env.txs = append(env.txs, tx)
+		env.receipts = append(env.receipts, receipt)
+		env.tcount++
+	}
+	return nil
+}
+
 func (miner *Miner) commitBlobTransaction(env *environment, tx *types.Transaction) error {
 	sc := tx.BlobTxSidecar()
 	if sc == nil {
@@ -89,6 +89,26 @@ func (api *adminAPI) RemovePeer(url string) (bool, error) {
 	return true, nil
 }
 
+// SetStaticPriority configures the given static peers to be dialed before any
+// other static peer, in the order given.
+func (api *adminAPI) SetStaticPriority(urls []string) (bool, error) {
+	// Make sure the server is running, fail otherwise
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	nodes := make([]*enode.Node, len(urls))
+	for i, url := range urls {
+		node, err := enode.Parse(enode.ValidSchemes, url)
+		if err != nil {
+			return false, fmt.Errorf("invalid enode: %v", err)
+		}
+		nodes[i] = node
+	}
+	server.SetStaticPriority(nodes)
+	return true, nil
+}
+
 // AddTrustedPeer allows a remote node to always connect, even if slots are full
 func (api *adminAPI) AddTrustedPeer(url string) (bool, error) {
 	// Make sure the server is running, fail otherwise
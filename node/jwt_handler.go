@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
@@ -75,6 +76,23 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
 	default:
-		handler.next.ServeHTTP(out, r)
+		ctx := rpc.WithAuthClaims(r.Context(), authClaims(&claims))
+		handler.next.ServeHTTP(out, r.WithContext(ctx))
 	}
 }
+
+// authClaims converts the claims of a validated token into the authentication
+// claims made available to the RPC method handlers.
+func authClaims(claims *jwt.RegisteredClaims) map[string]interface{} {
+	auth := map[string]interface{}{"iat": claims.IssuedAt.Unix()}
+	if claims.ExpiresAt != nil {
+		auth["exp"] = claims.ExpiresAt.Unix()
+	}
+	if claims.Subject != "" {
+		auth["sub"] = claims.Subject
+	}
+	if claims.ID != "" {
+		auth["jti"] = claims.ID
+	}
+	return auth
+}
@@ -401,7 +401,7 @@ func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSe
 	if len(jwtSecret) != 0 {
 		handler = newJWTHandler(jwtSecret, handler)
 	}
-	return newGzipHandler(handler)
+	return newGzipHandler(handler, gzipMinSize)
 }
 
 // NewWSHandlerStack returns a wrapped ws-related handler.
@@ -472,6 +472,11 @@ func (h *virtualHostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "invalid host specified", http.StatusForbidden)
 }
 
+// gzipMinSize is the minimum size of responses compressed by the HTTP handler.
+// Smaller responses are sent as is, since compressing them doesn't save enough
+// to make up for the overhead.
+const gzipMinSize = 1024
+
 var gzPool = sync.Pool{
 	New: func() interface{} {
 		w := gzip.NewWriter(io.Discard)
@@ -480,17 +485,22 @@ var gzPool = sync.Pool{
 }
 
 type gzipResponseWriter struct {
-	resp http.ResponseWriter
+	resp    http.ResponseWriter
+	minSize int // minimum size of the response to compress
 
 	gz            *gzip.Writer
+	buf           []byte // beginning of the response, held back until compression is decided
+	status        int    // status code, held back until compression is decided
 	contentLength uint64 // total length of the uncompressed response
 	written       uint64 // amount of written bytes from the uncompressed response
 	hasLength     bool   // true if uncompressed response had Content-Length
 	inited        bool   // true after init was called for the first time
+	decided       bool   // true after it was decided whether to compress
 }
 
-// init runs just before response headers are written. Among other things, this function
-// also decides whether compression will be applied at all.
+// init runs just before the first response headers or data are written. Among
+// other things, this function also decides whether compression will be applied
+// at all, if the response size allows doing so early.
 func (w *gzipResponseWriter) init() {
 	if w.inited {
 		return
@@ -516,12 +526,31 @@ func (w *gzipResponseWriter) init() {
 	// these cases, we want to avoid chunked transfer encoding and compression because
 	// they require additional output that may not get written in time.
 	passthrough := hdr.Get("transfer-encoding") == "identity"
-	if !passthrough {
+	switch {
+	case passthrough:
+		w.decide(false)
+	case w.hasLength:
+		w.decide(w.contentLength >= uint64(w.minSize))
+	case w.minSize <= 0:
+		w.decide(true)
+	}
+}
+
+// decide sets up the response for compression or not, flushing out the headers
+// held back so far.
+func (w *gzipResponseWriter) decide(compress bool) {
+	w.decided = true
+	if compress {
 		w.gz = gzPool.Get().(*gzip.Writer)
 		w.gz.Reset(w.resp)
+
+		hdr := w.resp.Header()
 		hdr.Del("content-length")
 		hdr.Set("content-encoding", "gzip")
 	}
+	if w.status != 0 {
+		w.resp.WriteHeader(w.status)
+	}
 }
 
 func (w *gzipResponseWriter) Header() http.Header {
@@ -530,12 +559,44 @@ func (w *gzipResponseWriter) Header() http.Header {
 
 func (w *gzipResponseWriter) WriteHeader(status int) {
 	w.init()
+	if !w.decided {
+		w.status = status
+		return
+	}
 	w.resp.WriteHeader(status)
 }
 
 func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 	w.init()
 
+	// Without the response length known upfront, hold the response back until
+	// it's large enough to be compressed.
+	if !w.decided {
+		if len(w.buf)+len(b) < w.minSize {
+			w.buf = append(w.buf, b...)
+			return len(b), nil
+		}
+		w.decide(true)
+		if err := w.flushBuffer(); err != nil {
+			return 0, err
+		}
+	}
+	return w.write(b)
+}
+
+// flushBuffer writes out the part of the response held back while it wasn't
+// decided whether to compress it.
+func (w *gzipResponseWriter) flushBuffer() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	buf := w.buf
+	w.buf = nil
+	_, err := w.write(buf)
+	return err
+}
+
+func (w *gzipResponseWriter) write(b []byte) (int, error) {
 	if w.gz == nil {
 		// Compression is disabled.
 		return w.resp.Write(b)
@@ -553,6 +614,15 @@ func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 }
 
 func (w *gzipResponseWriter) Flush() {
+	// Flushing requires sending out the response written so far. If it's still too
+	// small for compression, send the rest of it uncompressed too.
+	if !w.decided {
+		w.init()
+	}
+	if !w.decided {
+		w.decide(false)
+		w.flushBuffer()
+	}
 	if w.gz != nil {
 		w.gz.Flush()
 	}
@@ -562,6 +632,11 @@ func (w *gzipResponseWriter) Flush() {
 }
 
 func (w *gzipResponseWriter) close() {
+	// Responses ending below the compression threshold are sent as is.
+	if w.inited && !w.decided {
+		w.decide(false)
+		w.flushBuffer()
+	}
 	if w.gz == nil {
 		return
 	}
@@ -570,14 +645,18 @@ func (w *gzipResponseWriter) close() {
 	w.gz = nil
 }
 
-func newGzipHandler(next http.Handler) http.Handler {
+// newGzipHandler wraps the handler to compress responses of at least minSize
+// bytes for clients accepting it. Responses of unknown length are buffered
+// until reaching minSize, so the compression decision covers the complete
+// response, e.g. a whole batch response.
+func newGzipHandler(next http.Handler, minSize int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		wrapper := &gzipResponseWriter{resp: w}
+		wrapper := &gzipResponseWriter{resp: w, minSize: minSize}
 		defer wrapper.close()
 
 		next.ServeHTTP(wrapper, r)
@@ -18,6 +18,7 @@ package node
 
 import (
 	"bytes"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"net/http"
@@ -523,7 +524,7 @@ func TestGzipHandler(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			srv := httptest.NewServer(newGzipHandler(test.handler))
+			srv := httptest.NewServer(newGzipHandler(test.handler, 0))
 			defer srv.Close()
 
 			resp, err := http.Get(srv.URL)
@@ -556,6 +557,156 @@ func TestGzipHandler(t *testing.T) {
 	}
 }
 
+func TestGzipHandlerMinSize(t *testing.T) {
+	const minSize = 64
+	var (
+		small = strings.Repeat("s", minSize-1)
+		large = strings.Repeat("l", minSize)
+	)
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		content string
+		status  int
+		isGzip  bool
+	}{
+		{
+			name: "small",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(small))
+			},
+			content: small,
+			status:  200,
+		},
+		{
+			name: "large-chunked",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(large[:10]))
+				w.Write([]byte(large[10:]))
+			},
+			content: large,
+			status:  200,
+			isGzip:  true,
+		},
+		{
+			name: "small-WriteHeader",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(205)
+				w.Write([]byte(small))
+			},
+			content: small,
+			status:  205,
+		},
+		{
+			name: "large-WriteHeader",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(205)
+				w.Write([]byte(large))
+			},
+			content: large,
+			status:  205,
+			isGzip:  true,
+		},
+		{
+			name: "small-ContentLength",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-length", strconv.Itoa(len(small)))
+				w.Write([]byte(small))
+			},
+			content: small,
+			status:  200,
+		},
+		{
+			name: "large-ContentLength",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-length", strconv.Itoa(len(large)))
+				w.Write([]byte(large))
+			},
+			content: large,
+			status:  200,
+			isGzip:  true,
+		},
+		{
+			name: "Flush",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(large[:10]))
+				w.(http.Flusher).Flush()
+				w.Write([]byte(large[10:]))
+			},
+			content: large,
+			status:  200,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(newGzipHandler(test.handler, minSize))
+			defer srv.Close()
+
+			resp, err := http.Get(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			content, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != test.content {
+				t.Fatalf("wrong response content %q", content)
+			}
+			if resp.Uncompressed != test.isGzip {
+				t.Fatalf("response gzipped == %t, want %t", resp.Uncompressed, test.isGzip)
+			}
+			if resp.StatusCode != test.status {
+				t.Fatalf("response status == %d, want %d", resp.StatusCode, test.status)
+			}
+		})
+	}
+}
+
+// This checks that batch responses are compressed as a whole once large enough.
+func TestHTTPGzipBatch(t *testing.T) {
+	const greetRes = `{"jsonrpc":"2.0","id":1,"result":"Hello"}`
+
+	srv := createAndStartServer(t, &httpConfig{Modules: []string{"test"}}, false, &wsConfig{}, nil)
+	defer srv.stop()
+	url := fmt.Sprintf("http://%v", srv.listenAddr())
+
+	// Small responses are not compressed
+	resp := rpcRequest(t, url, "test_greet", "accept-encoding", "gzip")
+	if enc := resp.Header.Get("content-encoding"); enc != "" {
+		t.Fatalf("small response encoded with %q", enc)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if strings.TrimSpace(string(body)) != greetRes {
+		t.Fatalf("wrong response: have %s, want %s", body, greetRes)
+	}
+	// Large batch responses are
+	var (
+		methods = make([]string, 2*gzipMinSize/len(greetRes))
+		results = make([]string, len(methods))
+	)
+	for i := range methods {
+		methods[i], results[i] = "test_greet", greetRes
+	}
+	resp = batchRpcRequest(t, url, methods, "accept-encoding", "gzip")
+	if enc := resp.Header.Get("content-encoding"); enc != "gzip" {
+		t.Fatalf("batch response encoded with %q, want gzip", enc)
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err = io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[" + strings.Join(results, ",") + "]"; strings.TrimSpace(string(body)) != want {
+		t.Fatalf("wrong batch response: have %s, want %s", body, want)
+	}
+}
+
 func TestHTTPWriteTimeout(t *testing.T) {
 	const (
 		timeoutRes = `{"jsonrpc":"2.0","id":1,"error":{"code":-32002,"message":"request timed out"}}`
@@ -4,6 +4,7 @@ package p2p
 
 import (
 	"crypto/ecdsa"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -36,6 +37,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		NAT              nat.Interface `toml:",omitempty"`
 		Dialer           NodeDialer    `toml:"-"`
 		NoDial           bool          `toml:",omitempty"`
+		DrainTimeout     time.Duration `toml:",omitempty"`
 		EnableMsgEvents  bool
 		Logger           log.Logger `toml:"-"`
 	}
@@ -60,6 +62,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.NAT = c.NAT
 	enc.Dialer = c.Dialer
 	enc.NoDial = c.NoDial
+	enc.DrainTimeout = c.DrainTimeout
 	enc.EnableMsgEvents = c.EnableMsgEvents
 	enc.Logger = c.Logger
 	return &enc, nil
@@ -85,9 +88,10 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Protocols        []Protocol       `toml:"-" json:"-"`
 		ListenAddr       *string
 		DiscAddr         *string
-		NAT              *configNAT `toml:",omitempty"`
-		Dialer           NodeDialer `toml:"-"`
-		NoDial           *bool      `toml:",omitempty"`
+		NAT              *configNAT     `toml:",omitempty"`
+		Dialer           NodeDialer     `toml:"-"`
+		NoDial           *bool          `toml:",omitempty"`
+		DrainTimeout     *time.Duration `toml:",omitempty"`
 		EnableMsgEvents  *bool
 		Logger           log.Logger `toml:"-"`
 	}
@@ -155,6 +159,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.NoDial != nil {
 		c.NoDial = *dec.NoDial
 	}
+	if dec.DrainTimeout != nil {
+		c.DrainTimeout = *dec.DrainTimeout
+	}
 	if dec.EnableMsgEvents != nil {
 		c.EnableMsgEvents = *dec.EnableMsgEvents
 	}
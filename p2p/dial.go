@@ -42,6 +42,11 @@ const (
 	// private networks.
 	dialHistoryExpiration = inboundThrottleTime + 5*time.Second
 
+	// This is the redial delay for prioritized static nodes. It is shorter than
+	// dialHistoryExpiration to reconnect business-critical peers quickly, at the
+	// cost of hitting the inbound throttle of remote nodes not on the LAN.
+	priorityDialHistoryExpiration = 10 * time.Second
+
 	// Config for the "Looking for peers" message.
 	dialStatsLogInterval = 10 * time.Second // printed at most this often
 	dialStatsPeerLimit   = 3                // but not if more than this many dialed peers
@@ -102,6 +107,7 @@ type dialScheduler struct {
 	doneCh        chan *dialTask
 	addStaticCh   chan *enode.Node
 	remStaticCh   chan *enode.Node
+	priorityCh    chan []enode.ID
 	addPeerCh     chan *conn
 	remPeerCh     chan *conn
 
@@ -118,6 +124,11 @@ type dialScheduler struct {
 	static     map[enode.ID]*dialTask
 	staticPool []*dialTask
 
+	// The priority map tracks the dial order of prioritized static nodes. Usable
+	// prioritized tasks are always launched before any other static task, lower
+	// values first.
+	priority map[enode.ID]int
+
 	// The dial history keeps recently dialed nodes. Members of history are not dialed.
 	history      expHeap
 	historyTimer *mclock.Alarm
@@ -169,11 +180,13 @@ func newDialScheduler(config dialConfig, it enode.Iterator, setupFunc dialSetupF
 		dnsLookupFunc: net.DefaultResolver.LookupNetIP,
 		dialing:       make(map[enode.ID]*dialTask),
 		static:        make(map[enode.ID]*dialTask),
+		priority:      make(map[enode.ID]int),
 		peers:         make(map[enode.ID]struct{}),
 		doneCh:        make(chan *dialTask),
 		nodesIn:       make(chan *enode.Node),
 		addStaticCh:   make(chan *enode.Node),
 		remStaticCh:   make(chan *enode.Node),
+		priorityCh:    make(chan []enode.ID),
 		addPeerCh:     make(chan *conn),
 		remPeerCh:     make(chan *conn),
 	}
@@ -207,6 +220,14 @@ func (d *dialScheduler) removeStatic(n *enode.Node) {
 	}
 }
 
+// setStaticPriority replaces the dial order of prioritized static nodes.
+func (d *dialScheduler) setStaticPriority(ids []enode.ID) {
+	select {
+	case d.priorityCh <- ids:
+	case <-d.ctx.Done():
+	}
+}
+
 // peerAdded updates the peer set.
 func (d *dialScheduler) peerAdded(c *conn) {
 	select {
@@ -300,6 +321,15 @@ loop:
 				}
 			}
 
+		case ids := <-d.priorityCh:
+			d.log.Trace("Updating static node priority", "count", len(ids))
+			clear(d.priority)
+			for i, id := range ids {
+				if _, exists := d.priority[id]; !exists {
+					d.priority[id] = i
+				}
+			}
+
 		case <-d.historyTimer.C():
 			d.expireHistory()
 
@@ -399,10 +429,14 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	return nil
 }
 
-// startStaticDials starts n static dial tasks.
+// startStaticDials starts n static dial tasks. Prioritized tasks are started
+// first, the remaining ones are selected at random.
 func (d *dialScheduler) startStaticDials(n int) (started int) {
 	for started = 0; started < n && len(d.staticPool) > 0; started++ {
-		idx := d.rand.Intn(len(d.staticPool))
+		idx := d.prioritizedStaticIndex()
+		if idx < 0 {
+			idx = d.rand.Intn(len(d.staticPool))
+		}
 		task := d.staticPool[idx]
 		d.startDial(task)
 		d.removeFromStaticPool(idx)
@@ -410,6 +444,22 @@ func (d *dialScheduler) startStaticDials(n int) (started int) {
 	return started
 }
 
+// prioritizedStaticIndex returns the staticPool index of the usable static task
+// with the highest priority, or -1 if no prioritized task is in the pool.
+func (d *dialScheduler) prioritizedStaticIndex() int {
+	best, bestPrio := -1, 0
+	if len(d.priority) == 0 {
+		return best
+	}
+	for i, task := range d.staticPool {
+		prio, ok := d.priority[task.dest().ID()]
+		if ok && (best < 0 || prio < bestPrio) {
+			best, bestPrio = i, prio
+		}
+	}
+	return best
+}
+
 // updateStaticPool attempts to move the given static dial back into staticPool.
 func (d *dialScheduler) updateStaticPool(id enode.ID) {
 	task, ok := d.static[id]
@@ -501,7 +551,11 @@ func (d *dialScheduler) startDial(task *dialTask) {
 	node := task.dest()
 	d.log.Trace("Starting p2p dial", "id", node.ID(), "endpoint", nodeEndpointForLog(node), "flag", task.flags)
 	hkey := string(node.ID().Bytes())
-	d.history.add(hkey, d.clock.Now().Add(dialHistoryExpiration))
+	expiration := dialHistoryExpiration
+	if _, ok := d.priority[node.ID()]; ok && task.isStatic() {
+		expiration = priorityDialHistoryExpiration
+	}
+	d.history.add(hkey, d.clock.Now().Add(expiration))
 	d.dialing[node.ID()] = task
 	go func() {
 		task.run(d)
@@ -312,6 +312,75 @@ func TestDialSchedManyStaticNodes(t *testing.T) {
 	})
 }
 
+// This test checks that prioritized static nodes are dialed first, in order,
+// and retried sooner than other static nodes.
+func TestDialSchedStaticPriority(t *testing.T) {
+	t.Parallel()
+
+	config := dialConfig{
+		maxActiveDials: 2,
+		maxDialPeers:   1,
+	}
+	runDialTest(t, config, []dialTestRound{
+		// The dial slots are occupied, static nodes are queued.
+		{
+			peersAdded: []*conn{
+				{flags: dynDialedConn, node: newNode(uintID(0xFF), "127.0.0.255:30303")},
+			},
+			update: func(d *dialScheduler) {
+				d.setStaticPriority([]enode.ID{uintID(0x04), uintID(0x02)})
+				for id := uint16(0x01); id <= 0x05; id++ {
+					d.addStatic(newNode(uintID(id), fmt.Sprintf("127.0.0.%d:30303", id)))
+				}
+			},
+		},
+		// The dynamic peer drops, prioritized nodes are dialed.
+		{
+			peersRemoved: []enode.ID{
+				uintID(0xFF),
+			},
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x04), "127.0.0.4:30303"),
+				newNode(uintID(0x02), "127.0.0.2:30303"),
+			},
+		},
+		// Both dials fail. Their history entries have already expired, so
+		// they are retried ahead of the other static nodes.
+		{
+			failed: []enode.ID{
+				uintID(0x04),
+				uintID(0x02),
+			},
+			wantResolves: map[enode.ID]*enode.Node{
+				uintID(0x04): nil,
+				uintID(0x02): nil,
+			},
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x04), "127.0.0.4:30303"),
+				newNode(uintID(0x02), "127.0.0.2:30303"),
+			},
+		},
+		// The priority is dropped, the remaining static nodes are dialed.
+		{
+			update: func(d *dialScheduler) {
+				d.setStaticPriority(nil)
+			},
+			failed: []enode.ID{
+				uintID(0x04),
+				uintID(0x02),
+			},
+			wantResolves: map[enode.ID]*enode.Node{
+				uintID(0x04): nil,
+				uintID(0x02): nil,
+			},
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x01), "127.0.0.1:30303"),
+				newNode(uintID(0x05), "127.0.0.5:30303"),
+			},
+		},
+	})
+}
+
 // This test checks that past dials are not retried for some time.
 func TestDialSchedHistory(t *testing.T) {
 	t.Parallel()
@@ -24,6 +24,7 @@ package discover
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/netip"
 	"slices"
@@ -174,6 +175,41 @@ func (tab *Table) Nodes() [][]BucketNode {
 	return nodes
 }
 
+// CheckLiveness pings the given node out of band of the regular revalidation and
+// reports whether it responded within the timeout. The node doesn't need to be
+// contained in the table. Failures unrelated to the liveness of the node, like
+// a missing UDP endpoint or a closed table, are returned as errors.
+func (tab *Table) CheckLiveness(n *enode.Node, timeout time.Duration) (bool, error) {
+	select {
+	case <-tab.closeReq:
+		return false, errClosed
+	default:
+	}
+	errc := make(chan error, 1)
+	go func() {
+		_, err := tab.net.ping(n)
+		errc <- err
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errc:
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, errTimeout):
+			return false, nil
+		default:
+			return false, err
+		}
+	case <-timer.C:
+		return false, nil
+	case <-tab.closeReq:
+		return false, errClosed
+	}
+}
+
 func (tab *Table) self() *enode.Node {
 	return tab.net.Self()
 }
@@ -180,6 +180,32 @@ func TestTable_BucketIPLimit(t *testing.T) {
 	checkIPLimitInvariant(t, tab)
 }
 
+func TestTable_CheckLiveness(t *testing.T) {
+	transport := newPingRecorder()
+	tab, db := newTestTable(transport, Config{})
+	defer db.Close()
+
+	var (
+		alive = nodeAtDistance(tab.self().ID(), 200, net.IP{172, 0, 1, 1})
+		dead  = nodeAtDistance(tab.self().ID(), 200, net.IP{172, 0, 1, 2})
+	)
+	transport.dead[dead.ID()] = true
+
+	if live, err := tab.CheckLiveness(alive, time.Second); err != nil || !live {
+		t.Errorf("live node: have live=%v err=%v, want live", live, err)
+	}
+	if live, err := tab.CheckLiveness(dead, time.Second); err != nil || live {
+		t.Errorf("dead node: have live=%v err=%v, want dead", live, err)
+	}
+	if len(transport.pinged) != 2 {
+		t.Errorf("wrong number of pings: have %d, want 2", len(transport.pinged))
+	}
+	tab.close()
+	if _, err := tab.CheckLiveness(alive, time.Second); err == nil {
+		t.Error("expected error on closed table")
+	}
+}
+
 // checkIPLimitInvariant checks that ip limit sets contain an entry for every
 // node in the table and no extra entries.
 func checkIPLimitInvariant(t *testing.T, tab *Table) {
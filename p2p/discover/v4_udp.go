@@ -216,6 +216,12 @@ func (t *UDPv4) Ping(n *enode.Node) error {
 	return err
 }
 
+// CheckLiveness pings the given node and reports whether it responded within
+// the timeout. See Table.CheckLiveness for details.
+func (t *UDPv4) CheckLiveness(n *enode.Node, timeout time.Duration) (bool, error) {
+	return t.tab.CheckLiveness(n, timeout)
+}
+
 // ping sends a ping message to the given node and waits for a reply.
 func (t *UDPv4) ping(n *enode.Node) (seq uint64, err error) {
 	addr, ok := n.UDPEndpoint()
@@ -206,6 +206,12 @@ func (t *UDPv5) Ping(n *enode.Node) error {
 	return err
 }
 
+// CheckLiveness pings the given node and reports whether it responded within
+// the timeout. See Table.CheckLiveness for details.
+func (t *UDPv5) CheckLiveness(n *enode.Node, timeout time.Duration) (bool, error) {
+	return t.tab.CheckLiveness(n, timeout)
+}
+
 // Resolve searches for a specific node with the given ID and tries to get the most recent
 // version of the node record for it. It returns n if the node could not be resolved.
 func (t *UDPv5) Resolve(n *enode.Node) *enode.Node {
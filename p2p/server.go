@@ -67,6 +67,7 @@ const (
 
 var (
 	errServerStopped       = errors.New("server stopped")
+	errDrainTimeout        = errors.New("timed out draining peers")
 	errEncHandshakeError   = errors.New("rlpx enc error")
 	errProtoHandshakeError = errors.New("rlpx proto error")
 )
@@ -107,6 +108,7 @@ type Server struct {
 	removetrusted           chan *enode.Node
 	peerOp                  chan peerOpFunc
 	peerOpDone              chan struct{}
+	drain                   chan chan struct{}
 	delpeer                 chan peerDrop
 	checkpointPostHandshake chan *conn
 	checkpointAddPeer       chan *conn
@@ -269,6 +271,19 @@ func (srv *Server) RemovePeer(node *enode.Node) {
 	}
 }
 
+// SetStaticPriority configures the dial order of static nodes. The given nodes are
+// always dialed before any other static node, in the order given, and are redialed
+// sooner after a failed connection attempt. Calling SetStaticPriority replaces the
+// previously configured order; nodes which are not (yet) part of the static node
+// set are remembered and take effect once added through AddPeer.
+func (srv *Server) SetStaticPriority(nodes []*enode.Node) {
+	ids := make([]enode.ID, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	srv.dialsched.setStaticPriority(ids)
+}
+
 // AddTrustedPeer adds the given node to a reserved trusted list which allows the
 // node to always connect, even if the slot are full.
 func (srv *Server) AddTrustedPeer(node *enode.Node) {
@@ -313,9 +328,45 @@ func (srv *Server) DiscoveryV5() *discover.UDPv5 {
 	return srv.discv5
 }
 
+// Drain prepares the server for shutdown: it stops accepting new peers, asks all
+// connected peers to disconnect and waits up to timeout until they are gone and
+// their protocol handlers have returned. The server keeps rejecting new peers
+// until it is stopped.
+func (srv *Server) Drain(timeout time.Duration) error {
+	srv.lock.Lock()
+	if !srv.running {
+		srv.lock.Unlock()
+		return errServerStopped
+	}
+	srv.lock.Unlock()
+
+	done := make(chan struct{})
+	select {
+	case srv.drain <- done:
+	case <-srv.quit:
+		return errServerStopped
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return errDrainTimeout
+	case <-srv.quit:
+		return errServerStopped
+	}
+}
+
 // Stop terminates the server and all active peer connections.
 // It blocks until all active connections have been closed.
 func (srv *Server) Stop() {
+	if srv.DrainTimeout > 0 {
+		if err := srv.Drain(srv.DrainTimeout); err == errDrainTimeout {
+			srv.log.Debug("Peers not drained before shutdown", "err", err)
+		}
+	}
 	srv.lock.Lock()
 	if !srv.running {
 		srv.lock.Unlock()
@@ -395,6 +446,7 @@ func (srv *Server) Start() (err error) {
 	srv.removetrusted = make(chan *enode.Node)
 	srv.peerOp = make(chan peerOpFunc)
 	srv.peerOpDone = make(chan struct{})
+	srv.drain = make(chan chan struct{})
 
 	if err := srv.setupLocalNode(); err != nil {
 		return err
@@ -624,6 +676,8 @@ func (srv *Server) run() {
 		peers        = make(map[enode.ID]*Peer)
 		inboundCount = 0
 		trusted      = make(map[enode.ID]bool, len(srv.TrustedNodes))
+		draining     bool
+		drained      []chan struct{} // Drain requests waiting for the peers to leave
 	)
 	// Put trusted nodes into a map to speed up checks.
 	// Trusted peers are loaded on startup or added via AddTrustedPeer RPC.
@@ -661,6 +715,22 @@ running:
 			op(peers)
 			srv.peerOpDone <- struct{}{}
 
+		case done := <-srv.drain:
+			// This channel is used by Drain to disconnect all peers
+			// ahead of the shutdown.
+			if !draining {
+				srv.log.Debug("Draining p2p peers", "peercount", len(peers))
+				draining = true
+				for _, p := range peers {
+					p.Disconnect(DiscQuitting)
+				}
+			}
+			if len(peers) == 0 {
+				close(done)
+			} else {
+				drained = append(drained, done)
+			}
+
 		case c := <-srv.checkpointPostHandshake:
 			// A connection has passed the encryption handshake so
 			// the remote identity is known (but hasn't been verified yet).
@@ -669,12 +739,19 @@ running:
 				c.flags |= trustedConn
 			}
 			// TODO: track in-progress inbound node IDs (pre-Peer) to avoid dialing them.
+			if draining {
+				c.cont <- DiscQuitting
+				continue
+			}
 			c.cont <- srv.postHandshakeChecks(peers, inboundCount, c)
 
 		case c := <-srv.checkpointAddPeer:
 			// At this point the connection is past the protocol handshake.
 			// Its capabilities are known and the remote identity is verified.
 			err := srv.addPeerChecks(peers, inboundCount, c)
+			if draining {
+				err = DiscQuitting
+			}
 			if err == nil {
 				// The handshakes are done and it passed all checks.
 				p := srv.launchPeer(c)
@@ -706,6 +783,13 @@ running:
 				activeOutboundPeerGauge.Dec(1)
 			}
 			activePeerGauge.Dec(1)
+
+			if draining && len(peers) == 0 {
+				for _, done := range drained {
+					close(done)
+				}
+				drained = nil
+			}
 		}
 	}
 
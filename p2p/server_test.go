@@ -243,6 +243,115 @@ func TestServerRemovePeerDisconnect(t *testing.T) {
 	}
 }
 
+func TestServerDrain(t *testing.T) {
+	newServer := func(name string, listen bool) *Server {
+		srv := &Server{Config: Config{
+			PrivateKey:  newkey(),
+			MaxPeers:    2,
+			NoDiscovery: true,
+			Logger:      testlog.Logger(t, log.LvlTrace).New("server", name),
+		}}
+		if listen {
+			srv.NoDial = true
+			srv.ListenAddr = "127.0.0.1:0"
+		}
+		if err := srv.Start(); err != nil {
+			t.Fatalf("could not start server %s: %v", name, err)
+		}
+		return srv
+	}
+	var (
+		srv1 = newServer("1", false)
+		srv2 = newServer("2", true)
+		srv3 = newServer("3", false)
+	)
+	defer srv1.Stop()
+	defer srv2.Stop()
+	defer srv3.Stop()
+
+	_, port, _ := net.SplitHostPort(srv2.ListenAddr)
+	if port, err := strconv.Atoi(port); err == nil {
+		srv2.localnode.Set(enr.TCP(uint16(port)))
+	}
+	if !syncAddPeer(srv1, srv2.Self()) {
+		t.Fatal("peer not connected")
+	}
+	events := make(chan *PeerEvent, 8)
+	sub := srv1.SubscribeEvents(events)
+	defer sub.Unsubscribe()
+
+	// Draining disconnects the peers politely and waits for them to leave
+	if err := srv2.Drain(5 * time.Second); err != nil {
+		t.Fatalf("drain failed: %v", err)
+	}
+	if n := srv2.PeerCount(); n != 0 {
+		t.Fatalf("%d peers left after drain", n)
+	}
+	timeout := time.After(2 * time.Second)
+	for dropped := false; !dropped; {
+		select {
+		case ev := <-events:
+			if ev.Type == PeerEventTypeDrop {
+				if ev.Error != DiscQuitting.Error() {
+					t.Fatalf("wrong disconnect reason: %q", ev.Error)
+				}
+				dropped = true
+			}
+		case <-timeout:
+			t.Fatal("remote peer not dropped")
+		}
+	}
+	// New peers are rejected after draining
+	if syncAddPeer(srv3, srv2.Self()) {
+		t.Fatal("peer connected to drained server")
+	}
+	// Draining a stopped server fails
+	srv2.Stop()
+	if err := srv2.Drain(time.Second); err != errServerStopped {
+		t.Fatalf("drain of stopped server: have %v, want %v", err, errServerStopped)
+	}
+}
+
+// Tests that stopping a server waits for the peers to drain only as long as it
+// takes them to leave, not for the entire drain timeout.
+func TestServerStopDrain(t *testing.T) {
+	newServer := func(name string, listen bool) *Server {
+		srv := &Server{Config: Config{
+			PrivateKey:   newkey(),
+			MaxPeers:     1,
+			NoDiscovery:  true,
+			DrainTimeout: time.Minute,
+			Logger:       testlog.Logger(t, log.LvlTrace).New("server", name),
+		}}
+		if listen {
+			srv.NoDial = true
+			srv.ListenAddr = "127.0.0.1:0"
+		}
+		if err := srv.Start(); err != nil {
+			t.Fatalf("could not start server %s: %v", name, err)
+		}
+		return srv
+	}
+	var (
+		srv1 = newServer("1", false)
+		srv2 = newServer("2", true)
+	)
+	defer srv1.Stop()
+
+	_, port, _ := net.SplitHostPort(srv2.ListenAddr)
+	if port, err := strconv.Atoi(port); err == nil {
+		srv2.localnode.Set(enr.TCP(uint16(port)))
+	}
+	if !syncAddPeer(srv1, srv2.Self()) {
+		t.Fatal("peer not connected")
+	}
+	start := time.Now()
+	srv2.Stop()
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("stop took %v with a drain timeout of %v", elapsed, srv2.DrainTimeout)
+	}
+}
+
 // This test checks that connections are disconnected just after the encryption handshake
 // when the server is at capacity. Trusted connections should still be accepted.
 func TestServerAtCap(t *testing.T) {
@@ -109,6 +109,7 @@ type decodeError struct {
 	msg string
 	typ reflect.Type
 	ctx []string
+	err error // Underlying stream error, if any
 }
 
 func (err *decodeError) Error() string {
@@ -122,20 +123,26 @@ func (err *decodeError) Error() string {
 	return fmt.Sprintf("rlp: %s for %v%s", err.msg, err.typ, ctx)
 }
 
+// Unwrap returns the stream error the decoding error originates from, allowing
+// to match errors such as ErrCanonInt with errors.Is.
+func (err *decodeError) Unwrap() error {
+	return err.err
+}
+
 func wrapStreamError(err error, typ reflect.Type) error {
 	switch err {
 	case ErrCanonInt:
-		return &decodeError{msg: "non-canonical integer (leading zero bytes)", typ: typ}
+		return &decodeError{msg: "non-canonical integer (leading zero bytes)", typ: typ, err: err}
 	case ErrCanonSize:
-		return &decodeError{msg: "non-canonical size information", typ: typ}
+		return &decodeError{msg: "non-canonical size information", typ: typ, err: err}
 	case ErrExpectedList:
-		return &decodeError{msg: "expected input list", typ: typ}
+		return &decodeError{msg: "expected input list", typ: typ, err: err}
 	case ErrExpectedString:
-		return &decodeError{msg: "expected input string or byte", typ: typ}
+		return &decodeError{msg: "expected input string or byte", typ: typ, err: err}
 	case errUintOverflow:
-		return &decodeError{msg: "input string too long", typ: typ}
+		return &decodeError{msg: "input string too long", typ: typ, err: err}
 	case errNotAtEOL:
-		return &decodeError{msg: "input list has too many elements", typ: typ}
+		return &decodeError{msg: "input list has too many elements", typ: typ, err: err}
 	}
 	return err
 }
@@ -352,6 +352,14 @@ func TestDecodeErrors(t *testing.T) {
 	if err := Decode(r, new(uint)); err != io.EOF {
 		t.Errorf("Decode(r, new(int)) error mismatch, got %q, want %q", err, io.EOF)
 	}
+
+	// Decoding errors retain the underlying stream error
+	if err := DecodeBytes(unhex("820001"), new(uint)); !errors.Is(err, ErrCanonInt) {
+		t.Errorf("DecodeBytes(820001, new(uint)) error mismatch, got %q, want %q", err, ErrCanonInt)
+	}
+	if err := DecodeBytes(unhex("C3820001"), new([]*big.Int)); !errors.Is(err, ErrCanonInt) {
+		t.Errorf("DecodeBytes(C3820001, new([]*big.Int)) error mismatch, got %q, want %q", err, ErrCanonInt)
+	}
 }
 
 type decodeTest struct {
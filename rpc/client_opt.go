@@ -41,6 +41,9 @@ type clientConfig struct {
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+
+	// server is the RPC server serving the connection, nil for outgoing connections
+	server *Server
 }
 
 func (cfg *clientConfig) initHeaders() {
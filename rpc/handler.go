@@ -68,6 +68,7 @@ type handler struct {
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
+	server     *Server // server serving the connection, nil for outgoing connections
 }
 
 type callProc struct {
@@ -364,6 +365,9 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 	for _, n := range nn {
 		if sub := n.takeSubscription(); sub != nil {
 			h.serverSubs[sub.ID] = sub
+			if h.server != nil {
+				h.server.trackSubscription(sub.ID, h)
+			}
 		}
 	}
 }
@@ -377,7 +381,38 @@ func (h *handler) cancelServerSubscriptions(err error) {
 		s.err <- err
 		close(s.err)
 		delete(h.serverSubs, id)
+		if h.server != nil {
+			h.server.untrackSubscription(id)
+		}
+	}
+}
+
+// cancelSubscription removes the given subscription and notifies the client that
+// it has been canceled by the server.
+func (h *handler) cancelSubscription(id ID) bool {
+	h.subLock.Lock()
+	s := h.serverSubs[id]
+	if s == nil {
+		h.subLock.Unlock()
+		return false
+	}
+	s.err <- ErrSubscriptionCanceled
+	close(s.err)
+	delete(h.serverSubs, id)
+	if h.server != nil {
+		h.server.untrackSubscription(id)
 	}
+	h.subLock.Unlock()
+
+	params, _ := json.Marshal(&subscriptionResult{
+		ID:    string(id),
+		Error: &jsonError{Code: errcodeDefault, Message: ErrSubscriptionCanceled.Error()},
+	})
+	msg := &jsonrpcMessage{Version: vsn, Method: s.namespace + notificationMethodSuffix, Params: params}
+	if err := h.conn.writeJSON(context.Background(), msg, false); err != nil {
+		h.log.Debug("Failed to send subscription cancellation", "id", id, "err", err)
+	}
+	return true
 }
 
 // startCallProc runs fn in a new goroutine and starts tracking it in the h.calls wait group.
@@ -455,9 +490,17 @@ func (h *handler) handleSubscriptionResult(msg *jsonrpcMessage) {
 		h.log.Debug("Dropping invalid subscription message")
 		return
 	}
-	if h.clientSubs[result.ID] != nil {
-		h.clientSubs[result.ID].deliver(result.Result)
+	sub := h.clientSubs[result.ID]
+	if sub == nil {
+		return
+	}
+	// A notification carrying an error ends the subscription
+	if result.Error != nil {
+		delete(h.clientSubs, result.ID)
+		sub.close(result.Error)
+		return
 	}
+	sub.deliver(result.Result)
 }
 
 // handleCallMsg executes a call message and returns the answer.
@@ -494,6 +537,9 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if !h.reg.allowed(msg.Method) {
+		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -583,6 +629,9 @@ func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 	}
 	close(s.err)
 	delete(h.serverSubs, id)
+	if h.server != nil {
+		h.server.untrackSubscription(id)
+	}
 	return true, nil
 }
 
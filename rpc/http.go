@@ -19,6 +19,7 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -323,6 +324,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	setRequestPeerInfo(&connInfo, r)
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
 
@@ -335,6 +337,18 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.serveSingleRequest(ctx, codec)
 }
 
+// setRequestPeerInfo fills in the TLS identity and the authentication claims
+// of the client from the HTTP request.
+func setRequestPeerInfo(info *PeerInfo, r *http.Request) {
+	if r.TLS != nil {
+		info.TLS.Version = tls.VersionName(r.TLS.Version)
+		if len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			info.TLS.ClientSubject = r.TLS.VerifiedChains[0][0].Subject.String()
+		}
+	}
+	info.Auth, _ = r.Context().Value(authClaimsContextKey{}).(map[string]interface{})
+}
+
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func (s *Server) validateRequest(r *http.Request) (int, error) {
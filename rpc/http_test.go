@@ -222,6 +222,36 @@ func TestHTTPPeerInfo(t *testing.T) {
 	}
 }
 
+func TestHTTPPeerInfoTLSAndAuth(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	defer s.Stop()
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithAuthClaims(r.Context(), map[string]interface{}{"sub": "tester"})
+		s.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	defer ts.Close()
+
+	c, err := DialHTTPWithClient(ts.URL, ts.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info PeerInfo
+	if err := c.Call(&info, "test_peerInfo"); err != nil {
+		t.Fatal(err)
+	}
+	if info.TLS.Version == "" {
+		t.Error("TLS.Version not set")
+	}
+	if info.TLS.ClientSubject != "" {
+		t.Errorf("TLS.ClientSubject set without client certificate: %q", info.TLS.ClientSubject)
+	}
+	if info.Auth["sub"] != "tester" {
+		t.Errorf("wrong Auth %v", info.Auth)
+	}
+}
+
 func TestNewContextWithHeaders(t *testing.T) {
 	t.Parallel()
 
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -54,6 +55,9 @@ type Server struct {
 	batchItemLimit     int
 	batchResponseLimit int
 	httpBodyLimit      int
+
+	subLock sync.Mutex
+	subs    map[ID]*handler // active subscriptions across all connections
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -62,6 +66,7 @@ func NewServer() *Server {
 		idgen:         randomIDGenerator(),
 		codecs:        make(map[ServerCodec]struct{}),
 		httpBodyLimit: defaultBodyLimit,
+		subs:          make(map[ID]*handler),
 	}
 	server.run.Store(true)
 	// Register the default service providing meta information about the RPC service such
@@ -89,6 +94,20 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetMethodFilter restricts the methods served to the ones matching any of the
+// allow patterns, if given, and none of the deny patterns. Patterns ending with
+// '*' match all methods with the preceding prefix, e.g. "eth_get*", others match
+// the exact method name. Filtered out methods, including the subscription methods
+// like "eth_subscribe", are reported as not found. Calling it with empty lists
+// removes the filter.
+func (s *Server) SetMethodFilter(allow []string, deny []string) {
+	if len(allow) == 0 && len(deny) == 0 {
+		s.services.setFilter(nil)
+		return
+	}
+	s.services.setFilter(&methodFilter{allow: slices.Clone(allow), deny: slices.Clone(deny)})
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -114,6 +133,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		server:             s,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -195,6 +215,37 @@ func (s *Server) Stop() {
 	}
 }
 
+// CancelSubscription terminates the active subscription with the given ID, without
+// closing the connection it belongs to. The subscription is removed as if the
+// client had unsubscribed, and the client is notified of the cancellation with a
+// final subscription notification carrying an error instead of a result.
+//
+// It returns false if no such subscription exists.
+func (s *Server) CancelSubscription(id ID) bool {
+	s.subLock.Lock()
+	h := s.subs[id]
+	s.subLock.Unlock()
+
+	if h == nil {
+		return false
+	}
+	return h.cancelSubscription(id)
+}
+
+func (s *Server) trackSubscription(id ID, h *handler) {
+	s.subLock.Lock()
+	defer s.subLock.Unlock()
+
+	s.subs[id] = h
+}
+
+func (s *Server) untrackSubscription(id ID) {
+	s.subLock.Lock()
+	defer s.subLock.Unlock()
+
+	delete(s.subs, id)
+}
+
 // RPCService gives meta information about the server.
 // e.g. gives information about the loaded modules.
 type RPCService struct {
@@ -235,10 +286,34 @@ type PeerInfo struct {
 		Origin    string
 		Host      string
 	}
+
+	// TLS identity of HTTP and WebSocket clients. This is not set for
+	// unencrypted connections.
+	TLS struct {
+		// Negotiated protocol version, i.e. "TLS 1.3".
+		Version string
+		// Subject of the verified client certificate, if any.
+		ClientSubject string
+	}
+
+	// Auth contains the claims of authenticated HTTP and WebSocket clients, as
+	// attached to the request by an authenticating middleware through
+	// WithAuthClaims. It is nil if the client is not authenticated.
+	Auth map[string]interface{}
 }
 
 type peerInfoContextKey struct{}
 
+type authClaimsContextKey struct{}
+
+// WithAuthClaims returns a copy of ctx carrying the authentication claims of the
+// client. HTTP middlewares authenticating the requests of the RPC server can set
+// it on the request context to make the claims available to the method handlers
+// through PeerInfoFromContext.
+func WithAuthClaims(ctx context.Context, claims map[string]interface{}) context.Context {
+	return context.WithValue(ctx, authClaimsContextKey{}, claims)
+}
+
 // PeerInfoFromContext returns information about the client's network connection.
 // Use this with the context passed to RPC method handler functions.
 //
@@ -19,6 +19,7 @@ package rpc
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"io"
 	"net"
 	"os"
@@ -202,3 +203,67 @@ func TestServerBatchResponseSizeLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestServerMethodFilter(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	tests := []struct {
+		allow, deny []string
+		allowed     []string
+		denied      []string
+	}{
+		// Exact allowlist
+		{
+			allow:   []string{"test_null"},
+			allowed: []string{"test_null"},
+			denied:  []string{"test_noArgsRets", "rpc_modules"},
+		},
+		// Wildcard allowlist
+		{
+			allow:   []string{"test_*"},
+			allowed: []string{"test_null", "test_noArgsRets"},
+			denied:  []string{"rpc_modules", "nftest_echo"},
+		},
+		// Denylist only
+		{
+			deny:    []string{"test_no*"},
+			allowed: []string{"test_null", "rpc_modules"},
+			denied:  []string{"test_noArgsRets"},
+		},
+		// Denylist takes precedence over the allowlist
+		{
+			allow:   []string{"test_*", "rpc_modules"},
+			deny:    []string{"test_null"},
+			allowed: []string{"test_noArgsRets", "rpc_modules"},
+			denied:  []string{"test_null", "nftest_echo"},
+		},
+		// Empty lists remove the filter
+		{
+			allowed: []string{"test_null", "test_noArgsRets", "rpc_modules"},
+		},
+	}
+	for i, tt := range tests {
+		server.SetMethodFilter(tt.allow, tt.deny)
+		for _, method := range tt.allowed {
+			if err := client.Call(nil, method); err != nil {
+				t.Errorf("test %d: allowed method %s failed: %v", i, method, err)
+			}
+		}
+		for _, method := range tt.denied {
+			err := client.Call(nil, method)
+			if re, ok := err.(Error); !ok || re.ErrorCode() != (&methodNotFoundError{}).ErrorCode() {
+				t.Errorf("test %d: denied method %s error mismatch: %v", i, method, err)
+			}
+		}
+	}
+	// Subscriptions are filtered by their subscribe method
+	server.SetMethodFilter(nil, []string{"nftest_subscribe"})
+	if _, err := client.Subscribe(context.Background(), "nftest", make(chan int), "someSubscription", 1, 1); err == nil {
+		t.Error("denied subscription succeeded")
+	}
+}
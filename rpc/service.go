@@ -38,6 +38,37 @@ var (
 type serviceRegistry struct {
 	mu       sync.Mutex
 	services map[string]service
+	filter   *methodFilter // restricts the callable methods if non-nil
+}
+
+// methodFilter restricts the methods served to the ones matching the allowlist,
+// if any, and none of the denylist. Patterns ending with '*' match any method
+// with the preceding prefix, others match the exact method name.
+type methodFilter struct {
+	allow []string
+	deny  []string
+}
+
+// matchMethod reports whether the method matches any of the patterns.
+func matchMethod(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if pattern == method {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed reports whether the filter lets the method be called.
+func (f *methodFilter) allowed(method string) bool {
+	if len(f.allow) > 0 && !matchMethod(f.allow, method) {
+		return false
+	}
+	return !matchMethod(f.deny, method)
 }
 
 // service represents a registered object.
@@ -102,6 +133,20 @@ func (r *serviceRegistry) callback(method string) *callback {
 	return r.services[before].callbacks[after]
 }
 
+// setFilter installs the method filter, removing it if nil.
+func (r *serviceRegistry) setFilter(filter *methodFilter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.filter = filter
+}
+
+// allowed reports whether the method filter lets the given RPC method be called.
+func (r *serviceRegistry) allowed(method string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.filter == nil || r.filter.allowed(method)
+}
+
 // subscription returns a subscription callback in the given service.
 func (r *serviceRegistry) subscription(service, name string) *callback {
 	r.mu.Lock()
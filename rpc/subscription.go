@@ -45,6 +45,9 @@ var (
 
 	// ErrSubscriptionNotFound is returned when the notification for the given id is not found
 	ErrSubscriptionNotFound = errors.New("subscription not found")
+
+	// ErrSubscriptionCanceled is returned when the subscription was canceled by the server
+	ErrSubscriptionCanceled = errors.New("subscription canceled by server")
 )
 
 var globalGen = randomIDGenerator()
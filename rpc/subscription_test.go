@@ -184,6 +184,50 @@ func TestServerUnsubscribe(t *testing.T) {
 	}
 }
 
+// This test checks that subscriptions can be canceled on the server side.
+func TestServerCancelSubscription(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	service := &notificationTestService{unsubscribed: make(chan string, 1)}
+	server.RegisterName("nftest2", service)
+	client := DialInProc(server)
+	defer client.Close()
+
+	ch := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "nftest2", ch, "someSubscription", 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := <-ch; v != 0 {
+		t.Fatalf("wrong notification value %d", v)
+	}
+	if !server.CancelSubscription(ID(sub.subid)) {
+		t.Fatal("subscription not found on server")
+	}
+	if server.CancelSubscription(ID(sub.subid)) {
+		t.Fatal("subscription canceled twice")
+	}
+	// Check that the subscription is ended on both sides.
+	timeout := time.After(5 * time.Second)
+	select {
+	case id := <-service.unsubscribed:
+		if id != sub.subid {
+			t.Errorf("wrong subscription ID unsubscribed: %s", id)
+		}
+	case <-timeout:
+		t.Fatal("subscription not ended on server")
+	}
+	select {
+	case err := <-sub.Err():
+		if err == nil || err.Error() != ErrSubscriptionCanceled.Error() {
+			t.Errorf("wrong subscription error: %v", err)
+		}
+	case <-timeout:
+		t.Fatal("subscription not ended on client")
+	}
+}
+
 type subConfirmation struct {
 	reqid int
 	subid ID
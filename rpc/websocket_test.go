@@ -211,6 +211,41 @@ func TestWebsocketPeerInfo(t *testing.T) {
 	}
 }
 
+// This test checks that the auth claims attached to the request context are
+// reported in the peer info, and no TLS identity for unencrypted connections.
+func TestWebsocketPeerInfoAuth(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s  = newTestServer()
+		ws = s.WebsocketHandler([]string{"*"})
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithAuthClaims(r.Context(), map[string]interface{}{"sub": "tester"})
+			ws.ServeHTTP(w, r.WithContext(ctx))
+		}))
+		tsurl = "ws:" + strings.TrimPrefix(ts.URL, "http:")
+	)
+	defer s.Stop()
+	defer ts.Close()
+
+	c, err := DialWebsocket(context.Background(), tsurl, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var connInfo PeerInfo
+	if err := c.Call(&connInfo, "test_peerInfo"); err != nil {
+		t.Fatal(err)
+	}
+	if connInfo.TLS.Version != "" {
+		t.Errorf("TLS.Version set for unencrypted connection: %q", connInfo.TLS.Version)
+	}
+	if connInfo.Auth["sub"] != "tester" {
+		t.Errorf("wrong Auth %v", connInfo.Auth)
+	}
+}
+
 // This test checks that client handles WebSocket ping frames correctly.
 func TestClientWebsocketPing(t *testing.T) {
 	t.Parallel()
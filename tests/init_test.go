@@ -45,6 +45,7 @@ var (
 	executionSpecStateTestDir       = filepath.Join(".", "spec-tests", "fixtures", "state_tests")
 	executionSpecTransactionTestDir = filepath.Join(".", "spec-tests", "fixtures", "transaction_tests")
 	benchmarksDir                   = filepath.Join(".", "evm-benchmarks", "benchmarks")
+	localTransactionTestDir         = filepath.Join(".", "fixtures", "TransactionTests")
 )
 
 func readJSON(reader io.Reader, value interface{}) error {
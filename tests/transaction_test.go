@@ -17,10 +17,13 @@
 package tests
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 func TestTransaction(t *testing.T) {
@@ -65,6 +68,20 @@ func TestTransaction(t *testing.T) {
 	})
 }
 
+// Tests the transaction fixtures maintained in this repository, covering the
+// cases not in the upstream test suites.
+func TestLocalTransaction(t *testing.T) {
+	t.Parallel()
+
+	txt := new(testMatcher)
+	txt.walk(t, localTransactionTestDir, func(t *testing.T, name string, test *TransactionTest) {
+		cfg := params.MainnetChainConfig
+		if err := txt.checkFailure(t, test.Run(cfg)); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
 func TestExecutionSpecTransaction(t *testing.T) {
 	if !common.FileExist(executionSpecStateTestDir) {
 		t.Skipf("directory %s does not exist", executionSpecStateTestDir)
@@ -81,3 +98,31 @@ func TestExecutionSpecTransaction(t *testing.T) {
 		}
 	})
 }
+
+// Tests that the typed decoding errors only match their own fixture exception
+// categories.
+func TestTxExceptionCategories(t *testing.T) {
+	for _, tt := range []struct {
+		err      error
+		expected string
+		match    bool
+	}{
+		{types.ErrTxTruncated, "TransactionException.RLP_ERROR_EOF", true},
+		{types.ErrTxTruncated, "TransactionException.RLP_TOO_FEW_ELEMENTS", false},
+		{types.ErrTxTrailingBytes, "TransactionException.RLP_ERROR_SIZE", true},
+		{types.ErrTxTrailingBytes, "TransactionException.RLP_ERROR_EOF", false},
+		{types.ErrTxInvalidField, "TransactionException.RLP_INVALID_VALUE", true},
+		{types.ErrTxInvalidField, "TransactionException.RLP_TOO_MANY_ELEMENTS", true},
+		{types.ErrTxInvalidField, "TransactionException.RLP_ERROR_EOF", false},
+		{types.ErrTxTruncated, "TransactionException.TYPE_NOT_SUPPORTED|TransactionException.RLP_ERROR_EOF", true},
+		{fmt.Errorf("%w: unexpected EOF", types.ErrTxTruncated), "TransactionException.RLP_ERROR_EOF", true},
+		{fmt.Errorf("%w: %w", types.ErrTxNonCanonical, rlp.ErrCanonInt), "TransactionException.RLP_LEADING_ZEROS_NONCE", true},
+		{fmt.Errorf("%w: %w", types.ErrTxNonCanonical, rlp.ErrCanonInt), "TransactionException.RLP_ERROR_SIZE", false},
+		{fmt.Errorf("%w: %w", types.ErrTxNonCanonical, rlp.ErrCanonSize), "TransactionException.RLP_ERROR_SIZE_LEADING_ZEROS", true},
+		{fmt.Errorf("%w: %w", types.ErrTxNonCanonical, rlp.ErrCanonSize), "TransactionException.RLP_LEADING_ZEROS_NONCE", false},
+	} {
+		if err := checkTxException(tt.expected, tt.err); (err == nil) != tt.match {
+			t.Errorf("%v against %s: have mismatch %v, want match %v", tt.err, tt.expected, err, tt.match)
+		}
+	}
+}
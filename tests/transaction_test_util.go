@@ -17,7 +17,10 @@
 package tests
 
 import (
+	"errors"
 	"fmt"
+	stdmath "math"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -25,11 +28,13 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // TransactionTest checks RLP decoding and sender derivation of transactions.
 type TransactionTest struct {
 	Txbytes hexutil.Bytes `json:"txbytes"`
+	Lenient bool          `json:"lenient"` // Decode like UnmarshalBinaryLenient, not part of the upstream format
 	Result  map[string]*ttFork
 }
 
@@ -69,17 +74,35 @@ func (tt *TransactionTest) Run(config *params.ChainConfig) error {
 	if err := tt.validate(); err != nil {
 		return err
 	}
-	validateTx := func(rlpData hexutil.Bytes, signer types.Signer, isHomestead, isIstanbul, isShanghai bool) (sender common.Address, hash common.Hash, requiredGas uint64, err error) {
+	validateTx := func(rlpData hexutil.Bytes, signer types.Signer, isHomestead, isIstanbul, isShanghai, isCancun bool) (sender common.Address, hash common.Hash, requiredGas uint64, err error) {
 		tx := new(types.Transaction)
-		if err = tx.UnmarshalBinary(rlpData); err != nil {
+		if tt.Lenient {
+			err = tx.UnmarshalBinaryLenient(rlpData)
+		} else {
+			err = tx.UnmarshalBinary(rlpData)
+		}
+		if err != nil {
+			return
+		}
+		if err = tx.ValidateFees(); err != nil {
+			return
+		}
+		// The protocol doesn't limit the size of transactions
+		if err = tx.SanityCheck(stdmath.MaxInt); err != nil {
 			return
 		}
 		sender, err = types.Sender(signer, tx)
 		if err != nil {
 			return
 		}
+		// Blob sidecar, if the transaction is in its network form
+		if sidecar := tx.BlobTxSidecar(); isCancun && sidecar != nil {
+			if err = sidecar.ValidateAgainst(tx.BlobHashes()); err != nil {
+				return
+			}
+		}
 		// Intrinsic gas
-		requiredGas, err = core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, isHomestead, isIstanbul, isShanghai)
+		requiredGas, err = core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.IsContractCreation(), isHomestead, isIstanbul, isShanghai)
 		if err != nil {
 			return
 		}
@@ -96,29 +119,33 @@ func (tt *TransactionTest) Run(config *params.ChainConfig) error {
 		isHomestead bool
 		isIstanbul  bool
 		isShanghai  bool
+		isCancun    bool
 	}{
-		{"Frontier", types.FrontierSigner{}, tt.Result["Frontier"], false, false, false},
-		{"Homestead", types.HomesteadSigner{}, tt.Result["Homestead"], true, false, false},
-		{"EIP150", types.HomesteadSigner{}, tt.Result["EIP150"], true, false, false},
-		{"EIP158", types.NewEIP155Signer(config.ChainID), tt.Result["EIP158"], true, false, false},
-		{"Byzantium", types.NewEIP155Signer(config.ChainID), tt.Result["Byzantium"], true, false, false},
-		{"Constantinople", types.NewEIP155Signer(config.ChainID), tt.Result["Constantinople"], true, false, false},
-		{"Istanbul", types.NewEIP155Signer(config.ChainID), tt.Result["Istanbul"], true, true, false},
-		{"Berlin", types.NewEIP2930Signer(config.ChainID), tt.Result["Berlin"], true, true, false},
-		{"London", types.NewLondonSigner(config.ChainID), tt.Result["London"], true, true, false},
-		{"Paris", types.NewLondonSigner(config.ChainID), tt.Result["Paris"], true, true, false},
-		{"Shanghai", types.NewLondonSigner(config.ChainID), tt.Result["Shanghai"], true, true, true},
-		{"Cancun", types.NewCancunSigner(config.ChainID), tt.Result["Cancun"], true, true, true},
-		{"Prague", types.NewPragueSigner(config.ChainID), tt.Result["Prague"], true, true, true},
+		{"Frontier", types.FrontierSigner{}, tt.Result["Frontier"], false, false, false, false},
+		{"Homestead", types.HomesteadSigner{}, tt.Result["Homestead"], true, false, false, false},
+		{"EIP150", types.HomesteadSigner{}, tt.Result["EIP150"], true, false, false, false},
+		{"EIP158", types.NewEIP155Signer(config.ChainID), tt.Result["EIP158"], true, false, false, false},
+		{"Byzantium", types.NewEIP155Signer(config.ChainID), tt.Result["Byzantium"], true, false, false, false},
+		{"Constantinople", types.NewEIP155Signer(config.ChainID), tt.Result["Constantinople"], true, false, false, false},
+		{"Istanbul", types.NewEIP155Signer(config.ChainID), tt.Result["Istanbul"], true, true, false, false},
+		{"Berlin", types.NewEIP2930Signer(config.ChainID), tt.Result["Berlin"], true, true, false, false},
+		{"London", types.NewLondonSigner(config.ChainID), tt.Result["London"], true, true, false, false},
+		{"Paris", types.NewLondonSigner(config.ChainID), tt.Result["Paris"], true, true, false, false},
+		{"Shanghai", types.NewLondonSigner(config.ChainID), tt.Result["Shanghai"], true, true, true, false},
+		{"Cancun", types.NewCancunSigner(config.ChainID), tt.Result["Cancun"], true, true, true, true},
+		{"Prague", types.NewPragueSigner(config.ChainID), tt.Result["Prague"], true, true, true, true},
 	} {
 		if testcase.fork == nil {
 			continue
 		}
-		sender, hash, gas, err := validateTx(tt.Txbytes, testcase.signer, testcase.isHomestead, testcase.isIstanbul, testcase.isShanghai)
+		sender, hash, gas, err := validateTx(tt.Txbytes, testcase.signer, testcase.isHomestead, testcase.isIstanbul, testcase.isShanghai, testcase.isCancun)
 		if err != nil {
 			if testcase.fork.Hash != nil {
 				return fmt.Errorf("unexpected error: %v", err)
 			}
+			if err := checkTxException(*testcase.fork.Exception, err); err != nil {
+				return err
+			}
 			continue
 		}
 		if testcase.fork.Exception != nil {
@@ -139,3 +166,45 @@ func (tt *TransactionTest) Run(config *params.ChainConfig) error {
 	}
 	return nil
 }
+
+// txExceptions maps transaction validation errors to the exception categories
+// used by the execution spec fixtures, the first matching error deciding. An
+// error might match any of several categories, as the decoder doesn't tell e.g.
+// which field of a transaction is invalid.
+var txExceptions = []struct {
+	err        error
+	categories []string
+}{
+	{types.ErrTipAboveFeeCap, []string{"TransactionException.PRIORITY_GREATER_THAN_MAX_FEE_PER_GAS"}},
+	{types.ErrTxTypeNotSupported, []string{"TransactionException.TYPE_NOT_SUPPORTED"}},
+	{rlp.ErrCanonSize, []string{"TransactionException.RLP_ERROR_SIZE_LEADING_ZEROS"}},
+	{types.ErrTxNonCanonical, []string{"TransactionException.RLP_LEADING_ZEROS_"}},
+	{types.ErrTxTruncated, []string{"TransactionException.RLP_ERROR_EOF"}},
+	{types.ErrTxTrailingBytes, []string{"TransactionException.RLP_ERROR_SIZE"}},
+	{types.ErrTxInvalidField, []string{
+		"TransactionException.RLP_INVALID_",
+		"TransactionException.RLP_TOO_FEW_ELEMENTS",
+		"TransactionException.RLP_TOO_MANY_ELEMENTS",
+	}},
+}
+
+// checkTxException verifies that a rejection with a known exception category
+// matches the exception expected by the fixture. Fixtures not using categories
+// and errors without a known category are not checked.
+func checkTxException(expected string, err error) error {
+	if !strings.HasPrefix(expected, "TransactionException.") {
+		return nil
+	}
+	for _, exception := range txExceptions {
+		if !errors.Is(err, exception.err) {
+			continue
+		}
+		for _, category := range exception.categories {
+			if strings.Contains(expected, category) {
+				return nil
+			}
+		}
+		return fmt.Errorf("exception mismatch: got %s (%v), want %s", strings.Join(exception.categories, "|"), err, expected)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -424,3 +425,9 @@ func (t *StackTrie) Hash() common.Hash {
 	t.hash(n, nil)
 	return common.BytesToHash(n.val)
 }
+
+// DeriveTxHash recomputes the transactions root of the block using a stack trie,
+// along with the time spent on it.
+func DeriveTxHash(block *types.Block) (common.Hash, time.Duration) {
+	return block.DeriveTxHash(NewStackTrie(nil))
+}